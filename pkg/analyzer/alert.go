@@ -0,0 +1,146 @@
+package analyzer
+
+import (
+	"errors"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/model"
+	"time"
+)
+
+const (
+	// AlertSpikesAndDrops notifies about spikes and drops.
+	AlertSpikesAndDrops = AlertDirection(iota)
+
+	// AlertSpikes only notifies about spikes.
+	AlertSpikes
+
+	// AlertDrops only notifies about drops.
+	AlertDrops
+)
+
+// AlertDirection sets whether spikes, drops, or both are reported for an AlertRule.
+type AlertDirection int
+
+// AlertRule is the threshold for anomalies of a metric for a client.
+type AlertRule struct {
+	// ClientID is the client the rule is evaluated for.
+	ClientID int64
+
+	// Timezone is the timezone used for the daily and hourly series.
+	// Defaults to UTC.
+	Timezone *time.Location
+
+	// Metric is the metric to evaluate (FieldVisitors, FieldViews, FieldSessions, or FieldBounces).
+	// Defaults to FieldVisitors.
+	Metric Field
+
+	// Hourly evaluates the hourly series instead of the daily series.
+	Hourly bool
+
+	// Direction sets whether spikes, drops, or both are reported.
+	Direction AlertDirection
+
+	// Threshold is the minimum absolute robust z-score for an anomaly to be reported.
+	// Defaults to DefaultAnomalyThreshold.
+	Threshold float64
+
+	// Weeks is the number of previous weeks used for the baseline.
+	// Defaults to DefaultAnomalyWeeks.
+	Weeks int
+}
+
+// Notification is an anomaly matching an AlertRule.
+type Notification struct {
+	Rule    AlertRule
+	Anomaly model.AnomalyStats
+}
+
+// Notifier sends the notifications for alerts, like an email or a webhook.
+type Notifier interface {
+	// Notify sends the notifications.
+	Notify([]Notification) error
+}
+
+// NotifierFunc is a function implementing the Notifier interface.
+type NotifierFunc func([]Notification) error
+
+// Notify implements the Notifier interface.
+func (f NotifierFunc) Notify(notifications []Notification) error {
+	return f(notifications)
+}
+
+// AlertEvaluator evaluates alert rules using the Anomaly detection and passes all matching anomalies to a Notifier.
+type AlertEvaluator struct {
+	analyzer *Analyzer
+	notifier Notifier
+}
+
+// NewAlertEvaluator returns a new AlertEvaluator for given Analyzer and Notifier.
+func NewAlertEvaluator(analyzer *Analyzer, notifier Notifier) *AlertEvaluator {
+	return &AlertEvaluator{
+		analyzer: analyzer,
+		notifier: notifier,
+	}
+}
+
+// Evaluate evaluates the rules for given period and passes all notifications to the Notifier in a single call.
+// The Notifier won't be called if there are no notifications.
+// Rules failing to evaluate don't stop the evaluation of the others, and their errors are returned combined.
+func (evaluator *AlertEvaluator) Evaluate(rules []AlertRule, from, to time.Time) ([]Notification, error) {
+	notifications := make([]Notification, 0)
+	var errs []error
+
+	for _, rule := range rules {
+		filter := &Filter{
+			ClientID: rule.ClientID,
+			Timezone: rule.Timezone,
+			From:     from,
+			To:       to,
+		}
+		options := &AnomalyOptions{
+			Metric:    rule.Metric,
+			Weeks:     rule.Weeks,
+			Threshold: rule.Threshold,
+		}
+		var anomalies []model.AnomalyStats
+		var err error
+
+		if rule.Hourly {
+			anomalies, err = evaluator.analyzer.Anomaly.Hourly(filter, options)
+		} else {
+			anomalies, err = evaluator.analyzer.Anomaly.Daily(filter, options)
+		}
+
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		for _, anomaly := range anomalies {
+			if rule.matches(anomaly) {
+				notifications = append(notifications, Notification{
+					Rule:    rule,
+					Anomaly: anomaly,
+				})
+			}
+		}
+	}
+
+	if len(notifications) > 0 && evaluator.notifier != nil {
+		if err := evaluator.notifier.Notify(notifications); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return notifications, errors.Join(errs...)
+}
+
+func (rule *AlertRule) matches(anomaly model.AnomalyStats) bool {
+	switch rule.Direction {
+	case AlertSpikes:
+		return anomaly.Score > 0
+	case AlertDrops:
+		return anomaly.Score < 0
+	default:
+		return true
+	}
+}
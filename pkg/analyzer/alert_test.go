@@ -0,0 +1,65 @@
+package analyzer
+
+import (
+	"errors"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/db"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/model"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAlertEvaluator_Evaluate(t *testing.T) {
+	db.CleanupDB(t, dbClient)
+	saveAnomalyData(t)
+	var notified []Notification
+	calls := 0
+	evaluator := NewAlertEvaluator(NewAnalyzer(dbClient), NotifierFunc(func(notifications []Notification) error {
+		notified = notifications
+		calls++
+		return nil
+	}))
+	rules := []AlertRule{
+		{Direction: AlertDrops},
+		{Hourly: true},
+		{Direction: AlertSpikes},
+		{ClientID: 42},
+		{Threshold: 10},
+	}
+	notifications, err := evaluator.Evaluate(rules, util.PastDay(7), util.Today())
+	assert.NoError(t, err)
+	assert.Len(t, notifications, 2)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, notifications, notified)
+	assert.Equal(t, AlertDrops, notifications[0].Rule.Direction)
+	assert.Equal(t, util.PastDay(1), notifications[0].Anomaly.Time)
+	assert.True(t, notifications[1].Rule.Hourly)
+	assert.Less(t, notifications[1].Anomaly.Score, 0.0)
+	notifications, err = evaluator.Evaluate(rules[2:], util.PastDay(7), util.Today())
+	assert.NoError(t, err)
+	assert.Empty(t, notifications)
+	assert.Equal(t, 1, calls)
+	notifications, err = evaluator.Evaluate([]AlertRule{{Metric: FieldCountry}, {}}, util.PastDay(7), util.Today())
+	assert.ErrorIs(t, err, ErrInvalidAnomalyMetric)
+	assert.Len(t, notifications, 1)
+	evaluator = NewAlertEvaluator(NewAnalyzer(dbClient), NotifierFunc(func([]Notification) error {
+		return errors.New("test")
+	}))
+	notifications, err = evaluator.Evaluate(rules[:1], util.PastDay(7), util.Today())
+	assert.EqualError(t, err, "test")
+	assert.Len(t, notifications, 1)
+}
+
+func TestAlertRule_matches(t *testing.T) {
+	spike := model.AnomalyStats{Score: 5}
+	drop := model.AnomalyStats{Score: -5}
+	rule := AlertRule{}
+	assert.True(t, rule.matches(spike))
+	assert.True(t, rule.matches(drop))
+	rule.Direction = AlertSpikes
+	assert.True(t, rule.matches(spike))
+	assert.False(t, rule.matches(drop))
+	rule.Direction = AlertDrops
+	assert.False(t, rule.matches(spike))
+	assert.True(t, rule.matches(drop))
+}
@@ -1,9 +1,49 @@
 package analyzer
 
 import (
+	"errors"
 	"fmt"
 	"github.com/pirsch-analytics/pirsch/v6/pkg"
 	"github.com/pirsch-analytics/pirsch/v6/pkg/db"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/model"
+)
+
+var (
+	// ErrNoQueryMetrics is returned in case Analyzer.Query is called without metrics.
+	ErrNoQueryMetrics = errors.New("at least one metric is required")
+
+	// ErrInvalidQueryDimension is returned in case Analyzer.Query is called with an unsupported dimension.
+	ErrInvalidQueryDimension = errors.New("unsupported dimension")
+
+	// ErrInvalidQueryMetric is returned in case Analyzer.Query is called with an unsupported metric.
+	ErrInvalidQueryMetric = errors.New("unsupported metric")
+
+	// ErrInvalidQueryCombination is returned in case Analyzer.Query is called with dimensions and metrics that cannot be combined.
+	ErrInvalidQueryCombination = errors.New("the dimensions and metrics cannot be combined")
+
+	// ErrInvalidQuerySort is returned in case Analyzer.Query is called with a Filter.Sort field that is neither a dimension nor a metric.
+	ErrInvalidQuerySort = errors.New("results can only be sorted by a dimension or metric")
+
+	queryDimensions = append([]Field{
+		FieldPath,
+		FieldEventName,
+	}, goalDimensions...)
+	queryMetrics = []Field{
+		FieldVisitors,
+		FieldSessions,
+		FieldViews,
+		FieldBounces,
+		FieldBounceRate,
+		FieldRelativeVisitors,
+		FieldCR,
+	}
+	queryEventMetrics = []Field{
+		FieldVisitors,
+		FieldSessions,
+		FieldViews,
+		FieldRelativeVisitors,
+		FieldCR,
+	}
 )
 
 // Analyzer provides an interface to analyze statistics.
@@ -15,12 +55,16 @@ type Analyzer struct {
 	UTM          UTM
 	Events       Events
 	Time         Time
+	Funnel       Funnel
+	Goals        Goals
+	Anomaly      Anomaly
 	Options      FilterOptions
+	store        db.Store
 }
 
 // NewAnalyzer returns a new Analyzer for given Store.
 func NewAnalyzer(store db.Store) *Analyzer {
-	analyzer := new(Analyzer)
+	analyzer := &Analyzer{store: store}
 	analyzer.Visitors = Visitors{
 		analyzer: analyzer,
 		store:    store,
@@ -49,6 +93,18 @@ func NewAnalyzer(store db.Store) *Analyzer {
 		analyzer: analyzer,
 		store:    store,
 	}
+	analyzer.Funnel = Funnel{
+		analyzer: analyzer,
+		store:    store,
+	}
+	analyzer.Goals = Goals{
+		analyzer: analyzer,
+		store:    store,
+	}
+	analyzer.Anomaly = Anomaly{
+		analyzer: analyzer,
+		store:    store,
+	}
 	analyzer.Options = FilterOptions{
 		analyzer: analyzer,
 		store:    store,
@@ -56,6 +112,142 @@ func NewAnalyzer(store db.Store) *Analyzer {
 	return analyzer
 }
 
+// Query returns the metrics grouped by any combination of dimensions.
+// Supported dimensions are FieldPath, FieldEventName, and all session attributes, like FieldCountry, FieldBrowser, FieldReferrer, or FieldEntryPath.
+// Supported metrics are FieldVisitors, FieldSessions, FieldViews, FieldBounces, FieldBounceRate, FieldRelativeVisitors, and FieldCR.
+// FieldPath and FieldEventName cannot be combined with each other, FieldEntryPath, or FieldExitPath,
+// and FieldEventName cannot be combined with FieldBounces and FieldBounceRate.
+// The results are sorted by the first metric and the dimensions, unless Filter.Sort is set.
+func (analyzer *Analyzer) Query(filter *Filter, dimensions, metrics []Field) ([]model.QueryStats, error) {
+	if len(metrics) == 0 {
+		return nil, ErrNoQueryMetrics
+	}
+
+	filter = analyzer.getFilter(filter)
+	dimensions = analyzer.uniqueFields(dimensions)
+	metrics = analyzer.uniqueFields(metrics)
+
+	if err := analyzer.validateQuery(filter, dimensions, metrics); err != nil {
+		return nil, err
+	}
+
+	fields := make([]Field, 0, len(dimensions)+len(metrics)+3)
+	fields = append(fields, dimensions...)
+
+	// add the metrics other metrics depend on
+	if (filter.fieldsContain(metrics, FieldRelativeVisitors) || filter.fieldsContain(metrics, FieldCR)) &&
+		!filter.fieldsContain(metrics, FieldVisitors) {
+		fields = append(fields, FieldVisitors)
+	}
+
+	if filter.fieldsContain(metrics, FieldBounceRate) {
+		if !filter.fieldsContain(metrics, FieldSessions) {
+			fields = append(fields, FieldSessions)
+		}
+
+		if !filter.fieldsContain(metrics, FieldBounces) {
+			fields = append(fields, FieldBounces)
+		}
+	}
+
+	fields = append(fields, metrics...)
+	orderBy := make([]Field, 0, len(dimensions)+1)
+	orderBy = append(orderBy, metrics[0])
+	orderBy = append(orderBy, dimensions...)
+	q, args := filter.buildQuery(fields, dimensions, orderBy)
+	rows, err := analyzer.store.SelectRows(q, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]model.QueryStats, 0, len(rows))
+
+	for _, row := range rows {
+		result := model.QueryStats{
+			Dimensions: make(map[string]any, len(dimensions)),
+			Metrics:    make(map[string]float64, len(metrics)),
+		}
+
+		for _, dimension := range dimensions {
+			result.Dimensions[dimension.Name] = row[dimension.Name]
+		}
+
+		for _, metric := range metrics {
+			switch value := row[metric.Name].(type) {
+			case int:
+				result.Metrics[metric.Name] = float64(value)
+			case float64:
+				result.Metrics[metric.Name] = value
+			}
+		}
+
+		stats = append(stats, result)
+	}
+
+	return stats, nil
+}
+
+func (analyzer *Analyzer) validateQuery(filter *Filter, dimensions, metrics []Field) error {
+	for _, dimension := range dimensions {
+		if !filter.fieldsContain(queryDimensions, dimension) {
+			return ErrInvalidQueryDimension
+		}
+	}
+
+	for _, metric := range metrics {
+		if !filter.fieldsContain(queryMetrics, metric) {
+			return ErrInvalidQueryMetric
+		}
+	}
+
+	path := filter.fieldsContain(dimensions, FieldPath)
+	eventName := filter.fieldsContain(dimensions, FieldEventName)
+	entryExit := filter.fieldsContain(dimensions, FieldEntryPath) || filter.fieldsContain(dimensions, FieldExitPath)
+
+	if (path || eventName) && entryExit || path && eventName {
+		return ErrInvalidQueryCombination
+	}
+
+	if eventName {
+		for _, metric := range metrics {
+			if !filter.fieldsContain(queryEventMetrics, metric) {
+				return ErrInvalidQueryCombination
+			}
+		}
+	}
+
+	for _, sort := range filter.Sort {
+		if !analyzer.fieldsContainName(dimensions, sort.Field.Name) && !analyzer.fieldsContainName(metrics, sort.Field.Name) {
+			return ErrInvalidQuerySort
+		}
+	}
+
+	return nil
+}
+
+func (analyzer *Analyzer) uniqueFields(fields []Field) []Field {
+	unique := make([]Field, 0, len(fields))
+
+	for _, field := range fields {
+		if !analyzer.fieldsContainName(unique, field.Name) {
+			unique = append(unique, field)
+		}
+	}
+
+	return unique
+}
+
+func (analyzer *Analyzer) fieldsContainName(haystack []Field, name string) bool {
+	for i := range haystack {
+		if haystack[i].Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (analyzer *Analyzer) timeOnPageQuery(filter *Filter) string {
 	timeOnPage := "neighbor(duration_seconds, 1, 0)"
 
@@ -51,18 +51,109 @@ func TestAnalyzer_NoData(t *testing.T) {
 	assert.NoError(t, err)
 	_, err = analyzer.Device.ScreenClass(nil)
 	assert.NoError(t, err)
+	_, err = analyzer.Device.DeviceType(nil)
+	assert.NoError(t, err)
+	_, err = analyzer.Device.DeviceModel(nil)
+	assert.NoError(t, err)
 	_, err = analyzer.Demographics.Languages(nil)
 	assert.NoError(t, err)
 	_, err = analyzer.Demographics.Countries(nil)
 	assert.NoError(t, err)
+	_, err = analyzer.Demographics.Regions(nil)
+	assert.NoError(t, err)
 	_, err = analyzer.Demographics.Cities(nil)
 	assert.NoError(t, err)
+	_, err = analyzer.Demographics.TimeZones(nil)
+	assert.NoError(t, err)
+	_, err = analyzer.Demographics.Networks(nil)
+	assert.NoError(t, err)
 	_, err = analyzer.Time.AvgSessionDuration(nil)
 	assert.NoError(t, err)
 	_, err = analyzer.Time.AvgTimeOnPage(nil)
 	assert.NoError(t, err)
 }
 
+func TestAnalyzer_Query(t *testing.T) {
+	db.CleanupDB(t, dbClient)
+	day := util.PastDay(1)
+	saveSessions(t, [][]model.Session{
+		{
+			{Sign: 1, VisitorID: 1, SessionID: 1, Time: day, Start: day, EntryPath: "/", ExitPath: "/", PageViews: 1, IsBounce: true, CountryCode: "de", Browser: pkg.BrowserChrome},
+			{Sign: 1, VisitorID: 2, SessionID: 1, Time: day, Start: day, EntryPath: "/", ExitPath: "/pricing", PageViews: 2, CountryCode: "de", Browser: pkg.BrowserChrome},
+			{Sign: 1, VisitorID: 3, SessionID: 1, Time: day, Start: day, EntryPath: "/pricing", ExitPath: "/pricing", PageViews: 1, IsBounce: true, CountryCode: "de", Browser: pkg.BrowserFirefox},
+			{Sign: 1, VisitorID: 4, SessionID: 1, Time: day, Start: day, EntryPath: "/", ExitPath: "/", PageViews: 1, IsBounce: true, CountryCode: "gb", Browser: pkg.BrowserChrome},
+		},
+	})
+	assert.NoError(t, dbClient.SavePageViews([]model.PageView{
+		{VisitorID: 1, SessionID: 1, Time: day, Path: "/", CountryCode: "de", Browser: pkg.BrowserChrome},
+		{VisitorID: 2, SessionID: 1, Time: day, Path: "/", CountryCode: "de", Browser: pkg.BrowserChrome},
+		{VisitorID: 2, SessionID: 1, Time: day.Add(time.Minute), Path: "/pricing", CountryCode: "de", Browser: pkg.BrowserChrome},
+		{VisitorID: 3, SessionID: 1, Time: day, Path: "/pricing", CountryCode: "de", Browser: pkg.BrowserFirefox},
+		{VisitorID: 4, SessionID: 1, Time: day, Path: "/", CountryCode: "gb", Browser: pkg.BrowserChrome},
+	}))
+	time.Sleep(time.Millisecond * 20)
+	analyzer := NewAnalyzer(dbClient)
+	filter := &Filter{From: util.PastDay(2), To: util.Today()}
+	stats, err := analyzer.Query(filter, []Field{FieldCountry, FieldBrowser}, []Field{FieldVisitors, FieldViews, FieldBounceRate})
+	assert.NoError(t, err)
+	assert.Len(t, stats, 3)
+	assert.Equal(t, "de", stats[0].Dimensions["country_code"])
+	assert.Equal(t, pkg.BrowserChrome, stats[0].Dimensions["browser"])
+	assert.InDelta(t, 2, stats[0].Metrics["visitors"], 0.01)
+	assert.InDelta(t, 3, stats[0].Metrics["views"], 0.01)
+	assert.InDelta(t, 0.5, stats[0].Metrics["bounce_rate"], 0.01)
+	assert.Len(t, stats[0].Dimensions, 2)
+	assert.Len(t, stats[0].Metrics, 3)
+	assert.Equal(t, "de", stats[1].Dimensions["country_code"])
+	assert.Equal(t, pkg.BrowserFirefox, stats[1].Dimensions["browser"])
+	assert.Equal(t, "gb", stats[2].Dimensions["country_code"])
+	stats, err = analyzer.Query(filter, []Field{FieldReferrer, FieldEntryPath}, []Field{FieldVisitors, FieldRelativeVisitors})
+	assert.NoError(t, err)
+	assert.Len(t, stats, 2)
+	assert.Equal(t, "/", stats[0].Dimensions["entry_path"])
+	assert.InDelta(t, 3, stats[0].Metrics["visitors"], 0.01)
+	assert.InDelta(t, 0.75, stats[0].Metrics["relative_visitors"], 0.01)
+	stats, err = analyzer.Query(filter, []Field{FieldPath}, []Field{FieldVisitors, FieldViews})
+	assert.NoError(t, err)
+	assert.Len(t, stats, 2)
+	assert.Equal(t, "/", stats[0].Dimensions["path"])
+	assert.InDelta(t, 3, stats[0].Metrics["views"], 0.01)
+	stats, err = analyzer.Query(&Filter{From: util.PastDay(2), To: util.Today(), Sort: []Sort{{Field: FieldCountry, Direction: pkg.DirectionDESC}}, Limit: 1}, []Field{FieldCountry}, []Field{FieldSessions})
+	assert.NoError(t, err)
+	assert.Len(t, stats, 1)
+	assert.Equal(t, "gb", stats[0].Dimensions["country_code"])
+	assert.InDelta(t, 1, stats[0].Metrics["sessions"], 0.01)
+	stats, err = analyzer.Query(filter, nil, []Field{FieldVisitors, FieldVisitors})
+	assert.NoError(t, err)
+	assert.Len(t, stats, 1)
+	assert.Empty(t, stats[0].Dimensions)
+	assert.InDelta(t, 4, stats[0].Metrics["visitors"], 0.01)
+	_, err = analyzer.Query(getMaxFilter(""), []Field{FieldCountry, FieldOS}, []Field{FieldVisitors, FieldBounces, FieldCR})
+	assert.NoError(t, err)
+	_, err = analyzer.Query(getMaxFilter("event"), []Field{FieldEventName, FieldUTMSource}, []Field{FieldVisitors, FieldViews})
+	assert.NoError(t, err)
+}
+
+func TestAnalyzer_validateQuery(t *testing.T) {
+	analyzer := new(Analyzer)
+	filter := new(Filter)
+	assert.NoError(t, analyzer.validateQuery(filter, []Field{FieldCountry, FieldBrowser}, []Field{FieldVisitors}))
+	assert.NoError(t, analyzer.validateQuery(filter, []Field{FieldPath, FieldCountry}, []Field{FieldBounceRate}))
+	assert.NoError(t, analyzer.validateQuery(filter, []Field{FieldEventName}, []Field{FieldViews, FieldCR}))
+	assert.ErrorIs(t, analyzer.validateQuery(filter, []Field{FieldVisitors}, []Field{FieldVisitors}), ErrInvalidQueryDimension)
+	assert.ErrorIs(t, analyzer.validateQuery(filter, []Field{FieldCountry}, []Field{FieldCountry}), ErrInvalidQueryMetric)
+	assert.ErrorIs(t, analyzer.validateQuery(filter, []Field{FieldPath, FieldEntryPath}, []Field{FieldVisitors}), ErrInvalidQueryCombination)
+	assert.ErrorIs(t, analyzer.validateQuery(filter, []Field{FieldPath, FieldEventName}, []Field{FieldVisitors}), ErrInvalidQueryCombination)
+	assert.ErrorIs(t, analyzer.validateQuery(filter, []Field{FieldEventName}, []Field{FieldBounces}), ErrInvalidQueryCombination)
+	filter.Sort = []Sort{{Field: FieldBrowser}}
+	assert.ErrorIs(t, analyzer.validateQuery(filter, []Field{FieldCountry}, []Field{FieldVisitors}), ErrInvalidQuerySort)
+	filter.Sort = []Sort{{Field: FieldVisitors}}
+	assert.NoError(t, analyzer.validateQuery(filter, []Field{FieldCountry}, []Field{FieldVisitors}))
+	_, err := NewAnalyzer(nil).Query(nil, []Field{FieldCountry}, nil)
+	assert.ErrorIs(t, err, ErrNoQueryMetrics)
+	assert.Len(t, analyzer.uniqueFields([]Field{FieldCountry, FieldVisitors, FieldCountry}), 2)
+}
+
 func getMaxFilter(eventName string) *Filter {
 	var events []string
 
@@ -79,7 +170,11 @@ func getMaxFilter(eventName string) *Filter {
 		ExitPath:       []string{"/exit"},
 		Language:       []string{"en"},
 		Country:        []string{"en"},
+		Region:         []string{"gb-eng"},
 		City:           []string{"London"},
+		TimeZone:       []string{"Europe/London"},
+		ASN:            []string{"20712"},
+		ASOrganization: []string{"Andrews & Arnold Ltd"},
 		Referrer:       []string{"ref"},
 		ReferrerName:   []string{"refname"},
 		OS:             []string{pkg.OSWindows},
@@ -87,6 +182,7 @@ func getMaxFilter(eventName string) *Filter {
 		Browser:        []string{pkg.BrowserChrome},
 		BrowserVersion: []string{"90"},
 		Platform:       pkg.PlatformDesktop,
+		DeviceType:     []string{pkg.DeviceTypeDesktop},
 		ScreenClass:    []string{"XL"},
 		UTMSource:      []string{"source"},
 		UTMMedium:      []string{"medium"},
@@ -0,0 +1,361 @@
+package analyzer
+
+import (
+	"errors"
+	"github.com/pirsch-analytics/pirsch/v6/pkg"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/db"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/model"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// DefaultAnomalyWeeks is the default number of weeks used for the seasonal baseline.
+	DefaultAnomalyWeeks = 4
+
+	// DefaultAnomalyThreshold is the default minimum absolute robust z-score for a value to be an anomaly.
+	DefaultAnomalyThreshold = 3.5
+
+	// scales the median absolute deviation to be comparable to the standard deviation
+	madScale = 0.6745
+
+	// the median absolute deviation is at least one visitor/view/session/bounce, so that constant baselines don't result in infinite scores
+	minMAD = 1
+)
+
+var (
+	// ErrInvalidAnomalyMetric is returned in case anomalies are detected for an unsupported metric.
+	ErrInvalidAnomalyMetric = errors.New("anomalies can only be detected for visitors, views, sessions, and bounces")
+
+	anomalyMetrics = []Field{
+		FieldVisitors,
+		FieldViews,
+		FieldSessions,
+		FieldBounces,
+	}
+)
+
+// AnomalyOptions are the options used to detect anomalies.
+type AnomalyOptions struct {
+	// Metric is the metric to detect anomalies for (FieldVisitors, FieldViews, FieldSessions, or FieldBounces).
+	// Defaults to FieldVisitors.
+	Metric Field
+
+	// Weeks is the number of previous weeks used for the baseline.
+	// Defaults to DefaultAnomalyWeeks.
+	Weeks int
+
+	// Threshold is the minimum absolute robust z-score for a value to be an anomaly.
+	// Defaults to DefaultAnomalyThreshold.
+	Threshold float64
+}
+
+func (options *AnomalyOptions) validate() error {
+	if options.Metric.Name == "" {
+		options.Metric = FieldVisitors
+	}
+
+	found := false
+
+	for _, metric := range anomalyMetrics {
+		if metric.Name == options.Metric.Name {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return ErrInvalidAnomalyMetric
+	}
+
+	if options.Weeks <= 0 {
+		options.Weeks = DefaultAnomalyWeeks
+	}
+
+	if options.Threshold <= 0 {
+		options.Threshold = DefaultAnomalyThreshold
+	}
+
+	return nil
+}
+
+// Anomaly detects unusual traffic, like a broken tracking snippet or a spike in visitors.
+// Each value is compared to a seasonal baseline (the same day of week or hour of week for the previous weeks)
+// using the robust z-score based on the median absolute deviation.
+type Anomaly struct {
+	analyzer *Analyzer
+	store    db.Store
+}
+
+// Daily returns the anomalies for each day in the Filter period.
+// Days that haven't completed yet and days without any traffic in the baseline are skipped.
+func (anomaly *Anomaly) Daily(filter *Filter, options *AnomalyOptions) ([]model.AnomalyStats, error) {
+	filter, options, err := anomaly.init(filter, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	to := anomaly.lastCompleteDay(filter)
+
+	if to.Before(filter.From) {
+		return []model.AnomalyStats{}, nil
+	}
+
+	seriesFilter := anomaly.seriesFilter(filter)
+	seriesFilter.From = filter.From.AddDate(0, 0, -7*options.Weeks)
+	seriesFilter.To = to
+	seriesFilter.Period = pkg.PeriodDay
+	stats, err := anomaly.analyzer.Visitors.ByPeriod(seriesFilter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[time.Time]int, len(stats))
+
+	for _, s := range stats {
+		values[filter.toDate(s.Day.Time)] = anomaly.metric(options.Metric, s.Visitors, s.Views, s.Sessions, s.Bounces)
+	}
+
+	anomalies := make([]model.AnomalyStats, 0)
+
+	for day := filter.From; !day.After(to); day = day.AddDate(0, 0, 1) {
+		baseline := make([]int, 0, options.Weeks)
+
+		for week := 1; week <= options.Weeks; week++ {
+			baseline = append(baseline, values[day.AddDate(0, 0, -7*week)])
+		}
+
+		t := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, filter.Timezone)
+
+		if a, ok := anomaly.score(t, options, values[day], baseline); ok {
+			anomalies = append(anomalies, a)
+		}
+	}
+
+	return anomalies, nil
+}
+
+// Hourly returns the anomalies for each hour in the Filter period.
+// Hours that haven't completed yet and hours without any traffic in the baseline are skipped.
+func (anomaly *Anomaly) Hourly(filter *Filter, options *AnomalyOptions) ([]model.AnomalyStats, error) {
+	filter, options, err := anomaly.init(filter, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().In(filter.Timezone)
+	today := filter.toDate(now)
+	to := filter.To
+
+	if to.After(today) {
+		to = today
+	}
+
+	days, err := anomaly.hourlySeries(filter, options, filter.From.AddDate(0, 0, -7*options.Weeks), to)
+
+	if err != nil {
+		return nil, err
+	}
+
+	hours := func(day time.Time) []int {
+		if values, ok := days[day]; ok {
+			return values
+		}
+
+		return make([]int, 24)
+	}
+	anomalies := make([]model.AnomalyStats, 0)
+
+	for day := filter.From; !day.After(to); day = day.AddDate(0, 0, 1) {
+		current := hours(day)
+		baselines := make([][]int, 0, options.Weeks)
+
+		for week := 1; week <= options.Weeks; week++ {
+			baselines = append(baselines, hours(day.AddDate(0, 0, -7*week)))
+		}
+
+		for hour := 0; hour < 24; hour++ {
+			if day.Equal(today) && hour >= now.Hour() {
+				break
+			}
+
+			baseline := make([]int, 0, len(baselines))
+
+			for _, values := range baselines {
+				baseline = append(baseline, values[hour])
+			}
+
+			t := time.Date(day.Year(), day.Month(), day.Day(), hour, 0, 0, 0, filter.Timezone)
+
+			if a, ok := anomaly.score(t, options, current[hour], baseline); ok {
+				anomalies = append(anomalies, a)
+			}
+		}
+	}
+
+	return anomalies, nil
+}
+
+// hourlySeries returns the metric for each hour of the days in given period, grouped by day.
+func (anomaly *Anomaly) hourlySeries(filter *Filter, options *AnomalyOptions, from, to time.Time) (map[time.Time][]int, error) {
+	seriesFilter := anomaly.seriesFilter(filter)
+	seriesFilter.From = from
+	seriesFilter.To = to
+	seriesFilter.Period = pkg.PeriodDay
+	q, args := seriesFilter.buildQuery([]Field{
+		FieldDay,
+		FieldHour,
+		options.Metric,
+	}, []Field{
+		FieldDay,
+		FieldHour,
+	}, nil)
+	rows, err := anomaly.store.SelectRows(q, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	days := make(map[time.Time][]int)
+
+	for _, row := range rows {
+		day, ok := row[FieldDay.Name].(time.Time)
+		hour, _ := row[FieldHour.Name].(int)
+
+		if !ok || hour < 0 || hour >= 24 {
+			continue
+		}
+
+		day = filter.toDate(day)
+
+		if days[day] == nil {
+			days[day] = make([]int, 24)
+		}
+
+		days[day][hour], _ = row[options.Metric.Name].(int)
+	}
+
+	return days, nil
+}
+
+func (anomaly *Anomaly) init(filter *Filter, options *AnomalyOptions) (*Filter, *AnomalyOptions, error) {
+	filter = anomaly.analyzer.getFilter(filter)
+
+	if filter.From.IsZero() || filter.To.IsZero() {
+		return nil, nil, ErrNoPeriodOrDay
+	}
+
+	if options == nil {
+		options = new(AnomalyOptions)
+	} else {
+		optionsCopy := *options
+		options = &optionsCopy
+	}
+
+	if err := options.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	return filter, options, nil
+}
+
+func (anomaly *Anomaly) seriesFilter(filter *Filter) *Filter {
+	filterCopy := *filter
+	filterCopy.IncludeTime = false
+	filterCopy.IncludeCR = false
+	filterCopy.Compare = pkg.CompareNone
+	filterCopy.Sort = nil
+	filterCopy.Offset = 0
+	filterCopy.Limit = 0
+	return &filterCopy
+}
+
+func (anomaly *Anomaly) lastCompleteDay(filter *Filter) time.Time {
+	yesterday := filter.toDate(time.Now().In(filter.Timezone)).AddDate(0, 0, -1)
+
+	if filter.To.After(yesterday) {
+		return yesterday
+	}
+
+	return filter.To
+}
+
+func (anomaly *Anomaly) metric(metric Field, visitors, views, sessions, bounces int) int {
+	switch metric.Name {
+	case FieldViews.Name:
+		return views
+	case FieldSessions.Name:
+		return sessions
+	case FieldBounces.Name:
+		return bounces
+	default:
+		return visitors
+	}
+}
+
+func (anomaly *Anomaly) score(t time.Time, options *AnomalyOptions, value int, baseline []int) (model.AnomalyStats, bool) {
+	empty := true
+
+	for _, v := range baseline {
+		if v != 0 {
+			empty = false
+			break
+		}
+	}
+
+	if empty {
+		return model.AnomalyStats{}, false
+	}
+
+	score, expected := robustZScore(float64(value), baseline)
+
+	if math.Abs(score) < options.Threshold {
+		return model.AnomalyStats{}, false
+	}
+
+	return model.AnomalyStats{
+		Time:     t,
+		Metric:   options.Metric.Name,
+		Value:    value,
+		Expected: expected,
+		Score:    score,
+	}, true
+}
+
+// robustZScore returns the robust z-score of the value compared to the baseline and the median of the baseline.
+func robustZScore(value float64, baseline []int) (float64, float64) {
+	values := make([]float64, len(baseline))
+
+	for i, v := range baseline {
+		values[i] = float64(v)
+	}
+
+	m := median(values)
+
+	for i := range values {
+		values[i] = math.Abs(values[i] - m)
+	}
+
+	mad := math.Max(median(values), minMAD)
+	return madScale * (value - m) / mad, m
+}
+
+// median returns the median of the values. The values will be sorted in place.
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sort.Float64s(values)
+	n := len(values)
+
+	if n%2 == 0 {
+		return (values[n/2-1] + values[n/2]) / 2
+	}
+
+	return values[n/2]
+}
@@ -0,0 +1,108 @@
+package analyzer
+
+import (
+	"github.com/pirsch-analytics/pirsch/v6/pkg/db"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/model"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAnomaly_Daily(t *testing.T) {
+	db.CleanupDB(t, dbClient)
+	saveAnomalyData(t)
+	analyzer := NewAnalyzer(dbClient)
+	stats, err := analyzer.Anomaly.Daily(&Filter{From: util.PastDay(7), To: util.Today()}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, stats, 1)
+	assert.Equal(t, util.PastDay(1), stats[0].Time)
+	assert.Equal(t, "visitors", stats[0].Metric)
+	assert.Equal(t, 1, stats[0].Value)
+	assert.InDelta(t, 10, stats[0].Expected, 0.01)
+	assert.InDelta(t, -6.07, stats[0].Score, 0.01)
+	stats, err = analyzer.Anomaly.Daily(&Filter{From: util.PastDay(7), To: util.Today()}, &AnomalyOptions{Threshold: 10})
+	assert.NoError(t, err)
+	assert.Empty(t, stats)
+	stats, err = analyzer.Anomaly.Daily(&Filter{From: util.PastDay(7), To: util.Today()}, &AnomalyOptions{Metric: FieldSessions, Weeks: 2})
+	assert.NoError(t, err)
+	assert.Len(t, stats, 1)
+	assert.Equal(t, "sessions", stats[0].Metric)
+	stats, err = analyzer.Anomaly.Daily(&Filter{From: util.Today(), To: util.Today()}, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, stats)
+	_, err = analyzer.Anomaly.Daily(getMaxFilter(""), nil)
+	assert.NoError(t, err)
+	_, err = analyzer.Anomaly.Daily(getMaxFilter("event"), nil)
+	assert.NoError(t, err)
+	_, err = analyzer.Anomaly.Daily(nil, nil)
+	assert.ErrorIs(t, err, ErrNoPeriodOrDay)
+	_, err = analyzer.Anomaly.Daily(&Filter{From: util.PastDay(7), To: util.Today()}, &AnomalyOptions{Metric: FieldCountry})
+	assert.ErrorIs(t, err, ErrInvalidAnomalyMetric)
+}
+
+func TestAnomaly_Hourly(t *testing.T) {
+	db.CleanupDB(t, dbClient)
+	saveAnomalyData(t)
+	analyzer := NewAnalyzer(dbClient)
+	stats, err := analyzer.Anomaly.Hourly(&Filter{From: util.PastDay(1), To: util.PastDay(1)}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, stats, 1)
+	assert.Equal(t, util.PastDay(1).Add(time.Hour*10), stats[0].Time)
+	assert.Equal(t, 1, stats[0].Value)
+	assert.InDelta(t, 10, stats[0].Expected, 0.01)
+	assert.InDelta(t, -6.07, stats[0].Score, 0.01)
+	stats, err = analyzer.Anomaly.Hourly(&Filter{From: util.PastDay(2), To: util.PastDay(2)}, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, stats)
+	_, err = analyzer.Anomaly.Hourly(&Filter{From: util.Today(), To: util.Today(), Timezone: time.FixedZone("test", 3600*5)}, nil)
+	assert.NoError(t, err)
+	_, err = analyzer.Anomaly.Hourly(getMaxFilter(""), &AnomalyOptions{Metric: FieldViews, Weeks: 1})
+	assert.NoError(t, err)
+	_, err = analyzer.Anomaly.Hourly(getMaxFilter("event"), &AnomalyOptions{Metric: FieldBounces, Weeks: 1})
+	assert.NoError(t, err)
+	_, err = analyzer.Anomaly.Hourly(nil, nil)
+	assert.ErrorIs(t, err, ErrNoPeriodOrDay)
+}
+
+func TestRobustZScore(t *testing.T) {
+	score, expected := robustZScore(30, []int{10, 12, 8, 11})
+	assert.InDelta(t, 10.5, expected, 0.01)
+	assert.InDelta(t, 13.15, score, 0.01)
+	score, _ = robustZScore(0, []int{10, 12, 8, 11})
+	assert.InDelta(t, -7.08, score, 0.01)
+	score, expected = robustZScore(7, []int{5, 5, 5})
+	assert.InDelta(t, 5, expected, 0.01)
+	assert.InDelta(t, 1.35, score, 0.01)
+	score, _ = robustZScore(5, []int{5, 5, 5})
+	assert.Zero(t, score)
+	assert.InDelta(t, 2.5, median([]float64{4, 1, 3, 2}), 0.01)
+	assert.InDelta(t, 2, median([]float64{3, 1, 2}), 0.01)
+	assert.Zero(t, median(nil))
+}
+
+func saveAnomalyData(t *testing.T) {
+	sessions := make([]model.Session, 0)
+	visitorID := uint64(1)
+	days := []struct {
+		day      int
+		visitors int
+	}{
+		{29, 10},
+		{22, 10},
+		{15, 11},
+		{8, 10},
+		{1, 1},
+	}
+
+	for _, d := range days {
+		day := util.PastDay(d.day).Add(time.Hour * 10)
+
+		for i := 0; i < d.visitors; i++ {
+			sessions = append(sessions, model.Session{Sign: 1, VisitorID: visitorID, SessionID: 1, Time: day, Start: day, EntryPath: "/", ExitPath: "/", PageViews: 1})
+			visitorID++
+		}
+	}
+
+	saveSessions(t, [][]model.Session{sessions})
+}
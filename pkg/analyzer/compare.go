@@ -0,0 +1,138 @@
+package analyzer
+
+import (
+	"errors"
+	"github.com/pirsch-analytics/pirsch/v6/pkg"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/model"
+	"math"
+	"sort"
+	"strings"
+)
+
+// ErrNoComparisonPeriod is returned in case pkg.CompareCustom is used without setting Filter.CompareFrom and Filter.CompareTo.
+var ErrNoComparisonPeriod = errors.New("no comparison period specified")
+
+type comparableStats[T any] interface {
+	*T
+	GetVisitors() int
+	GetComparison() *model.Comparison
+	ResetMetrics()
+}
+
+// compare runs the query for the filter and adds the comparison to each row in case Filter.Compare is set.
+// The rows of both periods are matched using the key function.
+// If Filter.TopMovers is set, rows only found in the comparison period are included without visitors.
+func compare[T any, P comparableStats[T]](analyzer *Analyzer, filter *Filter, query func(*Filter) ([]T, error), key func(T) string) ([]T, error) {
+	filter = analyzer.getFilter(filter)
+
+	if filter.Compare == pkg.CompareNone {
+		return query(filter)
+	}
+
+	previousFilter, err := filter.comparisonPeriod()
+
+	if err != nil {
+		return nil, err
+	}
+
+	offset, limit := filter.Offset, filter.Limit
+
+	if filter.TopMovers != "" {
+		filter.Offset = 0
+		filter.Limit = 0
+	}
+
+	current, err := query(filter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	previousFilter.Offset = 0
+	previousFilter.Limit = 0
+	previous, err := query(previousFilter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	previousVisitors := make(map[string]int, len(previous))
+
+	for _, row := range previous {
+		previousVisitors[key(row)] = P(&row).GetVisitors()
+	}
+
+	if filter.TopMovers != "" {
+		current = appendPreviousRows[T, P](current, previous, key)
+	}
+
+	for i := range current {
+		row := P(&current[i])
+		comparison := row.GetComparison()
+		comparison.PreviousVisitors = previousVisitors[key(current[i])]
+		comparison.VisitorsChange = row.GetVisitors() - comparison.PreviousVisitors
+		comparison.VisitorsGrowth = calculateGrowth(row.GetVisitors(), comparison.PreviousVisitors)
+	}
+
+	if filter.TopMovers != "" {
+		current = topMovers[T, P](current, filter.TopMovers, offset, limit)
+	}
+
+	return current, nil
+}
+
+// appendPreviousRows appends the rows not found in the current period with their metrics set to zero.
+func appendPreviousRows[T any, P comparableStats[T]](current, previous []T, key func(T) string) []T {
+	currentKeys := make(map[string]struct{}, len(current))
+
+	for _, row := range current {
+		currentKeys[key(row)] = struct{}{}
+	}
+
+	for _, row := range previous {
+		if _, found := currentKeys[key(row)]; !found {
+			P(&row).ResetMetrics()
+			current = append(current, row)
+		}
+	}
+
+	return current
+}
+
+// topMovers sorts the rows by the absolute value of their change and applies the offset and limit.
+func topMovers[T any, P comparableStats[T]](rows []T, mode pkg.TopMovers, offset, limit int) []T {
+	change := func(row *T) float64 {
+		comparison := P(row).GetComparison()
+
+		if mode == pkg.TopMoversRelative {
+			return math.Abs(comparison.VisitorsGrowth)
+		}
+
+		return math.Abs(float64(comparison.VisitorsChange))
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := change(&rows[i]), change(&rows[j])
+
+		if a == b {
+			return P(&rows[i]).GetVisitors() > P(&rows[j]).GetVisitors()
+		}
+
+		return a > b
+	})
+
+	if offset >= len(rows) {
+		return rows[:0]
+	}
+
+	rows = rows[offset:]
+
+	if limit > 0 && limit < len(rows) {
+		rows = rows[:limit]
+	}
+
+	return rows
+}
+
+func compareKey(values ...string) string {
+	return strings.Join(values, "\x00")
+}
@@ -0,0 +1,194 @@
+package analyzer
+
+import (
+	"github.com/pirsch-analytics/pirsch/v6/pkg"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/db"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/model"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAnalyzer_Compare(t *testing.T) {
+	db.CleanupDB(t, dbClient)
+	sessions := make([]model.Session, 0)
+	countries := []struct {
+		day      time.Time
+		country  string
+		visitors int
+	}{
+		{util.PastDay(4), "de", 1},
+		{util.PastDay(4), "gb", 3},
+		{util.PastDay(3), "fr", 2},
+		{util.PastDay(2), "de", 3},
+		{util.PastDay(1), "gb", 1},
+		{util.PastDay(1), "us", 1},
+	}
+	visitorID := uint64(1)
+
+	for _, c := range countries {
+		for i := 0; i < c.visitors; i++ {
+			sessions = append(sessions, model.Session{Sign: 1, VisitorID: visitorID, SessionID: 1, Time: c.day, Start: c.day, EntryPath: "/", ExitPath: "/", CountryCode: c.country})
+			visitorID++
+		}
+	}
+
+	saveSessions(t, [][]model.Session{sessions})
+	analyzer := NewAnalyzer(dbClient)
+	stats, err := analyzer.Demographics.Countries(&Filter{From: util.PastDay(2), To: util.PastDay(1)})
+	assert.NoError(t, err)
+	assert.Len(t, stats, 3)
+	assert.Zero(t, stats[0].PreviousVisitors)
+	stats, err = analyzer.Demographics.Countries(&Filter{From: util.PastDay(2), To: util.PastDay(1), Compare: pkg.ComparePrevious})
+	assert.NoError(t, err)
+	assert.Len(t, stats, 3)
+	assert.Equal(t, "de", stats[0].CountryCode)
+	assert.Equal(t, "gb", stats[1].CountryCode)
+	assert.Equal(t, "us", stats[2].CountryCode)
+	assert.Equal(t, 1, stats[0].PreviousVisitors)
+	assert.Equal(t, 2, stats[0].VisitorsChange)
+	assert.InDelta(t, 2, stats[0].VisitorsGrowth, 0.01)
+	assert.Equal(t, 3, stats[1].PreviousVisitors)
+	assert.Equal(t, -2, stats[1].VisitorsChange)
+	assert.InDelta(t, -0.66, stats[1].VisitorsGrowth, 0.01)
+	assert.Equal(t, 0, stats[2].PreviousVisitors)
+	assert.Equal(t, 1, stats[2].VisitorsChange)
+	assert.InDelta(t, 1, stats[2].VisitorsGrowth, 0.01)
+	stats, err = analyzer.Demographics.Countries(&Filter{
+		From:        util.PastDay(2),
+		To:          util.PastDay(1),
+		Compare:     pkg.CompareCustom,
+		CompareFrom: util.PastDay(3),
+		CompareTo:   util.PastDay(3),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, stats, 3)
+	assert.Zero(t, stats[0].PreviousVisitors)
+	assert.Zero(t, stats[1].PreviousVisitors)
+	stats, err = analyzer.Demographics.Countries(&Filter{From: util.PastDay(2), To: util.PastDay(1), Compare: pkg.CompareYear})
+	assert.NoError(t, err)
+	assert.Len(t, stats, 3)
+	assert.Zero(t, stats[0].PreviousVisitors)
+	assert.Equal(t, 3, stats[0].VisitorsChange)
+	stats, err = analyzer.Demographics.Countries(&Filter{From: util.PastDay(2), To: util.PastDay(1), Compare: pkg.ComparePrevious, TopMovers: pkg.TopMoversAbsolute})
+	assert.NoError(t, err)
+	assert.Len(t, stats, 4)
+	assert.Equal(t, "de", stats[0].CountryCode)
+	assert.Equal(t, "gb", stats[1].CountryCode)
+	assert.Equal(t, "fr", stats[2].CountryCode)
+	assert.Equal(t, "us", stats[3].CountryCode)
+	assert.Zero(t, stats[2].Visitors)
+	assert.Equal(t, 2, stats[2].PreviousVisitors)
+	assert.Equal(t, -2, stats[2].VisitorsChange)
+	stats, err = analyzer.Demographics.Countries(&Filter{From: util.PastDay(2), To: util.PastDay(1), Compare: pkg.ComparePrevious, TopMovers: pkg.TopMoversRelative, Offset: 1, Limit: 1})
+	assert.NoError(t, err)
+	assert.Len(t, stats, 1)
+	assert.Equal(t, "us", stats[0].CountryCode)
+	_, err = analyzer.Demographics.Countries(&Filter{Compare: pkg.ComparePrevious})
+	assert.ErrorIs(t, err, ErrNoPeriodOrDay)
+	_, err = analyzer.Demographics.Countries(&Filter{From: util.PastDay(2), To: util.PastDay(1), Compare: pkg.CompareCustom})
+	assert.ErrorIs(t, err, ErrNoComparisonPeriod)
+
+	for _, eventName := range []string{"", "event"} {
+		filter := getMaxFilter(eventName)
+		filter.Compare = pkg.ComparePrevious
+		filter.TopMovers = pkg.TopMoversRelative
+		filter.EventMetaKey = []string{"key"}
+		_, err = analyzer.Pages.ByPath(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Pages.ByEventPath(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Pages.Entry(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Pages.Exit(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Visitors.Referrer(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Demographics.Languages(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Demographics.Regions(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Demographics.Cities(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Demographics.TimeZones(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Demographics.Networks(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Device.Browser(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Device.BrowserVersion(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Device.OS(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Device.OSVersion(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Device.DeviceType(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Device.DeviceModel(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Device.ScreenClass(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.UTM.Source(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.UTM.Medium(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.UTM.Campaign(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.UTM.Content(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.UTM.Term(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Events.Events(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Events.Breakdown(filter)
+		assert.NoError(t, err)
+	}
+}
+
+func TestTopMovers(t *testing.T) {
+	input := []model.CountryStats{
+		{MetaStats: model.MetaStats{Visitors: 10, Comparison: model.Comparison{VisitorsChange: 5, VisitorsGrowth: 1}}, CountryCode: "de"},
+		{MetaStats: model.MetaStats{Visitors: 2, Comparison: model.Comparison{VisitorsChange: -8, VisitorsGrowth: -0.8}}, CountryCode: "gb"},
+		{MetaStats: model.MetaStats{Visitors: 4, Comparison: model.Comparison{VisitorsChange: 4, VisitorsGrowth: 1}}, CountryCode: "us"},
+		{MetaStats: model.MetaStats{Visitors: 3, Comparison: model.Comparison{VisitorsChange: 2, VisitorsGrowth: 2}}, CountryCode: "fr"},
+	}
+	expected := map[pkg.TopMovers][]string{
+		pkg.TopMoversAbsolute: {"gb", "de", "us", "fr"},
+		pkg.TopMoversRelative: {"fr", "de", "us", "gb"},
+	}
+
+	for mode, countries := range expected {
+		stats := topMovers(append([]model.CountryStats{}, input...), mode, 0, 0)
+		assert.Len(t, stats, len(countries))
+
+		for i := range countries {
+			assert.Equal(t, countries[i], stats[i].CountryCode)
+		}
+	}
+
+	stats := topMovers(append([]model.CountryStats{}, input...), pkg.TopMoversAbsolute, 1, 2)
+	assert.Len(t, stats, 2)
+	assert.Equal(t, "de", stats[0].CountryCode)
+	assert.Equal(t, "us", stats[1].CountryCode)
+	assert.Empty(t, topMovers(append([]model.CountryStats{}, input...), pkg.TopMoversAbsolute, 4, 0))
+}
+
+func TestAppendPreviousRows(t *testing.T) {
+	current := []model.CountryStats{
+		{MetaStats: model.MetaStats{Visitors: 3, RelativeVisitors: 0.75}, CountryCode: "de"},
+		{MetaStats: model.MetaStats{Visitors: 1, RelativeVisitors: 0.25}, CountryCode: "gb"},
+	}
+	previous := []model.CountryStats{
+		{MetaStats: model.MetaStats{Visitors: 2, RelativeVisitors: 0.5}, CountryCode: "fr"},
+		{MetaStats: model.MetaStats{Visitors: 2, RelativeVisitors: 0.5}, CountryCode: "gb"},
+	}
+	rows := appendPreviousRows(current, previous, func(stats model.CountryStats) string {
+		return stats.CountryCode
+	})
+	assert.Len(t, rows, 3)
+	assert.Equal(t, "fr", rows[2].CountryCode)
+	assert.Zero(t, rows[2].Visitors)
+	assert.Zero(t, rows[2].RelativeVisitors)
+	assert.Equal(t, 1, rows[1].Visitors)
+}
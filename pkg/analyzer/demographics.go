@@ -3,6 +3,7 @@ package analyzer
 import (
 	"github.com/pirsch-analytics/pirsch/v6/pkg/db"
 	"github.com/pirsch-analytics/pirsch/v6/pkg/model"
+	"strconv"
 )
 
 // Demographics aggregates metadata statistics like the referrer, browser, and OS.
@@ -13,18 +14,61 @@ type Demographics struct {
 
 // Languages returns the visitor count grouped by language.
 func (demographics *Demographics) Languages(filter *Filter) ([]model.LanguageStats, error) {
-	q, args := demographics.analyzer.selectByAttribute(filter, FieldLanguage)
-	return demographics.store.SelectLanguageStats(q, args...)
+	return compare(demographics.analyzer, filter, func(filter *Filter) ([]model.LanguageStats, error) {
+		q, args := demographics.analyzer.selectByAttribute(filter, FieldLanguage)
+		return demographics.store.SelectLanguageStats(q, args...)
+	}, func(stats model.LanguageStats) string {
+		return stats.Language
+	})
 }
 
 // Countries returns the visitor count grouped by country.
 func (demographics *Demographics) Countries(filter *Filter) ([]model.CountryStats, error) {
-	q, args := demographics.analyzer.selectByAttribute(filter, FieldCountry)
-	return demographics.store.SelectCountryStats(q, args...)
+	return compare(demographics.analyzer, filter, func(filter *Filter) ([]model.CountryStats, error) {
+		q, args := demographics.analyzer.selectByAttribute(filter, FieldCountry)
+		return demographics.store.SelectCountryStats(q, args...)
+	}, func(stats model.CountryStats) string {
+		return stats.CountryCode
+	})
+}
+
+// Regions returns the visitor count grouped by region.
+func (demographics *Demographics) Regions(filter *Filter) ([]model.RegionStats, error) {
+	return compare(demographics.analyzer, filter, func(filter *Filter) ([]model.RegionStats, error) {
+		q, args := demographics.analyzer.selectByAttribute(filter, FieldRegion)
+		return demographics.store.SelectRegionStats(q, args...)
+	}, func(stats model.RegionStats) string {
+		return stats.Region
+	})
 }
 
 // Cities returns the visitor count grouped by city.
 func (demographics *Demographics) Cities(filter *Filter) ([]model.CityStats, error) {
-	q, args := demographics.analyzer.selectByAttribute(filter, FieldCity, FieldCountryCity)
-	return demographics.store.SelectCityStats(q, args...)
+	return compare(demographics.analyzer, filter, func(filter *Filter) ([]model.CityStats, error) {
+		q, args := demographics.analyzer.selectByAttribute(filter, FieldCity, FieldCountryCity)
+		return demographics.store.SelectCityStats(q, args...)
+	}, func(stats model.CityStats) string {
+		return compareKey(stats.CountryCode, stats.City)
+	})
+}
+
+// TimeZones returns the visitor count grouped by time zone.
+func (demographics *Demographics) TimeZones(filter *Filter) ([]model.TimeZoneStats, error) {
+	return compare(demographics.analyzer, filter, func(filter *Filter) ([]model.TimeZoneStats, error) {
+		q, args := demographics.analyzer.selectByAttribute(filter, FieldTimeZone)
+		return demographics.store.SelectTimeZoneStats(q, args...)
+	}, func(stats model.TimeZoneStats) string {
+		return stats.TimeZone
+	})
+}
+
+// Networks returns the visitor count grouped by autonomous system (ASN and organization).
+// This requires an ASN database to be configured for the GeoDB.
+func (demographics *Demographics) Networks(filter *Filter) ([]model.NetworkStats, error) {
+	return compare(demographics.analyzer, filter, func(filter *Filter) ([]model.NetworkStats, error) {
+		q, args := demographics.analyzer.selectByAttribute(filter, FieldASN, FieldASOrganization)
+		return demographics.store.SelectNetworkStats(q, args...)
+	}, func(stats model.NetworkStats) string {
+		return compareKey(strconv.FormatUint(uint64(stats.ASN), 10), stats.ASOrganization)
+	})
 }
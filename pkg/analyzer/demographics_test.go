@@ -107,6 +107,52 @@ func TestAnalyzer_Countries(t *testing.T) {
 	assert.Len(t, visitors, 2)
 }
 
+func TestAnalyzer_Regions(t *testing.T) {
+	db.CleanupDB(t, dbClient)
+	saveSessions(t, [][]model.Session{
+		{
+			{Sign: 1, VisitorID: 1, Time: time.Now(), Start: time.Now(), CountryCode: "us", Region: "us-ca"},
+		},
+		{
+			{Sign: -1, VisitorID: 1, Time: time.Now(), Start: time.Now(), CountryCode: "us", Region: "us-ca"},
+			{Sign: 1, VisitorID: 1, Time: time.Now(), Start: time.Now(), CountryCode: "gb", Region: "gb-eng"},
+			{Sign: 1, VisitorID: 2, Time: time.Now(), Start: time.Now(), CountryCode: "de", Region: "de-be"},
+			{Sign: 1, VisitorID: 3, Time: time.Now(), Start: time.Now(), CountryCode: "gb", Region: "gb-eng"},
+			{Sign: 1, VisitorID: 4, Time: time.Now(), Start: time.Now(), CountryCode: "de", Region: ""},
+		},
+	})
+	time.Sleep(time.Millisecond * 20)
+	analyzer := NewAnalyzer(dbClient)
+	visitors, err := analyzer.Demographics.Regions(nil)
+	assert.NoError(t, err)
+	assert.Len(t, visitors, 3)
+	assert.Equal(t, "gb-eng", visitors[0].Region)
+	assert.Empty(t, visitors[1].Region)
+	assert.Equal(t, "de-be", visitors[2].Region)
+	assert.Equal(t, 2, visitors[0].Visitors)
+	assert.Equal(t, 1, visitors[1].Visitors)
+	assert.Equal(t, 1, visitors[2].Visitors)
+	assert.InDelta(t, 0.5, visitors[0].RelativeVisitors, 0.01)
+	assert.InDelta(t, 0.25, visitors[1].RelativeVisitors, 0.01)
+	assert.InDelta(t, 0.25, visitors[2].RelativeVisitors, 0.01)
+	_, err = analyzer.Demographics.Regions(getMaxFilter(""))
+	assert.NoError(t, err)
+	_, err = analyzer.Demographics.Regions(getMaxFilter("event"))
+	assert.NoError(t, err)
+	_, err = analyzer.Demographics.Regions(&Filter{Offset: 1, Limit: 10, Sort: []Sort{
+		{
+			Field:     FieldRegion,
+			Direction: pkg.DirectionASC,
+		},
+	}, Search: []Search{
+		{
+			Field: FieldRegion,
+			Input: "us-",
+		},
+	}})
+	assert.NoError(t, err)
+}
+
 func TestAnalyzer_Cities(t *testing.T) {
 	db.CleanupDB(t, dbClient)
 	saveSessions(t, [][]model.Session{
@@ -161,3 +207,69 @@ func TestAnalyzer_Cities(t *testing.T) {
 	}})
 	assert.NoError(t, err)
 }
+
+func TestAnalyzer_TimeZones(t *testing.T) {
+	db.CleanupDB(t, dbClient)
+	saveSessions(t, [][]model.Session{
+		{
+			{Sign: 1, VisitorID: 1, Time: time.Now(), Start: time.Now(), TimeZone: "America/New_York"},
+		},
+		{
+			{Sign: -1, VisitorID: 1, Time: time.Now(), Start: time.Now(), TimeZone: "America/New_York"},
+			{Sign: 1, VisitorID: 1, Time: time.Now(), Start: time.Now(), TimeZone: "Europe/Berlin"},
+			{Sign: 1, VisitorID: 2, Time: time.Now(), Start: time.Now(), TimeZone: "Europe/Berlin"},
+			{Sign: 1, VisitorID: 3, Time: time.Now(), Start: time.Now(), TimeZone: "Asia/Tokyo"},
+		},
+	})
+	time.Sleep(time.Millisecond * 20)
+	analyzer := NewAnalyzer(dbClient)
+	visitors, err := analyzer.Demographics.TimeZones(nil)
+	assert.NoError(t, err)
+	assert.Len(t, visitors, 2)
+	assert.Equal(t, "Europe/Berlin", visitors[0].TimeZone)
+	assert.Equal(t, "Asia/Tokyo", visitors[1].TimeZone)
+	assert.Equal(t, 2, visitors[0].Visitors)
+	assert.Equal(t, 1, visitors[1].Visitors)
+	assert.InDelta(t, 0.66, visitors[0].RelativeVisitors, 0.01)
+	assert.InDelta(t, 0.33, visitors[1].RelativeVisitors, 0.01)
+	_, err = analyzer.Demographics.TimeZones(getMaxFilter(""))
+	assert.NoError(t, err)
+	_, err = analyzer.Demographics.TimeZones(getMaxFilter("event"))
+	assert.NoError(t, err)
+}
+
+func TestAnalyzer_Networks(t *testing.T) {
+	db.CleanupDB(t, dbClient)
+	saveSessions(t, [][]model.Session{
+		{
+			{Sign: 1, VisitorID: 1, Time: time.Now(), Start: time.Now(), ASN: 209, ASOrganization: "CenturyLink Communications, LLC"},
+		},
+		{
+			{Sign: -1, VisitorID: 1, Time: time.Now(), Start: time.Now(), ASN: 209, ASOrganization: "CenturyLink Communications, LLC"},
+			{Sign: 1, VisitorID: 1, Time: time.Now(), Start: time.Now(), ASN: 24940, ASOrganization: "Hetzner Online GmbH"},
+			{Sign: 1, VisitorID: 2, Time: time.Now(), Start: time.Now(), ASN: 24940, ASOrganization: "Hetzner Online GmbH"},
+			{Sign: 1, VisitorID: 3, Time: time.Now(), Start: time.Now(), ASN: 20712, ASOrganization: "Andrews & Arnold Ltd"},
+		},
+	})
+	time.Sleep(time.Millisecond * 20)
+	analyzer := NewAnalyzer(dbClient)
+	visitors, err := analyzer.Demographics.Networks(nil)
+	assert.NoError(t, err)
+	assert.Len(t, visitors, 2)
+	assert.Equal(t, uint32(24940), visitors[0].ASN)
+	assert.Equal(t, "Hetzner Online GmbH", visitors[0].ASOrganization)
+	assert.Equal(t, uint32(20712), visitors[1].ASN)
+	assert.Equal(t, "Andrews & Arnold Ltd", visitors[1].ASOrganization)
+	assert.Equal(t, 2, visitors[0].Visitors)
+	assert.Equal(t, 1, visitors[1].Visitors)
+	assert.InDelta(t, 0.66, visitors[0].RelativeVisitors, 0.01)
+	assert.InDelta(t, 0.33, visitors[1].RelativeVisitors, 0.01)
+	visitors, err = analyzer.Demographics.Networks(&Filter{ASN: []string{"20712"}})
+	assert.NoError(t, err)
+	assert.Len(t, visitors, 1)
+	assert.Equal(t, uint32(20712), visitors[0].ASN)
+	_, err = analyzer.Demographics.Networks(getMaxFilter(""))
+	assert.NoError(t, err)
+	_, err = analyzer.Demographics.Networks(getMaxFilter("event"))
+	assert.NoError(t, err)
+}
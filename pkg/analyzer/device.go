@@ -12,6 +12,8 @@ type Device struct {
 }
 
 // Platform returns the visitor count grouped by platform.
+//
+// Deprecated: use DeviceType instead.
 func (device *Device) Platform(filter *Filter) (*model.PlatformStats, error) {
 	filter = device.analyzer.getFilter(filter)
 	q, args := filter.buildQuery([]Field{
@@ -33,18 +35,32 @@ func (device *Device) Platform(filter *Filter) (*model.PlatformStats, error) {
 
 // Browser returns the visitor count grouped by browser.
 func (device *Device) Browser(filter *Filter) ([]model.BrowserStats, error) {
-	q, args := device.analyzer.selectByAttribute(filter, FieldBrowser)
-	return device.store.SelectBrowserStats(q, args...)
+	return compare(device.analyzer, filter, func(filter *Filter) ([]model.BrowserStats, error) {
+		q, args := device.analyzer.selectByAttribute(filter, FieldBrowser)
+		return device.store.SelectBrowserStats(q, args...)
+	}, func(stats model.BrowserStats) string {
+		return stats.Browser
+	})
 }
 
 // OS returns the visitor count grouped by operating system.
 func (device *Device) OS(filter *Filter) ([]model.OSStats, error) {
-	q, args := device.analyzer.selectByAttribute(filter, FieldOS)
-	return device.store.SelectOSStats(q, args...)
+	return compare(device.analyzer, filter, func(filter *Filter) ([]model.OSStats, error) {
+		q, args := device.analyzer.selectByAttribute(filter, FieldOS)
+		return device.store.SelectOSStats(q, args...)
+	}, func(stats model.OSStats) string {
+		return stats.OS
+	})
 }
 
 // OSVersion returns the visitor count grouped by operating systems and version.
 func (device *Device) OSVersion(filter *Filter) ([]model.OSVersionStats, error) {
+	return compare(device.analyzer, filter, device.osVersion, func(stats model.OSVersionStats) string {
+		return compareKey(stats.OS, stats.OSVersion)
+	})
+}
+
+func (device *Device) osVersion(filter *Filter) ([]model.OSVersionStats, error) {
 	q, args := device.analyzer.getFilter(filter).buildQuery([]Field{
 		FieldOS,
 		FieldOSVersion,
@@ -69,6 +85,12 @@ func (device *Device) OSVersion(filter *Filter) ([]model.OSVersionStats, error)
 
 // BrowserVersion returns the visitor count grouped by browser and version.
 func (device *Device) BrowserVersion(filter *Filter) ([]model.BrowserVersionStats, error) {
+	return compare(device.analyzer, filter, device.browserVersion, func(stats model.BrowserVersionStats) string {
+		return compareKey(stats.Browser, stats.BrowserVersion)
+	})
+}
+
+func (device *Device) browserVersion(filter *Filter) ([]model.BrowserVersionStats, error) {
 	q, args := device.analyzer.getFilter(filter).buildQuery([]Field{
 		FieldBrowser,
 		FieldBrowserVersion,
@@ -91,8 +113,32 @@ func (device *Device) BrowserVersion(filter *Filter) ([]model.BrowserVersionStat
 	return stats, nil
 }
 
+// DeviceType returns the visitor count grouped by device type.
+func (device *Device) DeviceType(filter *Filter) ([]model.DeviceTypeStats, error) {
+	return compare(device.analyzer, filter, func(filter *Filter) ([]model.DeviceTypeStats, error) {
+		q, args := device.analyzer.selectByAttribute(filter, FieldDeviceType)
+		return device.store.SelectDeviceTypeStats(q, args...)
+	}, func(stats model.DeviceTypeStats) string {
+		return stats.DeviceType
+	})
+}
+
+// DeviceModel returns the visitor count grouped by device vendor and model.
+func (device *Device) DeviceModel(filter *Filter) ([]model.DeviceModelStats, error) {
+	return compare(device.analyzer, filter, func(filter *Filter) ([]model.DeviceModelStats, error) {
+		q, args := device.analyzer.selectByAttribute(filter, FieldDeviceVendor, FieldDeviceModel)
+		return device.store.SelectDeviceModelStats(q, args...)
+	}, func(stats model.DeviceModelStats) string {
+		return compareKey(stats.DeviceVendor, stats.DeviceModel)
+	})
+}
+
 // ScreenClass returns the visitor count grouped by screen class.
 func (device *Device) ScreenClass(filter *Filter) ([]model.ScreenClassStats, error) {
-	q, args := device.analyzer.selectByAttribute(filter, FieldScreenClass)
-	return device.store.SelectScreenClassStats(q, args...)
+	return compare(device.analyzer, filter, func(filter *Filter) ([]model.ScreenClassStats, error) {
+		q, args := device.analyzer.selectByAttribute(filter, FieldScreenClass)
+		return device.store.SelectScreenClassStats(q, args...)
+	}, func(stats model.ScreenClassStats) string {
+		return stats.ScreenClass
+	})
 }
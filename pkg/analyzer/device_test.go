@@ -268,6 +268,71 @@ func TestAnalyzer_OS(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestAnalyzer_DeviceType(t *testing.T) {
+	db.CleanupDB(t, dbClient)
+	saveSessions(t, [][]model.Session{
+		{
+			{Sign: 1, VisitorID: 1, Time: time.Now(), Start: time.Now(), DeviceType: pkg.DeviceTypeDesktop},
+		},
+		{
+			{Sign: -1, VisitorID: 1, Time: time.Now(), Start: time.Now(), DeviceType: pkg.DeviceTypeDesktop},
+			{Sign: 1, VisitorID: 1, Time: time.Now(), Start: time.Now(), DeviceType: pkg.DeviceTypeMobile},
+			{Sign: 1, VisitorID: 2, Time: time.Now(), Start: time.Now(), DeviceType: pkg.DeviceTypeMobile},
+			{Sign: 1, VisitorID: 3, Time: time.Now(), Start: time.Now(), DeviceType: pkg.DeviceTypeMobile},
+			{Sign: 1, VisitorID: 4, Time: time.Now(), Start: time.Now(), DeviceType: pkg.DeviceTypeTablet},
+			{Sign: 1, VisitorID: 5, Time: time.Now(), Start: time.Now(), DeviceType: pkg.DeviceTypeTablet},
+			{Sign: 1, VisitorID: 6, Time: time.Now(), Start: time.Now(), DeviceType: pkg.DeviceTypeTV},
+		},
+	})
+	time.Sleep(time.Millisecond * 20)
+	analyzer := NewAnalyzer(dbClient)
+	visitors, err := analyzer.Device.DeviceType(nil)
+	assert.NoError(t, err)
+	assert.Len(t, visitors, 3)
+	assert.Equal(t, pkg.DeviceTypeMobile, visitors[0].DeviceType)
+	assert.Equal(t, pkg.DeviceTypeTablet, visitors[1].DeviceType)
+	assert.Equal(t, pkg.DeviceTypeTV, visitors[2].DeviceType)
+	assert.Equal(t, 3, visitors[0].Visitors)
+	assert.Equal(t, 2, visitors[1].Visitors)
+	assert.Equal(t, 1, visitors[2].Visitors)
+	assert.InDelta(t, 0.5, visitors[0].RelativeVisitors, 0.01)
+	assert.InDelta(t, 0.33, visitors[1].RelativeVisitors, 0.01)
+	assert.InDelta(t, 0.1666, visitors[2].RelativeVisitors, 0.01)
+	visitors, err = analyzer.Device.DeviceType(&Filter{DeviceType: []string{"!" + pkg.DeviceTypeMobile}})
+	assert.NoError(t, err)
+	assert.Len(t, visitors, 2)
+	_, err = analyzer.Device.DeviceType(getMaxFilter(""))
+	assert.NoError(t, err)
+	_, err = analyzer.Device.DeviceType(getMaxFilter("event"))
+	assert.NoError(t, err)
+}
+
+func TestAnalyzer_DeviceModel(t *testing.T) {
+	db.CleanupDB(t, dbClient)
+	saveSessions(t, [][]model.Session{
+		{
+			{Sign: 1, VisitorID: 1, Time: time.Now(), Start: time.Now(), DeviceVendor: "Apple", DeviceModel: "iPhone"},
+			{Sign: 1, VisitorID: 2, Time: time.Now(), Start: time.Now(), DeviceVendor: "Apple", DeviceModel: "iPhone"},
+			{Sign: 1, VisitorID: 3, Time: time.Now(), Start: time.Now(), DeviceVendor: "Google", DeviceModel: "Pixel 7"},
+		},
+	})
+	time.Sleep(time.Millisecond * 20)
+	analyzer := NewAnalyzer(dbClient)
+	visitors, err := analyzer.Device.DeviceModel(nil)
+	assert.NoError(t, err)
+	assert.Len(t, visitors, 2)
+	assert.Equal(t, "Apple", visitors[0].DeviceVendor)
+	assert.Equal(t, "iPhone", visitors[0].DeviceModel)
+	assert.Equal(t, "Google", visitors[1].DeviceVendor)
+	assert.Equal(t, "Pixel 7", visitors[1].DeviceModel)
+	assert.Equal(t, 2, visitors[0].Visitors)
+	assert.Equal(t, 1, visitors[1].Visitors)
+	_, err = analyzer.Device.DeviceModel(&Filter{DeviceVendor: []string{"Google"}, DeviceModel: []string{"Pixel 7"}})
+	assert.NoError(t, err)
+	_, err = analyzer.Device.DeviceModel(getMaxFilter("event"))
+	assert.NoError(t, err)
+}
+
 func TestAnalyzer_OSVersion(t *testing.T) {
 	db.CleanupDB(t, dbClient)
 	saveSessions(t, [][]model.Session{
@@ -13,6 +13,12 @@ type Events struct {
 
 // Events returns the visitor count, views, and conversion rate for custom events.
 func (events *Events) Events(filter *Filter) ([]model.EventStats, error) {
+	return compare(events.analyzer, filter, events.events, func(stats model.EventStats) string {
+		return stats.Name
+	})
+}
+
+func (events *Events) events(filter *Filter) ([]model.EventStats, error) {
 	filter = events.analyzer.getFilter(filter)
 	q, args := filter.buildQuery([]Field{
 		FieldEventName,
@@ -39,6 +45,12 @@ func (events *Events) Events(filter *Filter) ([]model.EventStats, error) {
 // Breakdown returns the visitor count, views, and conversion rate for a custom event grouping them by a meta value for given key.
 // The Filter.EventName and Filter.EventMetaKey must be set, or otherwise the result set will be empty.
 func (events *Events) Breakdown(filter *Filter) ([]model.EventStats, error) {
+	return compare(events.analyzer, filter, events.breakdown, func(stats model.EventStats) string {
+		return compareKey(stats.Name, stats.MetaValue)
+	})
+}
+
+func (events *Events) breakdown(filter *Filter) ([]model.EventStats, error) {
 	filter = events.analyzer.getFilter(filter)
 
 	if len(filter.EventName) == 0 || len(filter.EventMetaKey) == 0 {
@@ -58,9 +58,21 @@ type Filter struct {
 	// Country filters for the ISO country code.
 	Country []string
 
+	// Region filters for the ISO 3166-2 region code (like gb-eng).
+	Region []string
+
 	// City filters for the city name.
 	City []string
 
+	// TimeZone filters for the IANA time zone of the visitor (like Europe/London).
+	TimeZone []string
+
+	// ASN filters for the autonomous system number of the visitor's network.
+	ASN []string
+
+	// ASOrganization filters for the organization owning the visitor's network.
+	ASOrganization []string
+
 	// Referrer filters for the full referrer.
 	Referrer []string
 
@@ -80,8 +92,19 @@ type Filter struct {
 	BrowserVersion []string
 
 	// Platform filters for the platform (desktop, mobile, unknown).
+	//
+	// Deprecated: use DeviceType instead.
 	Platform string
 
+	// DeviceType filters for the device type (desktop, mobile, tablet, tv, console, wearable).
+	DeviceType []string
+
+	// DeviceVendor filters for the device vendor.
+	DeviceVendor []string
+
+	// DeviceModel filters for the device model.
+	DeviceModel []string
+
 	// ScreenClass filters for the screen class.
 	ScreenClass []string
 
@@ -110,6 +133,14 @@ type Filter struct {
 	// EventMeta filters for event metadata.
 	EventMeta map[string]string
 
+	// Tree is an optional boolean combination of conditions, which can be used to combine different fields using OR.
+	// It is combined with the other fields using AND.
+	Tree *FilterTree
+
+	// Ranges filters sessions by comparing numeric properties, like the session duration or number of page views.
+	// All ranges must match.
+	Ranges []Range
+
 	// Search searches the results for given fields and inputs.
 	Search []Search
 
@@ -123,6 +154,22 @@ type Filter struct {
 	// Limit limits the number of results. Less or equal to zero means no limit.
 	Limit int
 
+	// Compare sets the period breakdowns (like Analyzer.Pages.ByPath or Analyzer.Demographics.Countries) are compared to.
+	// Each row will contain the visitors in the comparison period and the change relative to them.
+	// It also sets the period Analyzer.Visitors.Growth and Analyzer.Visitors.TotalVisitorsPageViews compare to.
+	// From and To must be set, else an error is returned.
+	Compare pkg.CompareMode
+
+	// CompareFrom is the start date of the comparison period for pkg.CompareCustom.
+	CompareFrom time.Time
+
+	// CompareTo is the end date of the comparison period for pkg.CompareCustom.
+	CompareTo time.Time
+
+	// TopMovers sorts compared breakdowns by the absolute or relative change in visitors, largest change first (up or down).
+	// Only rows with visitors in the selected period are returned. Offset and Limit are applied after sorting.
+	TopMovers pkg.TopMovers
+
 	// CustomMetricKey is used to calculate the average and total for an event metadata field.
 	// This must be used together with EventName and CustomMetricType.
 	CustomMetricKey string
@@ -174,25 +221,29 @@ func (filter *Filter) validate() {
 	}
 
 	if !filter.From.IsZero() {
-		if filter.IncludeTime {
-			filter.From = filter.From.In(time.UTC)
-		} else {
-			filter.From = filter.toDate(filter.From)
-		}
+		filter.From = filter.normalizeTime(filter.From)
 	}
 
 	if !filter.To.IsZero() {
-		if filter.IncludeTime {
-			filter.To = filter.To.In(time.UTC)
-		} else {
-			filter.To = filter.toDate(filter.To)
-		}
+		filter.To = filter.normalizeTime(filter.To)
 	}
 
 	if !filter.To.IsZero() && filter.From.After(filter.To) {
 		filter.From, filter.To = filter.To, filter.From
 	}
 
+	if !filter.CompareFrom.IsZero() {
+		filter.CompareFrom = filter.normalizeTime(filter.CompareFrom)
+	}
+
+	if !filter.CompareTo.IsZero() {
+		filter.CompareTo = filter.normalizeTime(filter.CompareTo)
+	}
+
+	if !filter.CompareTo.IsZero() && filter.CompareFrom.After(filter.CompareTo) {
+		filter.CompareFrom, filter.CompareTo = filter.CompareTo, filter.CompareFrom
+	}
+
 	// use tomorrow instead of limiting to "today", so that all timezones are included
 	tomorrow := util.Today().Add(time.Hour * 24)
 
@@ -216,6 +267,12 @@ func (filter *Filter) validate() {
 		filter.Limit = 0
 	}
 
+	if filter.TopMovers != "" &&
+		filter.TopMovers != pkg.TopMoversAbsolute &&
+		filter.TopMovers != pkg.TopMoversRelative {
+		filter.TopMovers = ""
+	}
+
 	if filter.CustomMetricType != "" &&
 		filter.CustomMetricType != pkg.CustomMetricTypeInteger &&
 		filter.CustomMetricType != pkg.CustomMetricTypeFloat {
@@ -228,13 +285,20 @@ func (filter *Filter) validate() {
 	filter.PathPattern = filter.removeDuplicates(filter.PathPattern)
 	filter.Language = filter.removeDuplicates(filter.Language)
 	filter.Country = filter.removeDuplicates(filter.Country)
+	filter.Region = filter.removeDuplicates(filter.Region)
 	filter.City = filter.removeDuplicates(filter.City)
+	filter.TimeZone = filter.removeDuplicates(filter.TimeZone)
+	filter.ASN = filter.removeDuplicates(filter.ASN)
+	filter.ASOrganization = filter.removeDuplicates(filter.ASOrganization)
 	filter.Referrer = filter.removeDuplicates(filter.Referrer)
 	filter.ReferrerName = filter.removeDuplicates(filter.ReferrerName)
 	filter.OS = filter.removeDuplicates(filter.OS)
 	filter.OSVersion = filter.removeDuplicates(filter.OSVersion)
 	filter.Browser = filter.removeDuplicates(filter.Browser)
 	filter.BrowserVersion = filter.removeDuplicates(filter.BrowserVersion)
+	filter.DeviceType = filter.removeDuplicates(filter.DeviceType)
+	filter.DeviceVendor = filter.removeDuplicates(filter.DeviceVendor)
+	filter.DeviceModel = filter.removeDuplicates(filter.DeviceModel)
 	filter.ScreenClass = filter.removeDuplicates(filter.ScreenClass)
 	filter.UTMSource = filter.removeDuplicates(filter.UTMSource)
 	filter.UTMMedium = filter.removeDuplicates(filter.UTMMedium)
@@ -243,6 +307,8 @@ func (filter *Filter) validate() {
 	filter.UTMTerm = filter.removeDuplicates(filter.UTMTerm)
 	filter.EventName = filter.removeDuplicates(filter.EventName)
 	filter.EventMetaKey = filter.removeDuplicates(filter.EventMetaKey)
+	filter.Tree = filter.normalizeTree(filter.Tree)
+	filter.Ranges = filter.validateRanges(filter.Ranges)
 }
 
 func (filter *Filter) removeDuplicates(in []string) []string {
@@ -263,6 +329,92 @@ func (filter *Filter) removeDuplicates(in []string) []string {
 	return list
 }
 
+func (filter *Filter) normalizeTime(t time.Time) time.Time {
+	if filter.IncludeTime {
+		return t.In(time.UTC)
+	}
+
+	return filter.toDate(t)
+}
+
+// comparisonPeriod returns a copy of the filter for the period selected by Compare.
+// If the selected period is today, the comparison period ends at the current time of day.
+func (filter *Filter) comparisonPeriod() (*Filter, error) {
+	if filter.From.IsZero() || filter.To.IsZero() {
+		return nil, ErrNoPeriodOrDay
+	}
+
+	filterCopy := *filter
+
+	if filter.Compare == pkg.CompareCustom {
+		if filter.CompareFrom.IsZero() || filter.CompareTo.IsZero() {
+			return nil, ErrNoComparisonPeriod
+		}
+
+		filterCopy.From = filter.CompareFrom
+		filterCopy.To = filter.CompareTo
+		return &filterCopy, nil
+	} else if filter.Compare != pkg.CompareYear && filter.Compare != pkg.ComparePreviousWeekday {
+		filterCopy.previousPeriod()
+		return &filterCopy, nil
+	}
+
+	if !filter.IncludeTime && filter.From.Equal(filter.To) && filter.To.Equal(util.Today()) {
+		filterCopy.To = time.Now().UTC()
+		filterCopy.IncludeTime = true
+	}
+
+	if filter.Compare == pkg.CompareYear {
+		filterCopy.From = previousYear(filterCopy.From)
+		filterCopy.To = previousYear(filterCopy.To)
+	} else {
+		length := filter.To.Sub(filter.From)
+
+		if !filter.IncludeTime {
+			length += time.Hour * 24
+		}
+
+		week := time.Hour * 24 * 7
+		weeks := (length + week - 1) / week
+		filterCopy.From = filterCopy.From.Add(-weeks * week)
+		filterCopy.To = filterCopy.To.Add(-weeks * week)
+	}
+
+	return &filterCopy, nil
+}
+
+// previousYear returns the same day one year earlier.
+// The day is limited to the last day of the month, so that February 29th becomes February 28th.
+func previousYear(t time.Time) time.Time {
+	year, month, day := t.Date()
+	lastDay := time.Date(year-1, month+1, 0, 0, 0, 0, 0, t.Location()).Day()
+	return time.Date(year-1, month, min(day, lastDay), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// previousPeriod sets the default comparison period for Analyzer.Visitors.Growth.
+func (filter *Filter) previousPeriod() {
+	if filter.From.Equal(filter.To) {
+		if filter.To.Equal(util.Today()) {
+			filter.From = filter.From.Add(-time.Hour * 24 * 7)
+			filter.To = time.Now().UTC().Add(-time.Hour * 24 * 7)
+			filter.IncludeTime = true
+		} else {
+			filter.From = filter.From.Add(-time.Hour * 24 * 7)
+			filter.To = filter.To.Add(-time.Hour * 24 * 7)
+		}
+	} else {
+		days := filter.To.Sub(filter.From)
+
+		if days >= time.Hour*24 {
+			filter.To = filter.From.Add(-time.Hour * 24)
+			filter.From = filter.To.Add(-days)
+		} else {
+			filter.From = filter.From.Add(-time.Hour * 24)
+			filter.To = filter.To.Add(-time.Hour * 24)
+		}
+	}
+}
+
 func (filter *Filter) toDate(date time.Time) time.Time {
 	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
 }
@@ -322,6 +474,17 @@ func (filter *Filter) buildTimeQuery() (string, []any) {
 	return q.whereTime(), q.args
 }
 
+// timeFilter returns a filter for the client and period only.
+func (filter *Filter) timeFilter() *Filter {
+	return &Filter{
+		ClientID:    filter.ClientID,
+		Timezone:    filter.Timezone,
+		From:        filter.From,
+		To:          filter.To,
+		IncludeTime: filter.IncludeTime,
+	}
+}
+
 func (filter *Filter) table(fields []Field) table {
 	if !filter.fieldsContain(fields, FieldEntryPath) && !filter.fieldsContain(fields, FieldExitPath) {
 		eventFilter := filter.fieldsContain(fields, FieldEventName) || filter.CustomMetricType != "" && filter.CustomMetricKey != ""
@@ -384,6 +547,8 @@ func (filter *Filter) joinSessions(fields []Field) *queryBuilder {
 
 		filterCopy := *filter
 		filterCopy.Sort = nil
+		filterCopy.Tree = nil
+		filterCopy.Ranges = nil
 		return &queryBuilder{
 			filter:  &filterCopy,
 			fields:  sessionFields,
@@ -407,6 +572,8 @@ func (filter *Filter) joinPageViews(fields []Field) *queryBuilder {
 
 		filterCopy := *filter
 		filterCopy.Sort = nil
+		filterCopy.Tree = nil
+		filterCopy.Ranges = nil
 		return &queryBuilder{
 			filter:  &filterCopy,
 			fields:  pageViewFields,
@@ -424,6 +591,8 @@ func (filter *Filter) joinEvents(fields []Field) *queryBuilder {
 		filterCopy.Path = nil
 		filterCopy.AnyPath = nil
 		filterCopy.Sort = nil
+		filterCopy.Tree = nil
+		filterCopy.Ranges = nil
 		eventFields := []Field{FieldVisitorID, FieldSessionID}
 
 		if filter.fieldsContain(fields, FieldEventPath) {
@@ -456,6 +625,8 @@ func (filter *Filter) leftJoinEvents(fields []Field) *queryBuilder {
 	filterCopy.EventMetaKey = nil
 	filterCopy.EventMeta = nil
 	filterCopy.Sort = nil
+	filterCopy.Tree = nil
+	filterCopy.Ranges = nil
 	eventFields := []Field{FieldVisitorID, FieldSessionID, FieldEventName}
 
 	if len(filter.EventMeta) != 0 || filter.fieldsContain(fields, FieldEventMeta) {
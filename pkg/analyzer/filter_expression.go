@@ -0,0 +1,1071 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+	"github.com/pirsch-analytics/pirsch/v6/pkg"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	filterOperatorEqual          = "="
+	filterOperatorNotEqual       = "!="
+	filterOperatorContains       = "~"
+	filterOperatorNotContains    = "!~"
+	filterOperatorGreater        = ">"
+	filterOperatorGreaterOrEqual = ">="
+	filterOperatorLess           = "<"
+	filterOperatorLessOrEqual    = "<="
+
+	filterKeyPath        = "path"
+	filterKeyPathPattern = "path_pattern"
+	filterKeyPlatform    = "platform"
+	filterKeyMetaPrefix  = "meta."
+)
+
+// ErrFilterExpressionSearch is returned by FormatFilter in case Filter.Search is set, as it cannot be represented by a filter expression.
+var ErrFilterExpressionSearch = errors.New("search cannot be represented by a filter expression")
+
+var filterExpressionFields = []struct {
+	key    string
+	field  Field
+	values func(*Filter) *[]string
+}{
+	{filterKeyPath, FieldPath, func(filter *Filter) *[]string { return &filter.Path }},
+	{filterKeyPathPattern, Field{}, func(filter *Filter) *[]string { return &filter.PathPattern }},
+	{"entry_path", FieldEntryPath, func(filter *Filter) *[]string { return &filter.EntryPath }},
+	{"exit_path", FieldExitPath, func(filter *Filter) *[]string { return &filter.ExitPath }},
+	{"language", FieldLanguage, func(filter *Filter) *[]string { return &filter.Language }},
+	{"country", FieldCountry, func(filter *Filter) *[]string { return &filter.Country }},
+	{"region", FieldRegion, func(filter *Filter) *[]string { return &filter.Region }},
+	{"city", FieldCity, func(filter *Filter) *[]string { return &filter.City }},
+	{"time_zone", FieldTimeZone, func(filter *Filter) *[]string { return &filter.TimeZone }},
+	{"asn", FieldASN, func(filter *Filter) *[]string { return &filter.ASN }},
+	{"as_organization", FieldASOrganization, func(filter *Filter) *[]string { return &filter.ASOrganization }},
+	{"referrer", FieldReferrer, func(filter *Filter) *[]string { return &filter.Referrer }},
+	{"referrer_name", FieldReferrerName, func(filter *Filter) *[]string { return &filter.ReferrerName }},
+	{"os", FieldOS, func(filter *Filter) *[]string { return &filter.OS }},
+	{"os_version", FieldOSVersion, func(filter *Filter) *[]string { return &filter.OSVersion }},
+	{"browser", FieldBrowser, func(filter *Filter) *[]string { return &filter.Browser }},
+	{"browser_version", FieldBrowserVersion, func(filter *Filter) *[]string { return &filter.BrowserVersion }},
+	{"device_type", FieldDeviceType, func(filter *Filter) *[]string { return &filter.DeviceType }},
+	{"device_vendor", FieldDeviceVendor, func(filter *Filter) *[]string { return &filter.DeviceVendor }},
+	{"device_model", FieldDeviceModel, func(filter *Filter) *[]string { return &filter.DeviceModel }},
+	{"screen_class", FieldScreenClass, func(filter *Filter) *[]string { return &filter.ScreenClass }},
+	{"utm_source", FieldUTMSource, func(filter *Filter) *[]string { return &filter.UTMSource }},
+	{"utm_medium", FieldUTMMedium, func(filter *Filter) *[]string { return &filter.UTMMedium }},
+	{"utm_campaign", FieldUTMCampaign, func(filter *Filter) *[]string { return &filter.UTMCampaign }},
+	{"utm_content", FieldUTMContent, func(filter *Filter) *[]string { return &filter.UTMContent }},
+	{"utm_term", FieldUTMTerm, func(filter *Filter) *[]string { return &filter.UTMTerm }},
+	{"event_name", FieldEventName, func(filter *Filter) *[]string { return &filter.EventName }},
+	{"event_meta_key", Field{}, func(filter *Filter) *[]string { return &filter.EventMetaKey }},
+}
+
+// FilterExpressionError is returned in case a filter expression cannot be parsed or cannot be represented by a Filter.
+type FilterExpressionError struct {
+	// Pos is the 1-based position (in bytes) of the error in the expression.
+	Pos int
+
+	// Message describes the error.
+	Message string
+}
+
+// Error implements the error interface.
+func (err *FilterExpressionError) Error() string {
+	return fmt.Sprintf("filter expression: %s at position %d", err.Message, err.Pos)
+}
+
+// ParseFilter parses a filter expression and returns a Filter with the fields set accordingly.
+// Only the fields used to filter results are set, so the client ID, period, and other options need to be set afterwards.
+//
+// An expression consists of conditions in the form of <field> <operator> <value>, which can be combined using "and", "or", "not", and parentheses.
+// Fields are named like the Filter fields in snake case (like path, country, referrer_name, or utm_source) and event metadata is accessed using meta.<key>.
+// Supported operators are "=", "!=", "~" (contains), and "!~".
+// The session properties (session_duration, page_views, bounce, events, and time_on_page) are compared to whole numbers
+// using "=", "!=", ">", ">=", "<", and "<=" and stored in the Filter.Ranges.
+// For the path, "~" and "!~" match a pattern in case the value contains a *, where * matches every character but slashes
+// and ** matches all characters (like "/blog/**"). path_pattern can be used to filter using a regex directly.
+// Values can be quoted using double quotes and null compares to none/unknown/empty.
+// Conditions that cannot be stored in the Filter fields, like conditions for different fields joined by "or", are stored in the Filter.Tree.
+// This isn't supported for the platform, event metadata, and session properties.
+//
+//	path ~ "/blog/**" and country != de and (referrer_name = Google or utm_source = newsletter) and session_duration > 30
+func ParseFilter(expression string) (*Filter, error) {
+	parser := filterParser{expression: expression}
+
+	if err := parser.tokenize(); err != nil {
+		return nil, err
+	}
+
+	filter := new(Filter)
+
+	if parser.peek().kind == filterTokenEOF {
+		return filter, nil
+	}
+
+	node, err := parser.parseOr()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if token := parser.peek(); token.kind != filterTokenEOF {
+		return nil, parser.errorf(token.pos, "unexpected %q", token.value)
+	}
+
+	conditions := []filterNode{node}
+
+	if node.kind == filterNodeAnd {
+		conditions = node.children
+	}
+
+	trees := make([]FilterTree, 0)
+
+	for i := range conditions {
+		clauses, err := conditions[i].clauses(false)
+		result := copyFilterFields(filter)
+
+		if err == nil {
+			err = applyFilterClauses(result, clauses)
+		}
+
+		if err != nil {
+			tree, treeErr := conditions[i].tree()
+
+			if treeErr != nil {
+				return nil, err
+			}
+
+			trees = append(trees, tree)
+			continue
+		}
+
+		filter = result
+	}
+
+	if len(trees) == 1 {
+		filter.Tree = &trees[0]
+	} else if len(trees) > 1 {
+		filter.Tree = &FilterTree{Children: trees}
+	}
+
+	return filter, nil
+}
+
+// copyFilterFields returns a copy of the filter that can be modified by applyFilterClauses without changing the original.
+func copyFilterFields(filter *Filter) *Filter {
+	filterCopy := *filter
+
+	if filter.EventMeta != nil {
+		filterCopy.EventMeta = make(map[string]string, len(filter.EventMeta))
+
+		for k, v := range filter.EventMeta {
+			filterCopy.EventMeta[k] = v
+		}
+	}
+
+	return &filterCopy
+}
+
+func applyFilterClauses(filter *Filter, clauses []filterClause) error {
+	for _, clause := range clauses {
+		if err := clause.apply(filter); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FormatFilter returns the filter expression for the fields used to filter results.
+// The result can be parsed using ParseFilter to restore the fields.
+// Filter.AnyPath is restored as a condition for the path.
+// ErrFilterExpressionSearch is returned if Filter.Search is set.
+func FormatFilter(filter *Filter) (string, error) {
+	if filter == nil {
+		return "", nil
+	}
+
+	if len(filter.Search) != 0 {
+		return "", ErrFilterExpressionSearch
+	}
+
+	parts := make([]string, 0)
+
+	for _, field := range filterExpressionFields {
+		values := *field.values(filter)
+
+		if field.key == filterKeyPathPattern {
+			parts = append(parts, formatFilterPathPattern(values)...)
+		} else {
+			parts = append(parts, formatFilterValues(field.key, values)...)
+		}
+
+		if field.key == filterKeyPath {
+			parts = append(parts, formatFilterAnyPath(filter.AnyPath)...)
+		}
+	}
+
+	if filter.Platform != "" {
+		parts = append(parts, formatFilterValues(filterKeyPlatform, []string{filter.Platform})...)
+	}
+
+	keys := make([]string, 0, len(filter.EventMeta))
+
+	for key := range filter.EventMeta {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		parts = append(parts, formatFilterValues(filterKeyMetaPrefix+key, []string{filter.EventMeta[key]})...)
+	}
+
+	for _, r := range filter.Ranges {
+		parts = append(parts, fmt.Sprintf("%s %s %d", r.Property, r.Operator, r.Value))
+	}
+
+	if filter.Tree != nil {
+		if tree := formatFilterTree(filter.Tree, false); tree != "" {
+			parts = append(parts, tree)
+		}
+	}
+
+	return strings.Join(parts, " and "), nil
+}
+
+func formatFilterAnyPath(paths []string) []string {
+	positive := make([]string, 0, len(paths))
+
+	for _, path := range paths {
+		positive = append(positive, formatFilterCondition(filterKeyPath, filterOperatorEqual, path))
+	}
+
+	return joinFilterConditions(positive, nil)
+}
+
+func formatFilterTree(tree *FilterTree, nested bool) string {
+	var parts []string
+	operator := " and "
+
+	if len(tree.Children) > 0 {
+		parts = make([]string, 0, len(tree.Children))
+
+		for i := range tree.Children {
+			if part := formatFilterTree(&tree.Children[i], true); part != "" {
+				parts = append(parts, part)
+			}
+		}
+
+		if tree.Or {
+			operator = " or "
+		}
+	} else if tree.Pattern {
+		parts = formatFilterPathPattern(tree.Values)
+	} else {
+		for _, field := range filterExpressionFields {
+			if field.field.Name != "" && field.field == tree.Field {
+				parts = formatFilterValues(field.key, tree.Values)
+				break
+			}
+		}
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+
+	q := strings.Join(parts, operator)
+
+	if tree.Not {
+		return "not (" + q + ")"
+	} else if len(parts) > 1 && (nested || tree.Or) {
+		return "(" + q + ")"
+	}
+
+	return q
+}
+
+func formatFilterValues(key string, values []string) []string {
+	positive := make([]string, 0, len(values))
+	negative := make([]string, 0)
+
+	for _, value := range values {
+		if strings.HasPrefix(value, "!") {
+			negative = append(negative, formatFilterCondition(key, filterOperatorNotEqual, value[1:]))
+		} else if strings.HasPrefix(value, "~") {
+			positive = append(positive, formatFilterCondition(key, filterOperatorContains, value[1:]))
+		} else {
+			positive = append(positive, formatFilterCondition(key, filterOperatorEqual, value))
+		}
+	}
+
+	return joinFilterConditions(positive, negative)
+}
+
+func formatFilterPathPattern(values []string) []string {
+	positive := make([]string, 0, len(values))
+	negative := make([]string, 0)
+
+	for _, value := range values {
+		not := strings.HasPrefix(value, "!")
+
+		if not {
+			value = value[1:]
+		}
+
+		key, operator := filterKeyPathPattern, filterOperatorEqual
+
+		if glob, ok := patternToGlob(value); ok && strings.Contains(glob, "*") {
+			key, operator, value = filterKeyPath, filterOperatorContains, glob
+		}
+
+		if not {
+			if operator == filterOperatorEqual {
+				operator = filterOperatorNotEqual
+			} else {
+				operator = filterOperatorNotContains
+			}
+
+			negative = append(negative, formatFilterCondition(key, operator, value))
+		} else {
+			positive = append(positive, formatFilterCondition(key, operator, value))
+		}
+	}
+
+	return joinFilterConditions(positive, negative)
+}
+
+func joinFilterConditions(positive, negative []string) []string {
+	parts := make([]string, 0, len(negative)+1)
+
+	if len(positive) == 1 {
+		parts = append(parts, positive[0])
+	} else if len(positive) > 1 {
+		parts = append(parts, "("+strings.Join(positive, " or ")+")")
+	}
+
+	return append(parts, negative...)
+}
+
+func formatFilterCondition(key, operator, value string) string {
+	if strings.ToLower(value) == "null" {
+		value = "null"
+	} else {
+		value = quoteFilterValue(value)
+	}
+
+	return fmt.Sprintf("%s %s %s", quoteFilterValue(key), operator, value)
+}
+
+func quoteFilterValue(value string) string {
+	if value == "" || isFilterKeyword(value) || strings.ToLower(value) == "null" {
+		return strconv.Quote(value)
+	}
+
+	for _, c := range value {
+		if !isFilterWordChar(c) {
+			return strconv.Quote(value)
+		}
+	}
+
+	return value
+}
+
+func isFilterKeyword(word string) bool {
+	word = strings.ToLower(word)
+	return word == "and" || word == "or" || word == "not"
+}
+
+func isFilterWordChar(c rune) bool {
+	return !unicode.IsSpace(c) && !unicode.IsControl(c) && !strings.ContainsRune(`()"=!~<>`, c)
+}
+
+// globToPattern converts a path pattern using * and ** to a case-insensitive regex for Filter.PathPattern.
+func globToPattern(glob string) string {
+	var pattern strings.Builder
+	pattern.WriteString("(?i)^")
+
+	for {
+		i := strings.IndexByte(glob, '*')
+
+		if i < 0 {
+			pattern.WriteString(regexp.QuoteMeta(glob))
+			break
+		}
+
+		pattern.WriteString(regexp.QuoteMeta(glob[:i]))
+
+		if strings.HasPrefix(glob[i:], "**") {
+			pattern.WriteString(".*")
+			glob = glob[i+2:]
+		} else {
+			pattern.WriteString("[^/]+")
+			glob = glob[i+1:]
+		}
+	}
+
+	pattern.WriteString("$")
+	return pattern.String()
+}
+
+// patternToGlob converts a regex created by globToPattern back to the pattern using * and **.
+// It returns false if the regex wasn't created by globToPattern.
+func patternToGlob(pattern string) (string, bool) {
+	if !strings.HasPrefix(pattern, "(?i)^") || !strings.HasSuffix(pattern, "$") || strings.HasSuffix(pattern, `\$`) {
+		return "", false
+	}
+
+	pattern = pattern[len("(?i)^") : len(pattern)-1]
+	var glob strings.Builder
+
+	for len(pattern) > 0 {
+		if strings.HasPrefix(pattern, ".*") {
+			glob.WriteString("**")
+			pattern = pattern[2:]
+		} else if strings.HasPrefix(pattern, "[^/]+") {
+			glob.WriteString("*")
+			pattern = pattern[5:]
+		} else if pattern[0] == '\\' {
+			if len(pattern) < 2 || pattern[1] == '*' || !strings.ContainsRune(`\.+?()|[]{}^$`, rune(pattern[1])) {
+				return "", false
+			}
+
+			glob.WriteByte(pattern[1])
+			pattern = pattern[2:]
+		} else if strings.ContainsRune(`\.+*?()|[]{}^$`, rune(pattern[0])) {
+			return "", false
+		} else {
+			glob.WriteByte(pattern[0])
+			pattern = pattern[1:]
+		}
+	}
+
+	return glob.String(), true
+}
+
+type filterTokenKind int
+
+const (
+	filterTokenEOF = filterTokenKind(iota)
+	filterTokenWord
+	filterTokenString
+	filterTokenOperator
+	filterTokenLeftParen
+	filterTokenRightParen
+)
+
+type filterToken struct {
+	kind  filterTokenKind
+	value string
+	pos   int
+}
+
+type filterNodeKind int
+
+const (
+	filterNodeCondition = filterNodeKind(iota)
+	filterNodeAnd
+	filterNodeOr
+	filterNodeNot
+)
+
+type filterNode struct {
+	kind      filterNodeKind
+	pos       int
+	children  []filterNode
+	condition filterCondition
+}
+
+type filterCondition struct {
+	key         string
+	operator    string
+	value       string
+	null        bool
+	pos         int
+	operatorPos int
+	valuePos    int
+}
+
+type filterClause []filterCondition
+
+type filterParser struct {
+	expression string
+	tokens     []filterToken
+	current    int
+}
+
+func (parser *filterParser) errorf(pos int, format string, args ...any) error {
+	return &FilterExpressionError{
+		Pos:     pos + 1,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+func (parser *filterParser) tokenize() error {
+	expression := parser.expression
+
+	for i := 0; i < len(expression); {
+		c := rune(expression[i])
+
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(':
+			parser.tokens = append(parser.tokens, filterToken{filterTokenLeftParen, "(", i})
+			i++
+		case c == ')':
+			parser.tokens = append(parser.tokens, filterToken{filterTokenRightParen, ")", i})
+			i++
+		case c == '=' || c == '~':
+			parser.tokens = append(parser.tokens, filterToken{filterTokenOperator, string(c), i})
+			i++
+		case c == '>' || c == '<':
+			end := i + 1
+
+			if end < len(expression) && expression[end] == '=' {
+				end++
+			}
+
+			parser.tokens = append(parser.tokens, filterToken{filterTokenOperator, expression[i:end], i})
+			i = end
+		case c == '!':
+			if i+1 >= len(expression) || (expression[i+1] != '=' && expression[i+1] != '~') {
+				return parser.errorf(i, `expected "!=" or "!~"`)
+			}
+
+			parser.tokens = append(parser.tokens, filterToken{filterTokenOperator, expression[i : i+2], i})
+			i += 2
+		case c == '"':
+			end := i + 1
+
+			for end < len(expression) && expression[end] != '"' {
+				if expression[end] == '\\' {
+					end++
+				}
+
+				end++
+			}
+
+			if end >= len(expression) {
+				return parser.errorf(i, "unterminated string")
+			}
+
+			value, err := strconv.Unquote(expression[i : end+1])
+
+			if err != nil {
+				return parser.errorf(i, "invalid string")
+			}
+
+			parser.tokens = append(parser.tokens, filterToken{filterTokenString, value, i})
+			i = end + 1
+		default:
+			end := strings.IndexFunc(expression[i:], func(r rune) bool {
+				return !isFilterWordChar(r)
+			})
+
+			if end < 0 {
+				end = len(expression)
+			} else {
+				end += i
+			}
+
+			if end == i {
+				return parser.errorf(i, "unexpected character %q", expression[i])
+			}
+
+			parser.tokens = append(parser.tokens, filterToken{filterTokenWord, expression[i:end], i})
+			i = end
+		}
+	}
+
+	parser.tokens = append(parser.tokens, filterToken{filterTokenEOF, "end of expression", len(expression)})
+	return nil
+}
+
+func (parser *filterParser) peek() filterToken {
+	return parser.tokens[parser.current]
+}
+
+func (parser *filterParser) next() filterToken {
+	token := parser.tokens[parser.current]
+
+	if token.kind != filterTokenEOF {
+		parser.current++
+	}
+
+	return token
+}
+
+func (parser *filterParser) isKeyword(token filterToken, keyword string) bool {
+	return token.kind == filterTokenWord && strings.ToLower(token.value) == keyword
+}
+
+func (parser *filterParser) parseOr() (filterNode, error) {
+	return parser.parseBinary("or", filterNodeOr, parser.parseAnd)
+}
+
+func (parser *filterParser) parseAnd() (filterNode, error) {
+	return parser.parseBinary("and", filterNodeAnd, parser.parseUnary)
+}
+
+func (parser *filterParser) parseBinary(keyword string, kind filterNodeKind, parse func() (filterNode, error)) (filterNode, error) {
+	pos := parser.peek().pos
+	node, err := parse()
+
+	if err != nil {
+		return filterNode{}, err
+	}
+
+	if !parser.isKeyword(parser.peek(), keyword) {
+		return node, nil
+	}
+
+	children := []filterNode{node}
+
+	for parser.isKeyword(parser.peek(), keyword) {
+		parser.next()
+		child, err := parse()
+
+		if err != nil {
+			return filterNode{}, err
+		}
+
+		children = append(children, child)
+	}
+
+	return filterNode{kind: kind, pos: pos, children: children}, nil
+}
+
+func (parser *filterParser) parseUnary() (filterNode, error) {
+	token := parser.peek()
+
+	if parser.isKeyword(token, "not") {
+		parser.next()
+		child, err := parser.parseUnary()
+
+		if err != nil {
+			return filterNode{}, err
+		}
+
+		return filterNode{kind: filterNodeNot, pos: token.pos, children: []filterNode{child}}, nil
+	}
+
+	if token.kind == filterTokenLeftParen {
+		parser.next()
+		node, err := parser.parseOr()
+
+		if err != nil {
+			return filterNode{}, err
+		}
+
+		if closing := parser.next(); closing.kind != filterTokenRightParen {
+			return filterNode{}, parser.errorf(closing.pos, `expected ")" but found %q`, closing.value)
+		}
+
+		return node, nil
+	}
+
+	return parser.parseCondition()
+}
+
+func (parser *filterParser) parseCondition() (filterNode, error) {
+	key := parser.next()
+
+	if (key.kind != filterTokenWord && key.kind != filterTokenString) || isFilterKeyword(key.value) {
+		return filterNode{}, parser.errorf(key.pos, "expected field but found %q", key.value)
+	}
+
+	operator := parser.next()
+
+	if operator.kind != filterTokenOperator {
+		return filterNode{}, parser.errorf(operator.pos, "expected operator but found %q", operator.value)
+	}
+
+	value := parser.next()
+
+	if value.kind != filterTokenWord && value.kind != filterTokenString {
+		return filterNode{}, parser.errorf(value.pos, "expected value but found %q", value.value)
+	}
+
+	condition := filterCondition{
+		key:         strings.ToLower(key.value),
+		operator:    operator.value,
+		value:       value.value,
+		null:        value.kind == filterTokenWord && strings.ToLower(value.value) == "null",
+		pos:         key.pos,
+		operatorPos: operator.pos,
+		valuePos:    value.pos,
+	}
+
+	if strings.HasPrefix(condition.key, filterKeyMetaPrefix) {
+		// keep the case of the meta key
+		condition.key = filterKeyMetaPrefix + key.value[len(filterKeyMetaPrefix):]
+	}
+
+	if err := parser.validateCondition(condition); err != nil {
+		return filterNode{}, err
+	}
+
+	return filterNode{kind: filterNodeCondition, pos: key.pos, condition: condition}, nil
+}
+
+func (parser *filterParser) validateCondition(condition filterCondition) error {
+	isMeta := strings.HasPrefix(condition.key, filterKeyMetaPrefix)
+
+	if isMeta && len(condition.key) == len(filterKeyMetaPrefix) {
+		return parser.errorf(condition.pos, "missing event metadata key")
+	}
+
+	isRange := filterRangeProperty(condition.key) != ""
+
+	if !isMeta && !isRange && condition.key != filterKeyPlatform && filterExpressionField(condition.key) == nil {
+		return parser.errorf(condition.pos, "unknown field %q", condition.key)
+	}
+
+	isComparison := condition.operator == filterOperatorGreater ||
+		condition.operator == filterOperatorGreaterOrEqual ||
+		condition.operator == filterOperatorLess ||
+		condition.operator == filterOperatorLessOrEqual
+
+	if isRange {
+		if condition.operator == filterOperatorContains || condition.operator == filterOperatorNotContains {
+			return parser.errorf(condition.operatorPos, "operator %q is not supported for %s", condition.operator, condition.key)
+		}
+
+		if _, err := strconv.Atoi(condition.value); err != nil || condition.null {
+			return parser.errorf(condition.valuePos, "%s must be compared to a whole number", condition.key)
+		}
+
+		return nil
+	} else if isComparison {
+		return parser.errorf(condition.operatorPos, "operator %q is only supported for session properties", condition.operator)
+	}
+
+	if (condition.operator == filterOperatorContains || condition.operator == filterOperatorNotContains) && condition.null {
+		return parser.errorf(condition.valuePos, "null cannot be used with %q", condition.operator)
+	}
+
+	if condition.key == filterKeyPath || condition.key == filterKeyPathPattern {
+		if condition.key == filterKeyPathPattern && (condition.operator == filterOperatorContains || condition.operator == filterOperatorNotContains) {
+			return parser.errorf(condition.operatorPos, "operator %q is not supported for %s", condition.operator, condition.key)
+		}
+
+		if condition.null && condition.key == filterKeyPathPattern {
+			return parser.errorf(condition.valuePos, "null cannot be used for %s", condition.key)
+		}
+	} else if condition.key == filterKeyPlatform && (condition.operator == filterOperatorContains || condition.operator == filterOperatorNotContains) {
+		return parser.errorf(condition.operatorPos, "operator %q is not supported for %s", condition.operator, condition.key)
+	}
+
+	if !condition.null && condition.target() != filterKeyPathPattern && (strings.HasPrefix(condition.value, "!") || strings.HasPrefix(condition.value, "~")) {
+		return parser.errorf(condition.valuePos, `values must not start with "!" or "~"`)
+	}
+
+	if condition.key == filterKeyPathPattern && strings.HasPrefix(condition.value, "!") {
+		return parser.errorf(condition.valuePos, `patterns must not start with "!"`)
+	}
+
+	return nil
+}
+
+func filterRangeProperty(key string) RangeProperty {
+	for _, property := range rangeProperties {
+		if string(property) == key {
+			return property
+		}
+	}
+
+	return ""
+}
+
+func filterExpressionField(key string) func(*Filter) *[]string {
+	for _, field := range filterExpressionFields {
+		if field.key == key {
+			return field.values
+		}
+	}
+
+	return nil
+}
+
+// clauses returns the conditions of the node as clauses joined by "and", containing conditions joined by "or".
+func (node *filterNode) clauses(negate bool) ([]filterClause, error) {
+	switch node.kind {
+	case filterNodeNot:
+		return node.children[0].clauses(!negate)
+	case filterNodeAnd, filterNodeOr:
+		if (node.kind == filterNodeAnd) != negate {
+			clauses := make([]filterClause, 0, len(node.children))
+
+			for i := range node.children {
+				childClauses, err := node.children[i].clauses(negate)
+
+				if err != nil {
+					return nil, err
+				}
+
+				clauses = append(clauses, childClauses...)
+			}
+
+			return clauses, nil
+		}
+
+		clause := make(filterClause, 0, len(node.children))
+
+		for i := range node.children {
+			childClauses, err := node.children[i].clauses(negate)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if len(childClauses) != 1 {
+				return nil, &FilterExpressionError{Pos: node.children[i].pos + 1, Message: `conditions joined by "and" cannot be used inside "or"`}
+			}
+
+			clause = append(clause, childClauses[0]...)
+		}
+
+		return []filterClause{clause}, nil
+	default:
+		condition := node.condition
+
+		if negate {
+			condition.operator = condition.negatedOperator()
+		}
+
+		return []filterClause{{condition}}, nil
+	}
+}
+
+// tree returns the node as a FilterTree.
+func (node *filterNode) tree() (FilterTree, error) {
+	switch node.kind {
+	case filterNodeNot:
+		tree, err := node.children[0].tree()
+		tree.Not = !tree.Not
+		return tree, err
+	case filterNodeAnd, filterNodeOr:
+		tree := FilterTree{
+			Or:       node.kind == filterNodeOr,
+			Children: make([]FilterTree, 0, len(node.children)),
+		}
+
+		for i := range node.children {
+			child, err := node.children[i].tree()
+
+			if err != nil {
+				return FilterTree{}, err
+			}
+
+			tree.Children = append(tree.Children, child)
+		}
+
+		return tree, nil
+	default:
+		return node.condition.tree()
+	}
+}
+
+func (condition *filterCondition) negatedOperator() string {
+	switch condition.operator {
+	case filterOperatorEqual:
+		return filterOperatorNotEqual
+	case filterOperatorNotEqual:
+		return filterOperatorEqual
+	case filterOperatorContains:
+		return filterOperatorNotContains
+	case filterOperatorGreater:
+		return filterOperatorLessOrEqual
+	case filterOperatorGreaterOrEqual:
+		return filterOperatorLess
+	case filterOperatorLess:
+		return filterOperatorGreaterOrEqual
+	case filterOperatorLessOrEqual:
+		return filterOperatorGreater
+	default:
+		return filterOperatorContains
+	}
+}
+
+func (condition *filterCondition) negative() bool {
+	return condition.operator == filterOperatorNotEqual || condition.operator == filterOperatorNotContains
+}
+
+// target returns the key of the Filter field the condition is stored in.
+// The path is matched using a pattern in case the value contains a *, or otherwise checked to contain the value.
+func (condition *filterCondition) target() string {
+	if condition.key == filterKeyPath &&
+		(condition.operator == filterOperatorContains || condition.operator == filterOperatorNotContains) &&
+		strings.Contains(condition.value, "*") {
+		return filterKeyPathPattern
+	}
+
+	return condition.key
+}
+
+// filterValue returns the value as stored in the Filter.
+func (condition *filterCondition) filterValue() (string, error) {
+	value := condition.value
+
+	if condition.null {
+		value = "null"
+	}
+
+	if condition.target() == filterKeyPathPattern {
+		if condition.key == filterKeyPath {
+			value = globToPattern(value)
+		}
+
+		if condition.negative() {
+			return "!" + value, nil
+		}
+
+		return value, nil
+	}
+
+	switch condition.operator {
+	case filterOperatorNotEqual:
+		return "!" + value, nil
+	case filterOperatorContains:
+		return "~" + value, nil
+	case filterOperatorNotContains:
+		return "", &FilterExpressionError{Pos: condition.operatorPos + 1, Message: fmt.Sprintf("operator %q is only supported for path patterns", condition.operator)}
+	default:
+		return value, nil
+	}
+}
+
+// tree returns the condition as a FilterTree.
+// Values are stored like in the Filter, except for "!~", which is stored as an inverted "~".
+func (condition *filterCondition) tree() (FilterTree, error) {
+	target := condition.target()
+
+	if target == filterKeyPathPattern {
+		value, err := condition.filterValue()
+		return FilterTree{Field: FieldPath, Values: []string{value}, Pattern: true}, err
+	}
+
+	var field Field
+
+	for _, f := range filterExpressionFields {
+		if f.key == target {
+			field = f.field
+			break
+		}
+	}
+
+	if field.Name == "" {
+		return FilterTree{}, &FilterExpressionError{Pos: condition.pos + 1, Message: fmt.Sprintf(`%s cannot be combined with other fields`, target)}
+	}
+
+	if condition.operator == filterOperatorNotContains {
+		inverted := *condition
+		inverted.operator = filterOperatorContains
+		value, err := inverted.filterValue()
+		return FilterTree{Not: true, Field: field, Values: []string{value}}, err
+	}
+
+	value, err := condition.filterValue()
+	return FilterTree{Field: field, Values: []string{value}}, err
+}
+
+// conflicts returns an error in case the clause cannot be stored in the Filter fields without changing its meaning.
+// Positive values of a field are joined by "or", so a second positive clause joined by "and" cannot be added to the same field.
+// The path and path patterns cannot be combined either, as Filter.PathPattern is ignored if Filter.Path is set.
+func (clause filterClause) conflicts(filter *Filter) error {
+	first := clause[0]
+	target := first.target()
+
+	if target == filterKeyPlatform || strings.HasPrefix(target, filterKeyMetaPrefix) {
+		return nil
+	}
+
+	if target == filterKeyPath && len(filter.PathPattern) != 0 || target == filterKeyPathPattern && len(filter.Path) != 0 {
+		return &FilterExpressionError{Pos: first.pos + 1, Message: "path and path patterns cannot be combined"}
+	}
+
+	if !first.negative() {
+		for _, value := range *filterExpressionField(target)(filter) {
+			if !strings.HasPrefix(value, "!") {
+				return &FilterExpressionError{Pos: first.pos + 1, Message: fmt.Sprintf(`%s must not be joined by "and" with itself`, target)}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (clause filterClause) apply(filter *Filter) error {
+	first := clause[0]
+	target := first.target()
+
+	if len(clause) > 1 {
+		for _, condition := range clause {
+			if condition.target() != target {
+				return &FilterExpressionError{Pos: condition.pos + 1, Message: `conditions joined by "or" must be for the same field`}
+			}
+
+			if condition.negative() {
+				return &FilterExpressionError{Pos: condition.operatorPos + 1, Message: `negated conditions cannot be joined by "or"`}
+			}
+		}
+
+		if target == filterKeyPlatform || strings.HasPrefix(target, filterKeyMetaPrefix) || filterRangeProperty(target) != "" {
+			return &FilterExpressionError{Pos: clause[1].pos + 1, Message: fmt.Sprintf(`%s cannot be joined by "or"`, target)}
+		}
+	}
+
+	if property := filterRangeProperty(target); property != "" {
+		value, _ := strconv.Atoi(first.value)
+		filter.Ranges = append(filter.Ranges, Range{
+			Property: property,
+			Operator: pkg.Operator(first.operator),
+			Value:    value,
+		})
+		return nil
+	}
+
+	if err := clause.conflicts(filter); err != nil {
+		return err
+	}
+
+	for _, condition := range clause {
+		value, err := condition.filterValue()
+
+		if err != nil {
+			return err
+		}
+
+		if target == filterKeyPlatform {
+			if filter.Platform != "" {
+				return &FilterExpressionError{Pos: condition.pos + 1, Message: fmt.Sprintf("%s can only be used once", target)}
+			}
+
+			filter.Platform = value
+		} else if strings.HasPrefix(target, filterKeyMetaPrefix) {
+			key := target[len(filterKeyMetaPrefix):]
+
+			if _, ok := filter.EventMeta[key]; ok {
+				return &FilterExpressionError{Pos: condition.pos + 1, Message: fmt.Sprintf("%s can only be used once", target)}
+			}
+
+			if filter.EventMeta == nil {
+				filter.EventMeta = make(map[string]string)
+			}
+
+			filter.EventMeta[key] = value
+		} else {
+			values := filterExpressionField(target)(filter)
+			*values = append(*values, value)
+		}
+	}
+
+	return nil
+}
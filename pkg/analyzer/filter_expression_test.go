@@ -0,0 +1,242 @@
+package analyzer
+
+import (
+	"errors"
+	"github.com/pirsch-analytics/pirsch/v6/pkg"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseFilter(t *testing.T) {
+	filter, err := ParseFilter(`path ~ "/blog/**" and country != de and (referrer_name = Google or referrer_name = "Bing Search")`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`(?i)^/blog/.*$`}, filter.PathPattern)
+	assert.Equal(t, []string{"!de"}, filter.Country)
+	assert.Equal(t, []string{"Google", "Bing Search"}, filter.ReferrerName)
+	assert.Empty(t, filter.Path)
+	filter, err = ParseFilter(`not (country = de or country = gb) and city = null and region != NULL and utm_source ~ news AND Browser = "null"`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"!de", "!gb"}, filter.Country)
+	assert.Equal(t, []string{"null"}, filter.City)
+	assert.Equal(t, []string{"!null"}, filter.Region)
+	assert.Equal(t, []string{"~news"}, filter.UTMSource)
+	assert.Equal(t, []string{"null"}, filter.Browser)
+	filter, err = ParseFilter(`path = / or path ~ about or path = "/a \"quoted\" path"`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/", "~about", `/a "quoted" path`}, filter.Path)
+	filter, err = ParseFilter(`path !~ "/*/private" and path_pattern = "^/[0-9]+$" and platform != mobile and meta.Plan = pro and "meta.sign up" ~ yes and not meta.ref = null`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`!(?i)^/[^/]+/private$`, "^/[0-9]+$"}, filter.PathPattern)
+	assert.Equal(t, "!mobile", filter.Platform)
+	assert.Equal(t, map[string]string{"Plan": "pro", "sign up": "~yes", "ref": "!null"}, filter.EventMeta)
+	filter, err = ParseFilter(" \t")
+	assert.NoError(t, err)
+	assert.Equal(t, new(Filter), filter)
+	filter, err = ParseFilter(`session_duration>30 and not page_views < 3 and bounce = 0 and events != 0 and time_on_page <= -1`)
+	assert.NoError(t, err)
+	assert.Equal(t, []Range{
+		{Property: RangeSessionDuration, Operator: pkg.OperatorGreater, Value: 30},
+		{Property: RangePageViews, Operator: pkg.OperatorGreaterOrEqual, Value: 3},
+		{Property: RangeBounce, Operator: pkg.OperatorEqual, Value: 0},
+		{Property: RangeEvents, Operator: pkg.OperatorNotEqual, Value: 0},
+		{Property: RangeTimeOnPage, Operator: pkg.OperatorLessOrEqual, Value: -1},
+	}, filter.Ranges)
+	filter, err = ParseFilter(`not not event_name = signup and (event_meta_key = plan)`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"signup"}, filter.EventName)
+	assert.Equal(t, []string{"plan"}, filter.EventMetaKey)
+}
+
+func TestParseFilterTree(t *testing.T) {
+	filter, err := ParseFilter(`path = /a and (country = de or utm_source = newsletter) and not (browser = Chrome and os != Windows)`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/a"}, filter.Path)
+	assert.Empty(t, filter.Country)
+	assert.Equal(t, &FilterTree{
+		Children: []FilterTree{
+			{
+				Or: true,
+				Children: []FilterTree{
+					{Field: FieldCountry, Values: []string{"de"}},
+					{Field: FieldUTMSource, Values: []string{"newsletter"}},
+				},
+			},
+			{
+				Not: true,
+				Children: []FilterTree{
+					{Field: FieldBrowser, Values: []string{"Chrome"}},
+					{Field: FieldOS, Values: []string{"!Windows"}},
+				},
+			},
+		},
+	}, filter.Tree)
+	filter, err = ParseFilter(`country != de or path ~ "/blog/**"`)
+	assert.NoError(t, err)
+	assert.Equal(t, &FilterTree{
+		Or: true,
+		Children: []FilterTree{
+			{Field: FieldCountry, Values: []string{"!de"}},
+			{Field: FieldPath, Values: []string{`(?i)^/blog/.*$`}, Pattern: true},
+		},
+	}, filter.Tree)
+	filter, err = ParseFilter(`not country ~ de and event_name = signup`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"signup"}, filter.EventName)
+	assert.Equal(t, &FilterTree{Not: true, Field: FieldCountry, Values: []string{"~de"}}, filter.Tree)
+	filter, err = ParseFilter(`country = de and country = fr and country != gb`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"de", "!gb"}, filter.Country)
+	assert.Equal(t, &FilterTree{Field: FieldCountry, Values: []string{"fr"}}, filter.Tree)
+	filter, err = ParseFilter(`not (country != de or country != fr)`)
+	assert.NoError(t, err)
+	assert.Empty(t, filter.Country)
+	assert.Equal(t, &FilterTree{
+		Not: true,
+		Or:  true,
+		Children: []FilterTree{
+			{Field: FieldCountry, Values: []string{"!de"}},
+			{Field: FieldCountry, Values: []string{"!fr"}},
+		},
+	}, filter.Tree)
+	filter, err = ParseFilter(`(country = de and country = fr) or country = gb`)
+	assert.NoError(t, err)
+	assert.Empty(t, filter.Country)
+	assert.True(t, filter.Tree.Or)
+	filter, err = ParseFilter(`path = / and path_pattern = ^/a$`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/"}, filter.Path)
+	assert.Empty(t, filter.PathPattern)
+	assert.Equal(t, &FilterTree{Field: FieldPath, Values: []string{"^/a$"}, Pattern: true}, filter.Tree)
+	filter, err = ParseFilter(`country !~ de`)
+	assert.NoError(t, err)
+	assert.Equal(t, &FilterTree{Not: true, Field: FieldCountry, Values: []string{"~de"}}, filter.Tree)
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	input := []struct {
+		expression string
+		pos        int
+	}{
+		{`country = de and`, 17},
+		{`country de`, 9},
+		{`country = `, 11},
+		{`(country = de`, 14},
+		{`country = de)`, 13},
+		{`country ! de`, 9},
+		{`country = "de`, 11},
+		{`unknown = de`, 1},
+		{`path_pattern ~ x`, 14},
+		{`country = "!de"`, 11},
+		{`city ~ null`, 8},
+		{`platform = desktop and platform = mobile`, 24},
+		{`meta.plan = a or meta.plan = b`, 18},
+		{`meta.plan = a or country = de`, 18},
+		{`platform = desktop or country = de`, 23},
+		{`country = de or event_meta_key = plan`, 17},
+		{`event_meta_key !~ plan`, 16},
+		{`meta.plan !~ a`, 11},
+		{`platform !~ mobile`, 10},
+		{`event_meta_key = a and event_meta_key = b`, 24},
+		{`session_duration ~ 3`, 18},
+		{`page_views > x`, 14},
+		{`bounce = null`, 10},
+		{`country > 3`, 9},
+		{`country = a<b`, 12},
+		{`session_duration > 30 or session_duration < 10`, 26},
+		{`session_duration > 30 or country = de`, 26},
+		{`meta.plan = a and meta.plan = b`, 19},
+		{`meta. = a`, 1},
+		{`and = a`, 1},
+	}
+
+	for _, in := range input {
+		_, err := ParseFilter(in.expression)
+		var expressionErr *FilterExpressionError
+
+		if assert.True(t, errors.As(err, &expressionErr), in.expression) {
+			assert.Equal(t, in.pos, expressionErr.Pos, in.expression)
+			assert.Contains(t, expressionErr.Error(), "at position")
+		}
+	}
+}
+
+func TestFormatFilter(t *testing.T) {
+	input := []string{
+		`path ~ /blog/** and country != de and (referrer_name = Google or referrer_name = "Bing Search")`,
+		`(path = / or path ~ about) and entry_path = "/a \"quoted\" path" and path_pattern = ^/[0-9]+$ and path !~ /*/private`,
+		`region != null and city = null and os = "" and browser = "and" and utm_source ~ news`,
+		`event_name = signup and event_meta_key != plan and platform != mobile and meta.Plan = pro and meta.ref != null and "meta.sign up" ~ yes`,
+		`language ~ de,en and country = de and country != gb and country != fr`,
+		`(country = de or browser = Chrome)`,
+		`country = de and country = fr`,
+		`(country = de or country = fr) and country != gb and (country = gb or country = it)`,
+		`os = Linux and (country = de or (country = fr and country = it))`,
+		`country = "a<b" and session_duration >= 30 and bounce = 1 and events < 2`,
+		`path = /a and event_name != signup and path !~ /blog/** and (entry_path = /a or (country = de and country != null)) and not (utm_source ~ news)`,
+		``,
+	}
+
+	for _, in := range input {
+		filter, err := ParseFilter(in)
+		assert.NoError(t, err, in)
+		expression, err := FormatFilter(filter)
+		assert.NoError(t, err, in)
+		assert.Equal(t, in, expression)
+		roundTrip, err := ParseFilter(expression)
+		assert.NoError(t, err, in)
+		assert.Equal(t, filter, roundTrip)
+	}
+
+	filter := &Filter{
+		Path:        []string{"!/", "/home"},
+		PathPattern: []string{"(?i)^/about$", `(?i)^/user/[^/]+\.json$`, "!(?i)^/a\\*b/**$"},
+		Country:     []string{"NULL"},
+		ClientID:    42,
+	}
+	expression, err := FormatFilter(filter)
+	assert.NoError(t, err)
+	assert.Equal(t, `path = /home and path != / and (path_pattern = "(?i)^/about$" or path ~ /user/*.json) and path_pattern != "(?i)^/a\\*b/**$" and country = null`, expression)
+	roundTrip, err := ParseFilter(expression)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/home", "!/"}, roundTrip.Path)
+	assert.Empty(t, roundTrip.PathPattern)
+
+	// Filter.PathPattern is ignored if Filter.Path is set, so the patterns are stored in the tree
+	if assert.NotNil(t, roundTrip.Tree) && assert.Len(t, roundTrip.Tree.Children, 2) {
+		assert.Equal(t, filter.PathPattern[:1], roundTrip.Tree.Children[0].Children[0].Values)
+		assert.Equal(t, filter.PathPattern[1:2], roundTrip.Tree.Children[0].Children[1].Values)
+	}
+
+	expression, err = FormatFilter(&Filter{Path: []string{"!/"}, AnyPath: []string{"/a", "/b"}})
+	assert.NoError(t, err)
+	assert.Equal(t, `path != / and (path = /a or path = /b)`, expression)
+	roundTrip, err = ParseFilter(expression)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"!/", "/a", "/b"}, roundTrip.Path)
+	_, err = FormatFilter(&Filter{Search: []Search{{Field: FieldPath, Input: "blog"}}})
+	assert.ErrorIs(t, err, ErrFilterExpressionSearch)
+	expression, err = FormatFilter(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, expression)
+}
+
+func TestGlobToPattern(t *testing.T) {
+	input := map[string]string{
+		"/blog/**":          `(?i)^/blog/.*$`,
+		"/*/settings":       `(?i)^/[^/]+/settings$`,
+		"/file.json":        `(?i)^/file\.json$`,
+		"/a/**/b/*.html(1)": `(?i)^/a/.*/b/[^/]+\.html\(1\)$`,
+	}
+
+	for glob, pattern := range input {
+		assert.Equal(t, pattern, globToPattern(glob))
+		g, ok := patternToGlob(pattern)
+		assert.True(t, ok)
+		assert.Equal(t, glob, g)
+	}
+
+	for _, pattern := range []string{"^/blog$", `(?i)^/a+$`, `(?i)^/a\*$`, `(?i)^/a\$`, `(?i)^/[0-9]$`} {
+		_, ok := patternToGlob(pattern)
+		assert.False(t, ok, pattern)
+	}
+}
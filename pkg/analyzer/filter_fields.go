@@ -222,6 +222,14 @@ var (
 		Name:           "country_code",
 	}
 
+	// FieldRegion is a query result column.
+	FieldRegion = Field{
+		querySessions:  "region",
+		queryPageViews: "region",
+		queryDirection: "ASC",
+		Name:           "region",
+	}
+
 	// FieldCity is a query result column.
 	FieldCity = Field{
 		querySessions:  "city",
@@ -230,6 +238,30 @@ var (
 		Name:           "city",
 	}
 
+	// FieldTimeZone is a query result column.
+	FieldTimeZone = Field{
+		querySessions:  "time_zone",
+		queryPageViews: "time_zone",
+		queryDirection: "ASC",
+		Name:           "time_zone",
+	}
+
+	// FieldASN is a query result column.
+	FieldASN = Field{
+		querySessions:  "asn",
+		queryPageViews: "asn",
+		queryDirection: "ASC",
+		Name:           "asn",
+	}
+
+	// FieldASOrganization is a query result column.
+	FieldASOrganization = Field{
+		querySessions:  "as_organization",
+		queryPageViews: "as_organization",
+		queryDirection: "ASC",
+		Name:           "as_organization",
+	}
+
 	// FieldBrowser is a query result column.
 	FieldBrowser = Field{
 		querySessions:  "browser",
@@ -262,6 +294,30 @@ var (
 		Name:           "os_version",
 	}
 
+	// FieldDeviceType is a query result column.
+	FieldDeviceType = Field{
+		querySessions:  "device_type",
+		queryPageViews: "device_type",
+		queryDirection: "ASC",
+		Name:           "device_type",
+	}
+
+	// FieldDeviceVendor is a query result column.
+	FieldDeviceVendor = Field{
+		querySessions:  "device_vendor",
+		queryPageViews: "device_vendor",
+		queryDirection: "ASC",
+		Name:           "device_vendor",
+	}
+
+	// FieldDeviceModel is a query result column.
+	FieldDeviceModel = Field{
+		querySessions:  "device_model",
+		queryPageViews: "device_model",
+		queryDirection: "ASC",
+		Name:           "device_model",
+	}
+
 	// FieldScreenClass is a query result column.
 	FieldScreenClass = Field{
 		querySessions:  "screen_class",
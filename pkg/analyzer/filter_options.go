@@ -64,11 +64,26 @@ func (options *FilterOptions) Countries(filter *Filter) ([]string, error) {
 	return options.selectFilterOptions(filter, "country_code", "session")
 }
 
+// Regions returns all regions.
+func (options *FilterOptions) Regions(filter *Filter) ([]string, error) {
+	return options.selectFilterOptions(filter, "region", "session")
+}
+
 // Cities returns all cities.
 func (options *FilterOptions) Cities(filter *Filter) ([]string, error) {
 	return options.selectFilterOptions(filter, "city", "session")
 }
 
+// TimeZones returns all time zones.
+func (options *FilterOptions) TimeZones(filter *Filter) ([]string, error) {
+	return options.selectFilterOptions(filter, "time_zone", "session")
+}
+
+// ASOrganizations returns all network organizations.
+func (options *FilterOptions) ASOrganizations(filter *Filter) ([]string, error) {
+	return options.selectFilterOptions(filter, "as_organization", "session")
+}
+
 // Languages returns all languages.
 func (options *FilterOptions) Languages(filter *Filter) ([]string, error) {
 	return options.selectFilterOptions(filter, "language", "session")
@@ -179,6 +179,29 @@ func TestFilterOptions_Countries(t *testing.T) {
 	assert.Equal(t, "ja", options[1])
 }
 
+func TestFilterOptions_Regions(t *testing.T) {
+	db.CleanupDB(t, dbClient)
+	assert.NoError(t, dbClient.SaveSessions([]model.Session{
+		{Sign: 1, VisitorID: 1, SessionID: 1, Time: util.PastDay(4), Start: util.PastDay(4), Region: "us-ma"},
+		{Sign: 1, VisitorID: 1, SessionID: 1, Time: util.PastDay(2), Start: util.PastDay(2), Region: "jp-13"},
+		{Sign: 1, VisitorID: 1, SessionID: 2, Time: util.PastDay(2), Start: util.PastDay(2), Region: "jp-13"},
+		{Sign: 1, VisitorID: 1, SessionID: 1, Time: util.PastDay(1), Start: util.PastDay(1), Region: "de-be"},
+	}))
+	time.Sleep(time.Millisecond * 20)
+	analyzer := NewAnalyzer(dbClient)
+	options, err := analyzer.Options.Regions(nil)
+	assert.NoError(t, err)
+	assert.Len(t, options, 3)
+	assert.Equal(t, "de-be", options[0])
+	assert.Equal(t, "jp-13", options[1])
+	assert.Equal(t, "us-ma", options[2])
+	options, err = analyzer.Options.Regions(&Filter{From: util.PastDay(3), To: util.Today()})
+	assert.NoError(t, err)
+	assert.Len(t, options, 2)
+	assert.Equal(t, "de-be", options[0])
+	assert.Equal(t, "jp-13", options[1])
+}
+
 func TestFilterOptions_Cities(t *testing.T) {
 	db.CleanupDB(t, dbClient)
 	assert.NoError(t, dbClient.SaveSessions([]model.Session{
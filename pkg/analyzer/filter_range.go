@@ -0,0 +1,126 @@
+package analyzer
+
+import (
+	"github.com/pirsch-analytics/pirsch/v6/pkg"
+)
+
+const (
+	// RangeSessionDuration is the duration of a session in seconds.
+	RangeSessionDuration = RangeProperty("session_duration")
+
+	// RangePageViews is the number of page views of a session.
+	RangePageViews = RangeProperty("page_views")
+
+	// RangeBounce is the bounce status of a session, which is 1 for bounced sessions and 0 otherwise.
+	RangeBounce = RangeProperty("bounce")
+
+	// RangeEvents is the number of events of a session.
+	RangeEvents = RangeProperty("events")
+
+	// RangeTimeOnPage is the average time spent on a page of a session in seconds.
+	RangeTimeOnPage = RangeProperty("time_on_page")
+)
+
+var (
+	rangeProperties = []RangeProperty{
+		RangeSessionDuration,
+		RangePageViews,
+		RangeBounce,
+		RangeEvents,
+		RangeTimeOnPage,
+	}
+
+	// the expressions are evaluated for each session (grouped by visitor_id and session_id)
+	rangeExpressions = map[RangeProperty]string{
+		RangeSessionDuration: "sum(duration_seconds*sign)",
+		RangePageViews:       "sum(page_views*sign)",
+		RangeBounce:          "sum(is_bounce*sign)",
+		RangeEvents:          "count(*)",
+		RangeTimeOnPage:      "ifNull(toUInt64(avg(nullIf(duration_seconds, 0))), 0)",
+	}
+
+	rangeOperators = []pkg.Operator{
+		pkg.OperatorEqual,
+		pkg.OperatorNotEqual,
+		pkg.OperatorGreater,
+		pkg.OperatorGreaterOrEqual,
+		pkg.OperatorLess,
+		pkg.OperatorLessOrEqual,
+	}
+)
+
+// RangeProperty is a numeric session property used to filter sessions.
+type RangeProperty string
+
+// Range filters sessions by comparing a numeric property to a value, like a session duration greater than 30 seconds.
+type Range struct {
+	Property RangeProperty
+	Operator pkg.Operator
+	Value    int
+}
+
+// source returns the table the property is calculated from.
+func (r *Range) source() table {
+	switch r.Property {
+	case RangeEvents:
+		return events
+	case RangeTimeOnPage:
+		return pageViews
+	default:
+		return sessions
+	}
+}
+
+// matches returns whether the value is within the range.
+func (r *Range) matches(value int) bool {
+	switch r.Operator {
+	case pkg.OperatorEqual:
+		return value == r.Value
+	case pkg.OperatorNotEqual:
+		return value != r.Value
+	case pkg.OperatorGreater:
+		return value > r.Value
+	case pkg.OperatorGreaterOrEqual:
+		return value >= r.Value
+	case pkg.OperatorLess:
+		return value < r.Value
+	default:
+		return value <= r.Value
+	}
+}
+
+func (filter *Filter) validateRanges(in []Range) []Range {
+	if len(in) == 0 {
+		return nil
+	}
+
+	ranges := make([]Range, 0, len(in))
+
+	for _, r := range in {
+		validProperty, validOperator := false, false
+
+		for _, property := range rangeProperties {
+			if r.Property == property {
+				validProperty = true
+				break
+			}
+		}
+
+		for _, operator := range rangeOperators {
+			if r.Operator == operator {
+				validOperator = true
+				break
+			}
+		}
+
+		if validProperty && validOperator {
+			ranges = append(ranges, r)
+		}
+	}
+
+	if len(ranges) == 0 {
+		return nil
+	}
+
+	return ranges
+}
@@ -40,6 +40,77 @@ func TestFilter_Validate(t *testing.T) {
 	assert.Equal(t, "pattern", filter.PathPattern[0])
 }
 
+func TestFilter_comparisonPeriod(t *testing.T) {
+	filter := &Filter{From: util.PastDay(9), To: util.PastDay(5), Compare: pkg.ComparePrevious}
+	filter.validate()
+	previous, err := filter.comparisonPeriod()
+	assert.NoError(t, err)
+	assert.Equal(t, util.PastDay(14), previous.From)
+	assert.Equal(t, util.PastDay(10), previous.To)
+	assert.Equal(t, util.PastDay(9), filter.From)
+	filter = &Filter{From: util.PastDay(3), To: util.PastDay(3), Compare: pkg.ComparePrevious}
+	filter.validate()
+	previous, err = filter.comparisonPeriod()
+	assert.NoError(t, err)
+	assert.Equal(t, util.PastDay(10), previous.From)
+	assert.Equal(t, util.PastDay(10), previous.To)
+	filter = &Filter{From: util.Today().Add(time.Hour), To: util.Today().Add(time.Hour * 3), IncludeTime: true, Compare: pkg.ComparePrevious}
+	filter.validate()
+	previous, err = filter.comparisonPeriod()
+	assert.NoError(t, err)
+	assert.Equal(t, util.PastDay(1).Add(time.Hour), previous.From)
+	assert.Equal(t, util.PastDay(1).Add(time.Hour*3), previous.To)
+	filter = &Filter{From: util.Today(), To: util.Today(), Compare: pkg.ComparePrevious}
+	filter.validate()
+	previous, err = filter.comparisonPeriod()
+	assert.NoError(t, err)
+	assert.True(t, previous.IncludeTime)
+	assert.Equal(t, util.PastDay(7), previous.From)
+	assert.WithinDuration(t, time.Now().Add(-time.Hour*24*7), previous.To, time.Second)
+	filter = &Filter{From: util.PastDay(3), To: util.PastDay(3)}
+	filter.validate()
+	previous, err = filter.comparisonPeriod()
+	assert.NoError(t, err)
+	assert.Equal(t, util.PastDay(10), previous.From)
+	assert.Equal(t, util.PastDay(10), previous.To)
+	filter = &Filter{From: util.PastDay(9), To: util.PastDay(5)}
+	filter.validate()
+	previous, err = filter.comparisonPeriod()
+	assert.NoError(t, err)
+	assert.Equal(t, util.PastDay(14), previous.From)
+	assert.Equal(t, util.PastDay(10), previous.To)
+	filter = &Filter{From: util.PastDay(3), To: util.PastDay(3), Compare: pkg.ComparePreviousWeekday}
+	filter.validate()
+	previous, err = filter.comparisonPeriod()
+	assert.NoError(t, err)
+	assert.Equal(t, util.PastDay(10), previous.From)
+	assert.Equal(t, util.PastDay(10), previous.To)
+	filter = &Filter{From: util.PastDay(16), To: util.PastDay(8), Compare: pkg.ComparePreviousWeekday}
+	filter.validate()
+	previous, err = filter.comparisonPeriod()
+	assert.NoError(t, err)
+	assert.Equal(t, util.PastDay(30), previous.From)
+	assert.Equal(t, util.PastDay(22), previous.To)
+	filter = &Filter{From: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), Compare: pkg.CompareYear}
+	filter.validate()
+	previous, err = filter.comparisonPeriod()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC), previous.From)
+	assert.Equal(t, time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC), previous.To)
+	filter = &Filter{From: util.PastDay(3), To: util.PastDay(2), Compare: pkg.CompareCustom, CompareFrom: util.PastDay(20).Add(time.Hour), CompareTo: util.PastDay(30)}
+	filter.validate()
+	previous, err = filter.comparisonPeriod()
+	assert.NoError(t, err)
+	assert.Equal(t, util.PastDay(30), previous.From)
+	assert.Equal(t, util.PastDay(20), previous.To)
+	filter = &Filter{From: util.PastDay(3), To: util.PastDay(2), Compare: pkg.CompareCustom}
+	_, err = filter.comparisonPeriod()
+	assert.ErrorIs(t, err, ErrNoComparisonPeriod)
+	filter = &Filter{Compare: pkg.CompareYear}
+	_, err = filter.comparisonPeriod()
+	assert.ErrorIs(t, err, ErrNoPeriodOrDay)
+}
+
 func TestFilter_RemoveDuplicates(t *testing.T) {
 	filter := NewFilter(pkg.NullClient)
 	filter.Path = []string{
@@ -58,6 +129,255 @@ func TestFilter_RemoveDuplicates(t *testing.T) {
 	assert.Equal(t, "/bar", filter.Path[3])
 }
 
+func TestFilter_normalizeTree(t *testing.T) {
+	filter := &Filter{
+		Tree: &FilterTree{
+			Not: true,
+			Children: []FilterTree{
+				{Field: FieldCountry, Values: []string{"de", "de"}},
+				{Field: FieldVisitors, Values: []string{"42"}},
+				{Field: FieldCountry, Values: []string{"^/.*$"}, Pattern: true},
+				{Or: true, Children: []FilterTree{{Field: FieldPath}}},
+			},
+		},
+	}
+	filter.validate()
+	assert.Equal(t, &FilterTree{Not: true, Field: FieldCountry, Values: []string{"de"}}, filter.Tree)
+	filter = &Filter{Tree: &FilterTree{Or: true}}
+	filter.validate()
+	assert.Nil(t, filter.Tree)
+	tree := &FilterTree{
+		Or: true,
+		Children: []FilterTree{
+			{Field: FieldCountry, Values: []string{"de"}},
+			{Not: true, Field: FieldPath, Values: []string{"/"}},
+		},
+	}
+	filter = &Filter{Tree: tree}
+	filter.validate()
+	assert.Equal(t, tree, filter.Tree)
+}
+
+func TestFilter_Tree(t *testing.T) {
+	db.CleanupDB(t, dbClient)
+	day := util.PastDay(2)
+	saveSessions(t, [][]model.Session{
+		{
+			{Sign: 1, VisitorID: 1, SessionID: 1, Time: day, Start: day, EntryPath: "/", ExitPath: "/signup", CountryCode: "de", UTMSource: "newsletter"},
+			{Sign: 1, VisitorID: 2, SessionID: 1, Time: day, Start: day, EntryPath: "/pricing", ExitPath: "/pricing", CountryCode: "de"},
+			{Sign: 1, VisitorID: 3, SessionID: 1, Time: day, Start: day, EntryPath: "/blog/post", ExitPath: "/blog/post", CountryCode: "gb", UTMSource: "newsletter"},
+			{Sign: 1, VisitorID: 4, SessionID: 1, Time: day, Start: day, EntryPath: "/pricing", ExitPath: "/pricing", CountryCode: "gb"},
+		},
+	})
+	assert.NoError(t, dbClient.SavePageViews([]model.PageView{
+		{VisitorID: 1, SessionID: 1, Time: day, Path: "/", CountryCode: "de", UTMSource: "newsletter"},
+		{VisitorID: 1, SessionID: 1, Time: day.Add(time.Minute), Path: "/signup", CountryCode: "de", UTMSource: "newsletter"},
+		{VisitorID: 2, SessionID: 1, Time: day, Path: "/pricing", CountryCode: "de"},
+		{VisitorID: 3, SessionID: 1, Time: day, Path: "/blog/post", CountryCode: "gb", UTMSource: "newsletter"},
+		{VisitorID: 4, SessionID: 1, Time: day, Path: "/pricing", CountryCode: "gb"},
+	}))
+	assert.NoError(t, dbClient.SaveEvents([]model.Event{
+		{Name: "signup", VisitorID: 1, SessionID: 1, Time: day.Add(time.Minute * 2), Path: "/signup", CountryCode: "de", UTMSource: "newsletter"},
+	}))
+	time.Sleep(time.Millisecond * 20)
+	analyzer := NewAnalyzer(dbClient)
+	countryOrSource := &FilterTree{
+		Or: true,
+		Children: []FilterTree{
+			{Field: FieldCountry, Values: []string{"de"}},
+			{Field: FieldUTMSource, Values: []string{"newsletter"}},
+		},
+	}
+	total, err := analyzer.Visitors.Total(&Filter{From: util.PastDay(3), To: util.Today(), Tree: countryOrSource})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, total.Visitors)
+	total, err = analyzer.Visitors.Total(&Filter{From: util.PastDay(3), To: util.Today(), Country: []string{"gb"}, Tree: countryOrSource})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total.Visitors)
+	pathOrCountry := &FilterTree{
+		Or: true,
+		Children: []FilterTree{
+			{Field: FieldPath, Values: []string{"/signup"}},
+			{Field: FieldCountry, Values: []string{"gb"}},
+		},
+	}
+	total, err = analyzer.Visitors.Total(&Filter{From: util.PastDay(3), To: util.Today(), Tree: pathOrCountry})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, total.Visitors)
+	pages, err := analyzer.Pages.ByPath(&Filter{From: util.PastDay(3), To: util.Today(), Tree: pathOrCountry})
+	assert.NoError(t, err)
+	assert.Len(t, pages, 3)
+	assert.Equal(t, "/blog/post", pages[0].Path)
+	assert.Equal(t, "/pricing", pages[1].Path)
+	assert.Equal(t, "/signup", pages[2].Path)
+	notConverted := &FilterTree{
+		Not: true,
+		Or:  true,
+		Children: []FilterTree{
+			{Field: FieldEventName, Values: []string{"signup"}},
+			{Field: FieldEntryPath, Values: []string{"/pricing"}},
+		},
+	}
+	total, err = analyzer.Visitors.Total(&Filter{From: util.PastDay(3), To: util.Today(), Tree: notConverted})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total.Visitors)
+	pages, err = analyzer.Pages.ByPath(&Filter{From: util.PastDay(3), To: util.Today(), Tree: notConverted})
+	assert.NoError(t, err)
+	assert.Len(t, pages, 1)
+	assert.Equal(t, "/blog/post", pages[0].Path)
+	filter, err := ParseFilter(`country = gb or event_name = signup`)
+	assert.NoError(t, err)
+	filter.From, filter.To = util.PastDay(3), util.Today()
+	total, err = analyzer.Visitors.Total(filter)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, total.Visitors)
+	eventStats, err := analyzer.Events.Events(&Filter{From: util.PastDay(3), To: util.Today(), Tree: countryOrSource})
+	assert.NoError(t, err)
+	assert.Len(t, eventStats, 1)
+	tree := &FilterTree{
+		Children: []FilterTree{
+			*countryOrSource,
+			*pathOrCountry,
+			*notConverted,
+			{Field: FieldPath, Values: []string{"^/.*$"}, Pattern: true},
+			{Field: FieldExitPath, Values: []string{"!/exit"}},
+		},
+	}
+
+	for _, eventName := range []string{"", "event"} {
+		filter = getMaxFilter(eventName)
+		filter.Tree = tree
+		_, err = analyzer.Visitors.Total(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Visitors.ByPeriod(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Pages.ByPath(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Pages.Entry(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Pages.Exit(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Events.Events(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Demographics.Countries(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Time.AvgSessionDuration(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Time.AvgTimeOnPage(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Visitors.totalSessionDuration(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Visitors.totalTimeOnPage(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Pages.avgTimeOnPage(filter, []string{"/path"})
+		assert.NoError(t, err)
+	}
+}
+
+func TestFilter_Ranges(t *testing.T) {
+	db.CleanupDB(t, dbClient)
+	day := util.PastDay(2)
+	saveSessions(t, [][]model.Session{
+		{
+			{Sign: 1, VisitorID: 1, SessionID: 1, Time: day, Start: day, EntryPath: "/", ExitPath: "/", PageViews: 1, IsBounce: true},
+			{Sign: 1, VisitorID: 2, SessionID: 1, Time: day, Start: day, EntryPath: "/", ExitPath: "/", PageViews: 1, IsBounce: true},
+			{Sign: 1, VisitorID: 3, SessionID: 1, Time: day, Start: day, EntryPath: "/", ExitPath: "/", PageViews: 1, IsBounce: true},
+		},
+		{
+			{Sign: -1, VisitorID: 1, SessionID: 1, Time: day, Start: day, EntryPath: "/", ExitPath: "/", PageViews: 1, IsBounce: true},
+			{Sign: 1, VisitorID: 1, SessionID: 1, Time: day.Add(time.Minute), Start: day, EntryPath: "/", ExitPath: "/pricing", PageViews: 3, DurationSeconds: 60},
+			{Sign: -1, VisitorID: 3, SessionID: 1, Time: day, Start: day, EntryPath: "/", ExitPath: "/", PageViews: 1, IsBounce: true},
+			{Sign: 1, VisitorID: 3, SessionID: 1, Time: day.Add(time.Second * 10), Start: day, EntryPath: "/", ExitPath: "/blog", PageViews: 2, DurationSeconds: 10},
+		},
+	})
+	assert.NoError(t, dbClient.SavePageViews([]model.PageView{
+		{VisitorID: 1, SessionID: 1, Time: day, Path: "/"},
+		{VisitorID: 1, SessionID: 1, Time: day.Add(time.Second * 20), Path: "/signup", DurationSeconds: 20},
+		{VisitorID: 1, SessionID: 1, Time: day.Add(time.Minute), Path: "/pricing", DurationSeconds: 40},
+		{VisitorID: 2, SessionID: 1, Time: day, Path: "/"},
+		{VisitorID: 3, SessionID: 1, Time: day, Path: "/"},
+		{VisitorID: 3, SessionID: 1, Time: day.Add(time.Second * 10), Path: "/blog", DurationSeconds: 10},
+	}))
+	assert.NoError(t, dbClient.SaveEvents([]model.Event{
+		{Name: "signup", VisitorID: 1, SessionID: 1, Time: day.Add(time.Second * 30), Path: "/signup"},
+		{Name: "download", VisitorID: 3, SessionID: 1, Time: day.Add(time.Second * 5), Path: "/"},
+		{Name: "download", VisitorID: 3, SessionID: 1, Time: day.Add(time.Second * 15), Path: "/blog"},
+	}))
+	time.Sleep(time.Millisecond * 20)
+	analyzer := NewAnalyzer(dbClient)
+	input := []struct {
+		ranges   []Range
+		visitors int
+	}{
+		{[]Range{{RangeSessionDuration, pkg.OperatorGreater, 30}}, 1},
+		{[]Range{{RangeSessionDuration, pkg.OperatorLessOrEqual, 30}}, 2},
+		{[]Range{{RangeBounce, pkg.OperatorEqual, 1}}, 1},
+		{[]Range{{RangeBounce, pkg.OperatorEqual, 0}, {RangePageViews, pkg.OperatorGreaterOrEqual, 2}}, 2},
+		{[]Range{{RangePageViews, pkg.OperatorGreater, 2}, {RangePageViews, pkg.OperatorLess, 3}}, 0},
+		{[]Range{{RangeEvents, pkg.OperatorEqual, 0}}, 1},
+		{[]Range{{RangeEvents, pkg.OperatorLess, 2}}, 2},
+		{[]Range{{RangeEvents, pkg.OperatorGreaterOrEqual, 1}}, 2},
+		{[]Range{{RangeTimeOnPage, pkg.OperatorGreater, 15}}, 1},
+		{[]Range{{RangeTimeOnPage, pkg.OperatorEqual, 0}}, 1},
+	}
+
+	for _, in := range input {
+		total, err := analyzer.Visitors.Total(&Filter{From: util.PastDay(3), To: util.Today(), Ranges: in.ranges})
+		assert.NoError(t, err)
+		assert.Equal(t, in.visitors, total.Visitors, in.ranges)
+	}
+
+	engaged := []Range{{RangeSessionDuration, pkg.OperatorGreater, 30}}
+	pages, err := analyzer.Pages.ByPath(&Filter{From: util.PastDay(3), To: util.Today(), Ranges: engaged})
+	assert.NoError(t, err)
+	assert.Len(t, pages, 3)
+	assert.Equal(t, 1, pages[0].Visitors)
+	eventStats, err := analyzer.Events.Events(&Filter{From: util.PastDay(3), To: util.Today(), Ranges: engaged})
+	assert.NoError(t, err)
+	assert.Len(t, eventStats, 1)
+	assert.Equal(t, "signup", eventStats[0].Name)
+	filter, err := ParseFilter(`path = /blog and session_duration < 30`)
+	assert.NoError(t, err)
+	filter.From, filter.To = util.PastDay(3), util.Today()
+	total, err := analyzer.Visitors.Total(filter)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total.Visitors)
+	ranges := []Range{
+		{RangeSessionDuration, pkg.OperatorGreater, 30},
+		{RangePageViews, pkg.OperatorGreaterOrEqual, 2},
+		{RangeBounce, pkg.OperatorEqual, 0},
+		{RangeEvents, pkg.OperatorLess, 3},
+		{RangeTimeOnPage, pkg.OperatorNotEqual, 0},
+	}
+
+	for _, eventName := range []string{"", "event"} {
+		filter = getMaxFilter(eventName)
+		filter.Ranges = ranges
+		_, err = analyzer.Visitors.Total(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Visitors.ByPeriod(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Pages.ByPath(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Pages.Entry(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Events.Events(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Demographics.Countries(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Time.AvgSessionDuration(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Time.AvgTimeOnPage(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Visitors.totalSessionDuration(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Visitors.totalTimeOnPage(filter)
+		assert.NoError(t, err)
+		_, err = analyzer.Pages.avgTimeOnPage(filter, []string{"/path"})
+		assert.NoError(t, err)
+	}
+}
+
 func TestFilter_BuildQuery(t *testing.T) {
 	db.CleanupDB(t, dbClient)
 	assert.NoError(t, dbClient.SavePageViews([]model.PageView{
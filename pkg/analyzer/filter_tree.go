@@ -0,0 +1,101 @@
+package analyzer
+
+var filterTreeFields = append([]Field{FieldPath, FieldEventName}, goalDimensions...)
+
+// FilterTree is a boolean combination (AND, OR, NOT) of filter conditions.
+// It can be used to combine conditions for different fields using OR, like "country is Germany or the UTM source is the newsletter".
+// A node is either a group of Children or a condition for a single Field.
+// Conditions use the same values as the corresponding Filter fields:
+// values are combined using OR, values starting with "!" are inverted and combined using AND,
+// values starting with "~" are searched for, and "null" matches empty values.
+// The tree is combined with the other Filter fields using AND.
+type FilterTree struct {
+	// Or joins the Children using OR instead of AND.
+	Or bool
+
+	// Not inverts the group or condition.
+	Not bool
+
+	// Children are the nodes of a group.
+	Children []FilterTree
+
+	// Field is the field of a condition.
+	// Supported fields are FieldPath, FieldEventName, and the session attributes, like FieldEntryPath, FieldCountry, or FieldUTMSource.
+	// Conditions for fields not stored in the table a statistic is calculated from are checked for the whole session.
+	Field Field
+
+	// Values are the values of a condition.
+	Values []string
+
+	// Pattern sets the Values to be (ClickHouse supported) regex patterns for FieldPath (see Filter.PathPattern).
+	Pattern bool
+}
+
+// source returns the table the field of a condition is stored in.
+// Session attributes are stored in all tables, in which case an empty table is returned.
+func (tree *FilterTree) source() table {
+	switch tree.Field {
+	case FieldPath:
+		return pageViews
+	case FieldEventName:
+		return events
+	case FieldEntryPath, FieldExitPath:
+		return sessions
+	default:
+		return ""
+	}
+}
+
+// storedIn returns whether the field of a condition can be filtered directly in given table.
+func (tree *FilterTree) storedIn(from table) bool {
+	source := tree.source()
+	return source == "" || source == from || source == pageViews && from == events
+}
+
+// normalizeTree returns a copy of the tree without invalid conditions and empty groups, or nil if nothing is left.
+func (filter *Filter) normalizeTree(tree *FilterTree) *FilterTree {
+	if tree == nil {
+		return nil
+	}
+
+	if len(tree.Children) == 0 {
+		values := filter.removeDuplicates(tree.Values)
+
+		if len(values) == 0 ||
+			!filter.fieldsContain(filterTreeFields, tree.Field) ||
+			tree.Pattern && tree.Field != FieldPath {
+			return nil
+		}
+
+		return &FilterTree{
+			Not:     tree.Not,
+			Field:   tree.Field,
+			Values:  values,
+			Pattern: tree.Pattern,
+		}
+	}
+
+	children := make([]FilterTree, 0, len(tree.Children))
+
+	for i := range tree.Children {
+		if child := filter.normalizeTree(&tree.Children[i]); child != nil {
+			children = append(children, *child)
+		}
+	}
+
+	if len(children) == 0 {
+		return nil
+	}
+
+	if len(children) == 1 {
+		child := children[0]
+		child.Not = child.Not != tree.Not
+		return &child
+	}
+
+	return &FilterTree{
+		Or:       tree.Or,
+		Not:      tree.Not,
+		Children: children,
+	}
+}
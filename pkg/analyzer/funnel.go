@@ -0,0 +1,217 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/db"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/model"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	maxFunnelSteps        = 32 // limited by windowFunnel
+	defaultFunnelDuration = time.Hour * 24
+)
+
+var (
+	// ErrNoFunnelSteps is returned in case a funnel has no or too many steps.
+	ErrNoFunnelSteps = fmt.Errorf("a funnel requires between 1 and %d steps", maxFunnelSteps)
+
+	// ErrInvalidFunnelStep is returned in case a funnel step has no path, path pattern, or event name.
+	ErrInvalidFunnelStep = errors.New("a funnel step requires a path, path pattern, or event name")
+)
+
+// FunnelStep is a step in a conversion funnel.
+// A step is either a page view for a path or path pattern, or an event.
+// If EventName is set, Path and PathPattern additionally filter for the path the event was sent from.
+type FunnelStep struct {
+	// Name is an optional name for the step, which will be returned in the results.
+	Name string
+
+	// Path is the path of a page view.
+	Path string
+
+	// PathPattern is a (ClickHouse supported) regex pattern for the path of a page view (see Filter.PathPattern).
+	PathPattern string
+
+	// EventName is the name of an event.
+	EventName string
+
+	// EventMeta are optional conditions for the event metadata.
+	// Values can be inverted by adding a "!" in front of the string, or searched for using "~".
+	EventMeta map[string]string
+}
+
+// Funnel aggregates statistics regarding conversion funnels.
+type Funnel struct {
+	analyzer *Analyzer
+	store    db.Store
+}
+
+// Steps returns the visitors and drop-off for each step of a conversion funnel.
+// The steps must be completed in order within a single session, but other page views and events can happen in between.
+// The duration is the maximum total time to complete the funnel, measured from the first step, not the time between two consecutive steps.
+// A duration of 30 minutes for three steps therefore requires the third step to be completed within 30 minutes of the first one.
+// It defaults to 24 hours.
+// The Filter is used to select the sessions the funnel is evaluated for.
+func (funnel *Funnel) Steps(filter *Filter, steps []FunnelStep, duration time.Duration) ([]model.FunnelStepStats, error) {
+	if len(steps) == 0 || len(steps) > maxFunnelSteps {
+		return nil, ErrNoFunnelSteps
+	}
+
+	for _, step := range steps {
+		if step.Path == "" && step.PathPattern == "" && step.EventName == "" {
+			return nil, ErrInvalidFunnelStep
+		}
+	}
+
+	if duration <= 0 {
+		duration = defaultFunnelDuration
+	}
+
+	filter = funnel.analyzer.getFilter(filter)
+	filter.Sort = nil
+	filter.Offset = 0
+	filter.Limit = 0
+	args := make([]any, 0)
+	conditions := make([]string, 0, len(steps))
+	includePageViews, includeEvents := false, false
+
+	for _, step := range steps {
+		condition, conditionArgs := funnel.stepCondition(step)
+		conditions = append(conditions, condition)
+		args = append(args, conditionArgs...)
+
+		if step.EventName == "" {
+			includePageViews = true
+		} else {
+			includeEvents = true
+		}
+	}
+
+	tables, tableArgs := funnel.tables(filter, includePageViews, includeEvents)
+	args = append(args, tableArgs...)
+	sessionQuery, sessionArgs := filter.buildQuery([]Field{FieldVisitorID, FieldSessionID}, []Field{FieldVisitorID, FieldSessionID}, nil)
+	args = append(args, sessionArgs...)
+	query := fmt.Sprintf(`SELECT step, uniq(visitor_id) visitors, count(*) sessions
+		FROM (
+			SELECT visitor_id, session_id, windowFunnel(%d)(toUInt64(toUnixTimestamp64Milli(time)), %s) level
+			FROM (%s)
+			WHERE (visitor_id, session_id) IN (%s)
+			GROUP BY visitor_id, session_id
+		)
+		ARRAY JOIN range(1, toUInt64(level)+1) step
+		GROUP BY step
+		ORDER BY step`, duration.Milliseconds(), strings.Join(conditions, ", "), tables, sessionQuery)
+	stats, err := funnel.store.SelectFunnelStepStats(query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return funnel.dropOff(steps, stats), nil
+}
+
+// tables returns a query for the page views and events within the period of the filter as a single table.
+// Page views have an empty event name and metadata.
+func (funnel *Funnel) tables(filter *Filter, includePageViews, includeEvents bool) (string, []any) {
+	tables := make([]string, 0, 2)
+	args := make([]any, 0)
+
+	if includePageViews {
+		q := queryBuilder{filter: filter}
+		tables = append(tables, fmt.Sprintf(`SELECT visitor_id, session_id, time, path, '' event_name, CAST([], 'Array(String)') event_meta_keys, CAST([], 'Array(String)') event_meta_values
+			FROM "page_view" %s`, q.whereTime()))
+		args = append(args, q.args...)
+	}
+
+	if includeEvents {
+		q := queryBuilder{filter: filter}
+		tables = append(tables, fmt.Sprintf(`SELECT visitor_id, session_id, time, path, event_name, event_meta_keys, event_meta_values
+			FROM "event" %s`, q.whereTime()))
+		args = append(args, q.args...)
+	}
+
+	return strings.Join(tables, " UNION ALL "), args
+}
+
+func (funnel *Funnel) stepCondition(step FunnelStep) (string, []any) {
+	conditions := make([]string, 0, 3)
+	args := make([]any, 0, 3)
+
+	if step.EventName != "" {
+		conditions = append(conditions, "event_name = ?")
+		args = append(args, step.EventName)
+		keys := make([]string, 0, len(step.EventMeta))
+
+		for k := range step.EventMeta {
+			keys = append(keys, k)
+		}
+
+		// sort the keys to make the query and order of arguments deterministic
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			v := step.EventMeta[k]
+			comparator := "event_meta_values[indexOf(event_meta_keys, ?)] = ?"
+
+			if strings.HasPrefix(v, "!") {
+				v = v[1:]
+				comparator = "event_meta_values[indexOf(event_meta_keys, ?)] != ?"
+			} else if strings.HasPrefix(v, "~") {
+				v = fmt.Sprintf("%%%s%%", v[1:])
+				comparator = "ilike(event_meta_values[indexOf(event_meta_keys, ?)], ?) = 1"
+			}
+
+			conditions = append(conditions, comparator)
+			args = append(args, k, v)
+		}
+	} else {
+		conditions = append(conditions, "event_name = ''")
+	}
+
+	if step.Path != "" {
+		conditions = append(conditions, "path = ?")
+		args = append(args, step.Path)
+	} else if step.PathPattern != "" {
+		conditions = append(conditions, "match(path, ?) = 1")
+		args = append(args, step.PathPattern)
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+func (funnel *Funnel) dropOff(steps []FunnelStep, stats []model.FunnelStepStats) []model.FunnelStepStats {
+	results := make([]model.FunnelStepStats, len(steps))
+
+	for i := range steps {
+		results[i].Step = i + 1
+		results[i].Name = steps[i].Name
+	}
+
+	for _, s := range stats {
+		if s.Step > 0 && s.Step <= len(results) {
+			results[s.Step-1].Visitors = s.Visitors
+			results[s.Step-1].Sessions = s.Sessions
+		}
+	}
+
+	for i := range results {
+		if results[0].Visitors > 0 {
+			results[i].RelativeVisitors = float64(results[i].Visitors) / float64(results[0].Visitors)
+		}
+
+		if i > 0 {
+			previous := results[i-1].Visitors
+			results[i].DropOff = previous - results[i].Visitors
+
+			if previous > 0 {
+				results[i].DropOffRate = float64(results[i].DropOff) / float64(previous)
+			}
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,125 @@
+package analyzer
+
+import (
+	"github.com/pirsch-analytics/pirsch/v6/pkg/db"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/model"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestFunnel_Steps(t *testing.T) {
+	db.CleanupDB(t, dbClient)
+	day := util.PastDay(2)
+	saveSessions(t, [][]model.Session{
+		{
+			{Sign: 1, VisitorID: 1, SessionID: 1, Time: day, Start: day, EntryPath: "/", ExitPath: "/signup", CountryCode: "de"},
+			{Sign: 1, VisitorID: 2, SessionID: 1, Time: day, Start: day, EntryPath: "/pricing", ExitPath: "/signup", CountryCode: "de"},
+			{Sign: 1, VisitorID: 3, SessionID: 1, Time: day, Start: day, EntryPath: "/signup", ExitPath: "/pricing", CountryCode: "de"},
+			{Sign: 1, VisitorID: 4, SessionID: 1, Time: day, Start: day, EntryPath: "/pricing", ExitPath: "/signup", CountryCode: "gb"},
+		},
+	})
+	assert.NoError(t, dbClient.SavePageViews([]model.PageView{
+		{VisitorID: 1, SessionID: 1, Time: day, Path: "/", CountryCode: "de"},
+		{VisitorID: 1, SessionID: 1, Time: day.Add(time.Minute), Path: "/pricing", CountryCode: "de"},
+		{VisitorID: 1, SessionID: 1, Time: day.Add(time.Minute * 2), Path: "/signup", CountryCode: "de"},
+		{VisitorID: 2, SessionID: 1, Time: day, Path: "/pricing", CountryCode: "de"},
+		{VisitorID: 2, SessionID: 1, Time: day.Add(time.Minute), Path: "/signup", CountryCode: "de"},
+		{VisitorID: 3, SessionID: 1, Time: day, Path: "/signup", CountryCode: "de"},
+		{VisitorID: 3, SessionID: 1, Time: day.Add(time.Minute), Path: "/pricing", CountryCode: "de"},
+		{VisitorID: 4, SessionID: 1, Time: day, Path: "/pricing", CountryCode: "gb"},
+		{VisitorID: 4, SessionID: 1, Time: day.Add(time.Minute), Path: "/signup", CountryCode: "gb"},
+	}))
+	assert.NoError(t, dbClient.SaveEvents([]model.Event{
+		{Name: "signup_completed", MetaKeys: []string{"plan"}, MetaValues: []string{"pro"}, VisitorID: 1, SessionID: 1, Time: day.Add(time.Minute * 3), Path: "/signup", CountryCode: "de"},
+		{Name: "signup_completed", MetaKeys: []string{"plan"}, MetaValues: []string{"free"}, VisitorID: 4, SessionID: 1, Time: day.Add(time.Minute * 2), Path: "/signup", CountryCode: "gb"},
+	}))
+	time.Sleep(time.Millisecond * 20)
+	analyzer := NewAnalyzer(dbClient)
+	steps := []FunnelStep{
+		{Name: "Pricing", Path: "/pricing"},
+		{Name: "Sign Up", PathPattern: "^/signup$"},
+		{Name: "Completed", EventName: "signup_completed"},
+	}
+	stats, err := analyzer.Funnel.Steps(&Filter{From: util.PastDay(3), To: util.Today()}, steps, 0)
+	assert.NoError(t, err)
+	assert.Len(t, stats, 3)
+	assert.Equal(t, 1, stats[0].Step)
+	assert.Equal(t, 2, stats[1].Step)
+	assert.Equal(t, 3, stats[2].Step)
+	assert.Equal(t, "Pricing", stats[0].Name)
+	assert.Equal(t, "Sign Up", stats[1].Name)
+	assert.Equal(t, "Completed", stats[2].Name)
+	assert.Equal(t, 4, stats[0].Visitors)
+	assert.Equal(t, 3, stats[1].Visitors)
+	assert.Equal(t, 2, stats[2].Visitors)
+	assert.Equal(t, 4, stats[0].Sessions)
+	assert.InDelta(t, 1, stats[0].RelativeVisitors, 0.01)
+	assert.InDelta(t, 0.75, stats[1].RelativeVisitors, 0.01)
+	assert.InDelta(t, 0.5, stats[2].RelativeVisitors, 0.01)
+	assert.Equal(t, 0, stats[0].DropOff)
+	assert.Equal(t, 1, stats[1].DropOff)
+	assert.Equal(t, 1, stats[2].DropOff)
+	assert.InDelta(t, 0.25, stats[1].DropOffRate, 0.01)
+	assert.InDelta(t, 0.33, stats[2].DropOffRate, 0.01)
+	steps[2].EventMeta = map[string]string{"plan": "pro"}
+	stats, err = analyzer.Funnel.Steps(&Filter{From: util.PastDay(3), To: util.Today()}, steps, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stats[2].Visitors)
+	steps[2].EventMeta = nil
+	stats, err = analyzer.Funnel.Steps(&Filter{From: util.PastDay(3), To: util.Today(), Country: []string{"de"}}, steps, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, stats[0].Visitors)
+	assert.Equal(t, 2, stats[1].Visitors)
+	assert.Equal(t, 1, stats[2].Visitors)
+	stats, err = analyzer.Funnel.Steps(&Filter{From: util.PastDay(3), To: util.Today()}, steps, time.Second*30)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, stats[0].Visitors)
+	assert.Equal(t, 0, stats[1].Visitors)
+	assert.Equal(t, 0, stats[2].Visitors)
+	assert.Zero(t, stats[2].DropOffRate)
+
+	// the duration limits the time from the first to the last step, not between two steps
+	stats, err = analyzer.Funnel.Steps(&Filter{From: util.PastDay(3), To: util.Today()}, steps, time.Second*90)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, stats[0].Visitors)
+	assert.Equal(t, 3, stats[1].Visitors)
+	assert.Equal(t, 0, stats[2].Visitors)
+	_, err = analyzer.Funnel.Steps(getMaxFilter(""), steps, 0)
+	assert.NoError(t, err)
+	_, err = analyzer.Funnel.Steps(getMaxFilter("event"), steps, 0)
+	assert.NoError(t, err)
+	_, err = analyzer.Funnel.Steps(nil, nil, 0)
+	assert.ErrorIs(t, err, ErrNoFunnelSteps)
+	_, err = analyzer.Funnel.Steps(nil, []FunnelStep{{Name: "Empty"}}, 0)
+	assert.ErrorIs(t, err, ErrInvalidFunnelStep)
+}
+
+func TestFunnel_stepCondition(t *testing.T) {
+	funnel := new(Funnel)
+	input := []FunnelStep{
+		{Path: "/pricing"},
+		{PathPattern: "^/blog/.*$"},
+		{EventName: "signup", Path: "/signup"},
+		{EventName: "signup", EventMeta: map[string]string{"plan": "!free", "ref": "~news"}},
+	}
+	expected := []string{
+		"event_name = '' AND path = ?",
+		"event_name = '' AND match(path, ?) = 1",
+		"event_name = ? AND path = ?",
+		"event_name = ? AND event_meta_values[indexOf(event_meta_keys, ?)] != ? AND ilike(event_meta_values[indexOf(event_meta_keys, ?)], ?) = 1",
+	}
+	expectedArgs := [][]any{
+		{"/pricing"},
+		{"^/blog/.*$"},
+		{"signup", "/signup"},
+		{"signup", "plan", "free", "ref", "%news%"},
+	}
+
+	for i, step := range input {
+		condition, args := funnel.stepCondition(step)
+		assert.Equal(t, expected[i], condition)
+		assert.Equal(t, expectedArgs[i], args)
+	}
+}
@@ -0,0 +1,237 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/db"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/model"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrNoGoals is returned in case no goals are passed.
+	ErrNoGoals = errors.New("at least one goal is required")
+
+	// ErrInvalidGoal is returned in case a goal has no path, path pattern, or event name.
+	ErrInvalidGoal = errors.New("a goal requires a path, path pattern, or event name")
+
+	// ErrInvalidGoalDimension is returned in case goals are broken down by a field that is not a session attribute.
+	ErrInvalidGoalDimension = errors.New("goals can only be broken down by session attributes")
+
+	goalDimensions = []Field{
+		FieldEntryPath,
+		FieldExitPath,
+		FieldLanguage,
+		FieldCountry,
+		FieldRegion,
+		FieldCity,
+		FieldTimeZone,
+		FieldASN,
+		FieldASOrganization,
+		FieldReferrer,
+		FieldReferrerName,
+		FieldOS,
+		FieldOSVersion,
+		FieldBrowser,
+		FieldBrowserVersion,
+		FieldDeviceType,
+		FieldDeviceVendor,
+		FieldDeviceModel,
+		FieldScreenClass,
+		FieldUTMSource,
+		FieldUTMMedium,
+		FieldUTMCampaign,
+		FieldUTMContent,
+		FieldUTMTerm,
+	}
+)
+
+// Goal is a conversion goal.
+// A session converts if it contains a page view for the path or path pattern, or the event.
+// If EventName is set, Path and PathPattern additionally filter for the path the event was sent from.
+type Goal struct {
+	// Name is the name of the goal, which will be returned in the results.
+	Name string
+
+	// Path is the path of a page view.
+	Path string
+
+	// PathPattern is a (ClickHouse supported) regex pattern for the path of a page view (see Filter.PathPattern).
+	PathPattern string
+
+	// EventName is the name of an event.
+	EventName string
+
+	// EventMeta are optional conditions for the event metadata.
+	// Values can be inverted by adding a "!" in front of the string, or searched for using "~".
+	EventMeta map[string]string
+
+	// Value is an optional monetary value for a single conversion.
+	Value float64
+}
+
+// Goals aggregates statistics regarding conversion goals.
+type Goals struct {
+	analyzer *Analyzer
+	store    db.Store
+}
+
+// Conversions returns the converted visitors and sessions, conversion rate, and value for each goal.
+// All goals are evaluated in a single query. The Filter is used to select the sessions the goals are evaluated for.
+func (goals *Goals) Conversions(filter *Filter, list []Goal) ([]model.GoalStats, error) {
+	return goals.Breakdown(filter, list, Field{})
+}
+
+// Breakdown returns the converted visitors and sessions, conversion rate, and value for each goal grouped by a session attribute,
+// like the referrer, UTM source, country, or entry page. The conversion rate is relative to the visitors for each attribute value.
+// The results are sorted by the visitors for each attribute value, and Filter.Offset and Filter.Limit limit the number of attribute values.
+// All goals are evaluated in a single query. The Filter is used to select the sessions the goals are evaluated for.
+func (goals *Goals) Breakdown(filter *Filter, list []Goal, dimension Field) ([]model.GoalStats, error) {
+	if len(list) == 0 {
+		return nil, ErrNoGoals
+	}
+
+	for _, goal := range list {
+		if goal.Path == "" && goal.PathPattern == "" && goal.EventName == "" {
+			return nil, ErrInvalidGoal
+		}
+	}
+
+	filter = goals.analyzer.getFilter(filter)
+
+	if dimension.Name != "" && !filter.fieldsContain(goalDimensions, dimension) {
+		return nil, ErrInvalidGoalDimension
+	}
+
+	offset, limit := filter.Offset, filter.Limit
+	filter.Sort = nil
+	filter.Offset = 0
+	filter.Limit = 0
+	sessionFields := []Field{FieldVisitorID, FieldSessionID}
+	dimensionQuery := "''"
+
+	if dimension.Name != "" {
+		sessionFields = append(sessionFields, dimension)
+		dimensionQuery = fmt.Sprintf("toString(s.%s)", dimension.Name)
+	}
+
+	sessionQuery, args := filter.buildQuery(sessionFields, sessionFields, nil)
+	conditions := make([]string, 0, len(list))
+	includePageViews, includeEvents := false, false
+
+	for i, goal := range list {
+		condition, conditionArgs := goals.analyzer.Funnel.stepCondition(FunnelStep{
+			Path:        goal.Path,
+			PathPattern: goal.PathPattern,
+			EventName:   goal.EventName,
+			EventMeta:   goal.EventMeta,
+		})
+		conditions = append(conditions, fmt.Sprintf("if(countIf(%s) > 0, %d, 0)", condition, i+1))
+		args = append(args, conditionArgs...)
+
+		if goal.EventName == "" {
+			includePageViews = true
+		} else {
+			includeEvents = true
+		}
+	}
+
+	tables, tableArgs := goals.analyzer.Funnel.tables(filter, includePageViews, includeEvents)
+	args = append(args, tableArgs...)
+
+	// goal 0 is used for the total visitors and sessions
+	query := fmt.Sprintf(`SELECT dimension, goal, uniq(visitor_id) visitors, count(*) sessions
+		FROM (
+			SELECT s.visitor_id visitor_id, %s dimension, g.goals goals
+			FROM (%s) s
+			LEFT JOIN (
+				SELECT visitor_id, session_id, arrayFilter(x -> x > 0, [%s]) goals
+				FROM (%s)
+				GROUP BY visitor_id, session_id
+			) g
+			ON s.visitor_id = g.visitor_id AND s.session_id = g.session_id
+		)
+		ARRAY JOIN arrayConcat([0], goals) goal
+		GROUP BY dimension, goal`, dimensionQuery, sessionQuery, strings.Join(conditions, ", "), tables)
+	stats, err := goals.store.SelectGoalConversionStats(query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return goals.results(list, stats, dimension.Name != "", offset, limit), nil
+}
+
+func (goals *Goals) results(list []Goal, stats []model.GoalConversionStats, hasDimension bool, offset, limit int) []model.GoalStats {
+	totals := make(map[string]model.GoalConversionStats)
+	conversions := make(map[string]map[int]model.GoalConversionStats)
+
+	for _, s := range stats {
+		if s.Goal == 0 {
+			totals[s.Dimension] = s
+		} else if s.Goal <= len(list) {
+			if conversions[s.Dimension] == nil {
+				conversions[s.Dimension] = make(map[int]model.GoalConversionStats)
+			}
+
+			conversions[s.Dimension][s.Goal] = s
+		}
+	}
+
+	// always return all goals if there is no dimension
+	if !hasDimension && len(totals) == 0 {
+		totals[""] = model.GoalConversionStats{}
+	}
+
+	dimensions := make([]string, 0, len(totals))
+
+	for dimension := range totals {
+		dimensions = append(dimensions, dimension)
+	}
+
+	sort.Slice(dimensions, func(i, j int) bool {
+		a, b := totals[dimensions[i]].Visitors, totals[dimensions[j]].Visitors
+
+		if a == b {
+			return dimensions[i] < dimensions[j]
+		}
+
+		return a > b
+	})
+
+	if offset >= len(dimensions) {
+		dimensions = dimensions[:0]
+	} else {
+		dimensions = dimensions[offset:]
+	}
+
+	if limit > 0 && limit < len(dimensions) {
+		dimensions = dimensions[:limit]
+	}
+
+	results := make([]model.GoalStats, 0, len(dimensions)*len(list))
+
+	for _, dimension := range dimensions {
+		total := totals[dimension]
+
+		for i, goal := range list {
+			c := conversions[dimension][i+1]
+			result := model.GoalStats{
+				Goal:      goal.Name,
+				Dimension: dimension,
+				Visitors:  c.Visitors,
+				Sessions:  c.Sessions,
+				Value:     float64(c.Sessions) * goal.Value,
+			}
+
+			if total.Visitors > 0 {
+				result.CR = float64(c.Visitors) / float64(total.Visitors)
+			}
+
+			results = append(results, result)
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,126 @@
+package analyzer
+
+import (
+	"github.com/pirsch-analytics/pirsch/v6/pkg/db"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/model"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGoals_Conversions(t *testing.T) {
+	db.CleanupDB(t, dbClient)
+	day := util.PastDay(2)
+	saveSessions(t, [][]model.Session{
+		{
+			{Sign: 1, VisitorID: 1, SessionID: 1, Time: day, Start: day, EntryPath: "/", ExitPath: "/signup", CountryCode: "de", UTMSource: "newsletter"},
+			{Sign: 1, VisitorID: 2, SessionID: 1, Time: day, Start: day, EntryPath: "/pricing", ExitPath: "/signup", CountryCode: "de"},
+			{Sign: 1, VisitorID: 3, SessionID: 1, Time: day, Start: day, EntryPath: "/blog/post", ExitPath: "/blog/post", CountryCode: "gb"},
+			{Sign: 1, VisitorID: 4, SessionID: 1, Time: day, Start: day, EntryPath: "/pricing", ExitPath: "/pricing", CountryCode: "gb", UTMSource: "newsletter"},
+		},
+	})
+	assert.NoError(t, dbClient.SavePageViews([]model.PageView{
+		{VisitorID: 1, SessionID: 1, Time: day, Path: "/", CountryCode: "de", UTMSource: "newsletter"},
+		{VisitorID: 1, SessionID: 1, Time: day.Add(time.Minute), Path: "/signup", CountryCode: "de", UTMSource: "newsletter"},
+		{VisitorID: 2, SessionID: 1, Time: day, Path: "/pricing", CountryCode: "de"},
+		{VisitorID: 2, SessionID: 1, Time: day.Add(time.Minute), Path: "/signup", CountryCode: "de"},
+		{VisitorID: 3, SessionID: 1, Time: day, Path: "/blog/post", CountryCode: "gb"},
+		{VisitorID: 4, SessionID: 1, Time: day, Path: "/pricing", CountryCode: "gb", UTMSource: "newsletter"},
+	}))
+	assert.NoError(t, dbClient.SaveEvents([]model.Event{
+		{Name: "purchase", MetaKeys: []string{"plan"}, MetaValues: []string{"pro"}, VisitorID: 1, SessionID: 1, Time: day.Add(time.Minute * 2), Path: "/signup", CountryCode: "de", UTMSource: "newsletter"},
+		{Name: "purchase", MetaKeys: []string{"plan"}, MetaValues: []string{"free"}, VisitorID: 2, SessionID: 1, Time: day.Add(time.Minute * 2), Path: "/signup", CountryCode: "de"},
+	}))
+	time.Sleep(time.Millisecond * 20)
+	analyzer := NewAnalyzer(dbClient)
+	goals := []Goal{
+		{Name: "Sign Up", Path: "/signup"},
+		{Name: "Blog", PathPattern: "^/blog/.*$"},
+		{Name: "Purchase", EventName: "purchase", Value: 9.5},
+		{Name: "Pro", EventName: "purchase", EventMeta: map[string]string{"plan": "pro"}, Value: 20},
+	}
+	stats, err := analyzer.Goals.Conversions(&Filter{From: util.PastDay(3), To: util.Today()}, goals)
+	assert.NoError(t, err)
+	assert.Len(t, stats, 4)
+	assert.Equal(t, "Sign Up", stats[0].Goal)
+	assert.Equal(t, "Blog", stats[1].Goal)
+	assert.Equal(t, "Purchase", stats[2].Goal)
+	assert.Equal(t, "Pro", stats[3].Goal)
+	assert.Equal(t, 2, stats[0].Visitors)
+	assert.Equal(t, 1, stats[1].Visitors)
+	assert.Equal(t, 2, stats[2].Visitors)
+	assert.Equal(t, 1, stats[3].Visitors)
+	assert.Equal(t, 2, stats[0].Sessions)
+	assert.InDelta(t, 0.5, stats[0].CR, 0.01)
+	assert.InDelta(t, 0.25, stats[1].CR, 0.01)
+	assert.InDelta(t, 19, stats[2].Value, 0.01)
+	assert.InDelta(t, 20, stats[3].Value, 0.01)
+	stats, err = analyzer.Goals.Breakdown(&Filter{From: util.PastDay(3), To: util.Today()}, goals, FieldCountry)
+	assert.NoError(t, err)
+	assert.Len(t, stats, 8)
+	assert.Equal(t, "de", stats[0].Dimension)
+	assert.Equal(t, "de", stats[3].Dimension)
+	assert.Equal(t, "gb", stats[4].Dimension)
+	assert.Equal(t, 2, stats[0].Visitors)
+	assert.InDelta(t, 1, stats[0].CR, 0.01)
+	assert.Equal(t, 0, stats[1].Visitors)
+	assert.Equal(t, 0, stats[4].Visitors)
+	assert.Equal(t, 1, stats[5].Visitors)
+	assert.InDelta(t, 0.5, stats[5].CR, 0.01)
+	stats, err = analyzer.Goals.Breakdown(&Filter{From: util.PastDay(3), To: util.Today(), Limit: 1}, goals, FieldUTMSource)
+	assert.NoError(t, err)
+	assert.Len(t, stats, 4)
+	assert.Equal(t, "", stats[0].Dimension)
+	stats, err = analyzer.Goals.Breakdown(&Filter{From: util.PastDay(3), To: util.Today(), Offset: 1}, goals, FieldUTMSource)
+	assert.NoError(t, err)
+	assert.Len(t, stats, 4)
+	assert.Equal(t, "newsletter", stats[0].Dimension)
+	assert.Equal(t, 1, stats[0].Visitors)
+	assert.InDelta(t, 0.5, stats[0].CR, 0.01)
+	stats, err = analyzer.Goals.Breakdown(&Filter{From: util.PastDay(3), To: util.Today(), EntryPath: []string{"/pricing"}}, goals, FieldEntryPath)
+	assert.NoError(t, err)
+	assert.Len(t, stats, 4)
+	assert.Equal(t, "/pricing", stats[0].Dimension)
+	assert.Equal(t, 1, stats[0].Visitors)
+	assert.InDelta(t, 0.5, stats[0].CR, 0.01)
+	stats, err = analyzer.Goals.Conversions(&Filter{From: util.PastDay(20), To: util.PastDay(10)}, goals)
+	assert.NoError(t, err)
+	assert.Len(t, stats, 4)
+	assert.Zero(t, stats[0].Visitors)
+	_, err = analyzer.Goals.Breakdown(getMaxFilter(""), goals, FieldReferrerName)
+	assert.NoError(t, err)
+	_, err = analyzer.Goals.Breakdown(getMaxFilter("event"), goals, FieldASN)
+	assert.NoError(t, err)
+	_, err = analyzer.Goals.Conversions(nil, nil)
+	assert.ErrorIs(t, err, ErrNoGoals)
+	_, err = analyzer.Goals.Conversions(nil, []Goal{{Name: "Empty"}})
+	assert.ErrorIs(t, err, ErrInvalidGoal)
+	_, err = analyzer.Goals.Breakdown(nil, goals, FieldVisitors)
+	assert.ErrorIs(t, err, ErrInvalidGoalDimension)
+}
+
+func TestGoals_results(t *testing.T) {
+	goals := new(Goals)
+	list := []Goal{{Name: "A", Value: 2}, {Name: "B"}}
+	stats := goals.results(list, []model.GoalConversionStats{
+		{Dimension: "gb", Goal: 0, Visitors: 2, Sessions: 3},
+		{Dimension: "gb", Goal: 2, Visitors: 1, Sessions: 1},
+		{Dimension: "de", Goal: 0, Visitors: 4, Sessions: 4},
+		{Dimension: "de", Goal: 1, Visitors: 2, Sessions: 3},
+		{Dimension: "us", Goal: 0, Visitors: 2, Sessions: 2},
+	}, true, 0, 2)
+	assert.Len(t, stats, 4)
+	expected := []model.GoalStats{
+		{Goal: "A", Dimension: "de", Visitors: 2, Sessions: 3, CR: 0.5, Value: 6},
+		{Goal: "B", Dimension: "de"},
+		{Goal: "A", Dimension: "gb"},
+		{Goal: "B", Dimension: "gb", Visitors: 1, Sessions: 1, CR: 0.5},
+	}
+	assert.Equal(t, expected, stats)
+	assert.Empty(t, goals.results(list, nil, true, 0, 0))
+	stats = goals.results(list, nil, false, 0, 0)
+	assert.Len(t, stats, 2)
+	assert.Equal(t, "A", stats[0].Goal)
+	assert.Zero(t, stats[0].Visitors)
+}
@@ -9,6 +9,18 @@ import (
 	"strings"
 )
 
+const (
+	// PathEntrance is the path used for the start of a session in page flows and path trees.
+	PathEntrance = "(entrance)"
+
+	// PathExit is the path used for the end of a session in page flows and path trees.
+	PathExit = "(exit)"
+
+	defaultPathTreeDepth    = 3
+	maxPathTreeDepth        = 10
+	defaultPathTreeBranches = 5
+)
+
 // Pages aggregates statistics regarding pages.
 type Pages struct {
 	analyzer *Analyzer
@@ -17,7 +29,9 @@ type Pages struct {
 
 // ByPath returns the visitor count, session count, bounce rate, views, and average time on page grouped by path and (optional) page title.
 func (pages *Pages) ByPath(filter *Filter) ([]model.PageStats, error) {
-	return pages.byPath(filter, false)
+	return compare(pages.analyzer, filter, func(filter *Filter) ([]model.PageStats, error) {
+		return pages.byPath(filter, false)
+	}, pageStatsKey)
 }
 
 // ByEventPath returns the visitor count, session count, bounce rate, views, and average time on page grouped by event path and (optional) title.
@@ -26,7 +40,9 @@ func (pages *Pages) ByEventPath(filter *Filter) ([]model.PageStats, error) {
 		return []model.PageStats{}, nil
 	}
 
-	return pages.byPath(filter, true)
+	return compare(pages.analyzer, filter, func(filter *Filter) ([]model.PageStats, error) {
+		return pages.byPath(filter, true)
+	}, pageStatsKey)
 }
 
 func (pages *Pages) byPath(filter *Filter, eventPath bool) ([]model.PageStats, error) {
@@ -97,6 +113,12 @@ func (pages *Pages) byPath(filter *Filter, eventPath bool) ([]model.PageStats, e
 
 // Entry returns the visitor count and time on page grouped by path and (optional) page title for the first page visited.
 func (pages *Pages) Entry(filter *Filter) ([]model.EntryStats, error) {
+	return compare(pages.analyzer, filter, pages.entry, func(stats model.EntryStats) string {
+		return compareKey(stats.Path, stats.Title)
+	})
+}
+
+func (pages *Pages) entry(filter *Filter) ([]model.EntryStats, error) {
 	filter = pages.analyzer.getFilter(filter)
 	var sortVisitors pkg.Direction
 
@@ -189,6 +211,12 @@ func (pages *Pages) Entry(filter *Filter) ([]model.EntryStats, error) {
 
 // Exit returns the visitor count and time on page grouped by path and (optional) page title for the last page visited.
 func (pages *Pages) Exit(filter *Filter) ([]model.ExitStats, error) {
+	return compare(pages.analyzer, filter, pages.exit, func(stats model.ExitStats) string {
+		return compareKey(stats.Path, stats.Title)
+	})
+}
+
+func (pages *Pages) exit(filter *Filter) ([]model.ExitStats, error) {
 	filter = pages.analyzer.getFilter(filter)
 	var sortVisitors pkg.Direction
 
@@ -287,6 +315,100 @@ func (pages *Pages) Conversions(filter *Filter) (*model.ConversionsStats, error)
 	return stats, nil
 }
 
+// Flow returns the most common pages visited directly before and after given path within a session.
+// PathEntrance is returned as the previous page if a session started on the path, and PathExit as the next page if it ended on it.
+// Consecutive page views of the same path are treated as a single one. Filter.Limit limits the number of pages for each direction.
+func (pages *Pages) Flow(filter *Filter, path string) (*model.PageFlowStats, error) {
+	filter = pages.analyzer.getFilter(filter)
+	limit := filter.Limit
+	filter.Sort = nil
+	filter.Offset = 0
+	filter.Limit = 0
+	previous, err := pages.transitions(filter, path, limit, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	next, err := pages.transitions(filter, path, limit, true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PageFlowStats{
+		Path:     path,
+		Previous: previous,
+		Next:     next,
+	}, nil
+}
+
+// PathTree returns the most common paths taken from given path within a session as a tree, like for a Sankey diagram.
+// Sessions are followed from the first page view of the path. Pass PathEntrance to start at the beginning of sessions.
+// The depth is the number of pages following the path (3 by default, 10 at most), and branches the maximum number of children for each page (5 by default).
+// Sessions ending before the depth is reached continue to PathExit. Consecutive page views of the same path are treated as a single one.
+func (pages *Pages) PathTree(filter *Filter, path string, depth, branches int) (*model.PathTreeNode, error) {
+	if depth <= 0 {
+		depth = defaultPathTreeDepth
+	} else if depth > maxPathTreeDepth {
+		depth = maxPathTreeDepth
+	}
+
+	if branches <= 0 {
+		branches = defaultPathTreeBranches
+	}
+
+	if path == "" {
+		path = PathEntrance
+	}
+
+	filter = pages.analyzer.getFilter(filter)
+	filter.Sort = nil
+	filter.Offset = 0
+	filter.Limit = 0
+	sessionPaths, sessionArgs := pages.sessionPaths(filter)
+	var flow, where string
+	var args []any
+
+	if path == PathEntrance {
+		flow = fmt.Sprintf("arraySlice(arrayConcat([?], paths, [?]), 1, %d)", depth+1)
+		args = append(args, PathEntrance, PathExit)
+		args = append(args, sessionArgs...)
+	} else {
+		flow = fmt.Sprintf("arraySlice(arrayConcat(paths, [?]), indexOf(paths, ?), %d)", depth+1)
+		where = "WHERE has(paths, ?)"
+		args = append(args, PathExit, path)
+		args = append(args, sessionArgs...)
+		args = append(args, path)
+	}
+
+	// the pages are limited to the top branches for each parent, but the parent itself might not be included
+	query := fmt.Sprintf(`SELECT prefix, visitors, sessions
+		FROM (
+			SELECT prefix, uniq(visitor_id) visitors, count(*) sessions,
+			row_number() OVER (PARTITION BY arrayPopBack(prefix) ORDER BY uniq(visitor_id) DESC, count(*) DESC, prefix) n
+			FROM (
+				SELECT visitor_id, arraySlice(flow, 1, k) prefix
+				FROM (
+					SELECT visitor_id, %s flow
+					FROM (%s)
+					%s
+				)
+				ARRAY JOIN range(1, length(flow)+1) k
+			)
+			GROUP BY prefix
+		)
+		WHERE n <= %d
+		ORDER BY length(prefix), visitors DESC, sessions DESC, prefix`, flow, sessionPaths, where, branches)
+	stats, err := pages.store.SelectPathTreeStats(query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pages.buildPathTree(path, stats, branches), nil
+}
+
 func (pages *Pages) totalSessions(filter *Filter) (int, error) {
 	filter = pages.analyzer.getFilter(filter)
 	filterQuery, filterArgs := filter.buildTimeQuery()
@@ -422,6 +544,105 @@ func (pages *Pages) avgTimeOnPage(filter *Filter, paths []string) ([]model.AvgTi
 	return stats, nil
 }
 
+// sessionPaths returns a query for the paths of each session ordered by time.
+// Consecutive page views of the same path are merged.
+func (pages *Pages) sessionPaths(filter *Filter) (string, []any) {
+	q := queryBuilder{filter: filter}
+	timeQuery := q.whereTime()
+	sessionQuery, sessionArgs := filter.buildQuery([]Field{FieldVisitorID, FieldSessionID}, []Field{FieldVisitorID, FieldSessionID}, nil)
+	query := fmt.Sprintf(`SELECT visitor_id, session_id,
+		arrayMap(x -> x.2, arraySort(groupArray((time, path)))) sorted,
+		arrayFilter((p, n) -> n = 1 OR p != sorted[n-1], sorted, arrayEnumerate(sorted)) paths
+		FROM "page_view" %s
+		AND (visitor_id, session_id) IN (%s)
+		GROUP BY visitor_id, session_id`, timeQuery, sessionQuery)
+	return query, append(q.args, sessionArgs...)
+}
+
+func (pages *Pages) transitions(filter *Filter, path string, limit int, next bool) ([]model.PageTransitionStats, error) {
+	first, neighbor, placeholder := "i = 1", "paths[i-1]", PathEntrance
+
+	if next {
+		first, neighbor, placeholder = "i = length(paths)", "paths[i+1]", PathExit
+	}
+
+	sessionPaths, sessionArgs := pages.sessionPaths(filter)
+	args := append([]any{placeholder, path}, sessionArgs...)
+	query := fmt.Sprintf(`SELECT if(%s, ?, %s) neighbor,
+		uniq(visitor_id) visitors,
+		count(*) transitions,
+		count(*) / sum(count(*)) OVER () relative_transitions
+		FROM (
+			SELECT visitor_id, paths, arrayJoin(arrayFilter(x -> paths[x] = ?, arrayEnumerate(paths))) i
+			FROM (%s)
+		)
+		GROUP BY neighbor
+		ORDER BY visitors DESC, transitions DESC, neighbor`, first, neighbor, sessionPaths)
+
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", limit)
+	}
+
+	stats, err := pages.store.SelectPageTransitionStats(query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// buildPathTree builds the tree from the stats for each path prefix.
+// The stats must be ordered by prefix length and visitors.
+func (pages *Pages) buildPathTree(path string, stats []model.PathTreeStats, branches int) *model.PathTreeNode {
+	var root *model.PathTreeStats
+	children := make(map[string][]model.PathTreeStats)
+
+	for i := range stats {
+		if len(stats[i].Path) == 1 {
+			root = &stats[i]
+		} else if len(stats[i].Path) > 1 {
+			parent := strings.Join(stats[i].Path[:len(stats[i].Path)-1], "\x00")
+			children[parent] = append(children[parent], stats[i])
+		}
+	}
+
+	if root == nil {
+		return &model.PathTreeNode{
+			Path:     path,
+			Children: []model.PathTreeNode{},
+		}
+	}
+
+	node := pages.buildPathTreeNode(*root, children, branches)
+	return &node
+}
+
+func (pages *Pages) buildPathTreeNode(stats model.PathTreeStats, children map[string][]model.PathTreeStats, branches int) model.PathTreeNode {
+	next := children[strings.Join(stats.Path, "\x00")]
+
+	if len(next) > branches {
+		next = next[:branches]
+	}
+
+	node := model.PathTreeNode{
+		Path:     stats.Path[len(stats.Path)-1],
+		Visitors: stats.Visitors,
+		Sessions: stats.Sessions,
+		Children: make([]model.PathTreeNode, 0, len(next)),
+	}
+
+	for _, child := range next {
+		node.Children = append(node.Children, pages.buildPathTreeNode(child, children, branches))
+	}
+
+	return node
+}
+
+func pageStatsKey(stats model.PageStats) string {
+	return compareKey(stats.Path, stats.Title)
+}
+
 func getPathList[T interface{ GetPath() string }](stats []T) []string {
 	paths := make(map[string]struct{})
 
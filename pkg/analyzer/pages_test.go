@@ -1143,6 +1143,138 @@ func TestAnalyzer_totalVisitorsSessions(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestAnalyzer_PageFlow(t *testing.T) {
+	db.CleanupDB(t, dbClient)
+	savePathTreeData(t)
+	analyzer := NewAnalyzer(dbClient)
+	stats, err := analyzer.Pages.Flow(&Filter{From: util.PastDay(3), To: util.Today()}, "/pricing")
+	assert.NoError(t, err)
+	assert.Equal(t, "/pricing", stats.Path)
+	assert.Len(t, stats.Previous, 3)
+	assert.Len(t, stats.Next, 2)
+	assert.Equal(t, PathEntrance, stats.Previous[0].Path)
+	assert.Equal(t, "/", stats.Previous[1].Path)
+	assert.Equal(t, "/blog", stats.Previous[2].Path)
+	assert.Equal(t, 1, stats.Previous[0].Visitors)
+	assert.Equal(t, 1, stats.Previous[0].Transitions)
+	assert.InDelta(t, 0.33, stats.Previous[0].RelativeTransitions, 0.01)
+	assert.Equal(t, "/signup", stats.Next[0].Path)
+	assert.Equal(t, PathExit, stats.Next[1].Path)
+	assert.Equal(t, 2, stats.Next[0].Visitors)
+	assert.Equal(t, 2, stats.Next[0].Transitions)
+	assert.InDelta(t, 0.66, stats.Next[0].RelativeTransitions, 0.01)
+	stats, err = analyzer.Pages.Flow(&Filter{From: util.PastDay(3), To: util.Today(), Limit: 1}, "/pricing")
+	assert.NoError(t, err)
+	assert.Len(t, stats.Previous, 1)
+	assert.Len(t, stats.Next, 1)
+	stats, err = analyzer.Pages.Flow(&Filter{From: util.PastDay(3), To: util.Today(), Country: []string{"de"}}, "/pricing")
+	assert.NoError(t, err)
+	assert.Len(t, stats.Previous, 2)
+	assert.Len(t, stats.Next, 1)
+	_, err = analyzer.Pages.Flow(getMaxFilter(""), "/pricing")
+	assert.NoError(t, err)
+	_, err = analyzer.Pages.Flow(getMaxFilter("event"), "/pricing")
+	assert.NoError(t, err)
+}
+
+func TestAnalyzer_PathTree(t *testing.T) {
+	db.CleanupDB(t, dbClient)
+	savePathTreeData(t)
+	analyzer := NewAnalyzer(dbClient)
+	tree, err := analyzer.Pages.PathTree(&Filter{From: util.PastDay(3), To: util.Today()}, PathEntrance, 2, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, PathEntrance, tree.Path)
+	assert.Equal(t, 4, tree.Visitors)
+	assert.Equal(t, 4, tree.Sessions)
+	assert.Len(t, tree.Children, 3)
+	assert.Equal(t, "/", tree.Children[0].Path)
+	assert.Equal(t, "/blog", tree.Children[1].Path)
+	assert.Equal(t, "/pricing", tree.Children[2].Path)
+	assert.Equal(t, 2, tree.Children[0].Visitors)
+	assert.Len(t, tree.Children[0].Children, 2)
+	assert.Equal(t, "/blog", tree.Children[0].Children[0].Path)
+	assert.Equal(t, "/pricing", tree.Children[0].Children[1].Path)
+	assert.Empty(t, tree.Children[0].Children[0].Children)
+	tree, err = analyzer.Pages.PathTree(&Filter{From: util.PastDay(3), To: util.Today()}, "", 2, 1)
+	assert.NoError(t, err)
+	assert.Len(t, tree.Children, 1)
+	assert.Equal(t, "/", tree.Children[0].Path)
+	assert.Len(t, tree.Children[0].Children, 1)
+	assert.Equal(t, "/blog", tree.Children[0].Children[0].Path)
+	tree, err = analyzer.Pages.PathTree(&Filter{From: util.PastDay(3), To: util.Today()}, "/pricing", 3, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "/pricing", tree.Path)
+	assert.Equal(t, 3, tree.Visitors)
+	assert.Len(t, tree.Children, 2)
+	assert.Equal(t, "/signup", tree.Children[0].Path)
+	assert.Equal(t, PathExit, tree.Children[1].Path)
+	assert.Equal(t, 2, tree.Children[0].Visitors)
+	assert.Len(t, tree.Children[0].Children, 2)
+	assert.Equal(t, PathExit, tree.Children[0].Children[0].Path)
+	assert.Equal(t, "/", tree.Children[0].Children[1].Path)
+	assert.Len(t, tree.Children[0].Children[1].Children, 1)
+	assert.Equal(t, PathExit, tree.Children[0].Children[1].Children[0].Path)
+	tree, err = analyzer.Pages.PathTree(&Filter{From: util.PastDay(3), To: util.Today()}, "/unknown", 3, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "/unknown", tree.Path)
+	assert.Zero(t, tree.Visitors)
+	assert.Empty(t, tree.Children)
+	_, err = analyzer.Pages.PathTree(getMaxFilter(""), PathEntrance, 0, 0)
+	assert.NoError(t, err)
+	_, err = analyzer.Pages.PathTree(getMaxFilter("event"), "/pricing", 20, 0)
+	assert.NoError(t, err)
+}
+
+func TestAnalyzer_buildPathTree(t *testing.T) {
+	pages := new(Pages)
+	tree := pages.buildPathTree("/", []model.PathTreeStats{
+		{Path: []string{"/"}, Visitors: 5, Sessions: 6},
+		{Path: []string{"/", "/foo"}, Visitors: 3, Sessions: 3},
+		{Path: []string{"/", "/bar"}, Visitors: 2, Sessions: 2},
+		{Path: []string{"/", "/baz"}, Visitors: 1, Sessions: 1},
+		{Path: []string{"/", "/foo", PathExit}, Visitors: 3, Sessions: 3},
+		{Path: []string{"/", "/baz", PathExit}, Visitors: 1, Sessions: 1},
+	}, 2)
+	assert.Equal(t, "/", tree.Path)
+	assert.Equal(t, 5, tree.Visitors)
+	assert.Equal(t, 6, tree.Sessions)
+	assert.Len(t, tree.Children, 2)
+	assert.Equal(t, "/foo", tree.Children[0].Path)
+	assert.Equal(t, "/bar", tree.Children[1].Path)
+	assert.Len(t, tree.Children[0].Children, 1)
+	assert.Equal(t, PathExit, tree.Children[0].Children[0].Path)
+	assert.Empty(t, tree.Children[1].Children)
+	tree = pages.buildPathTree("/", nil, 2)
+	assert.Equal(t, "/", tree.Path)
+	assert.Empty(t, tree.Children)
+}
+
+func savePathTreeData(t *testing.T) {
+	day := util.PastDay(2)
+	saveSessions(t, [][]model.Session{
+		{
+			{Sign: 1, VisitorID: 1, SessionID: 1, Time: day, Start: day, EntryPath: "/", ExitPath: "/signup", CountryCode: "de"},
+			{Sign: 1, VisitorID: 2, SessionID: 1, Time: day, Start: day, EntryPath: "/blog", ExitPath: "/pricing", CountryCode: "gb"},
+			{Sign: 1, VisitorID: 3, SessionID: 1, Time: day, Start: day, EntryPath: "/pricing", ExitPath: "/", CountryCode: "de"},
+			{Sign: 1, VisitorID: 4, SessionID: 1, Time: day, Start: day, EntryPath: "/", ExitPath: "/blog", CountryCode: "de"},
+		},
+	})
+	assert.NoError(t, dbClient.SavePageViews([]model.PageView{
+		{VisitorID: 1, SessionID: 1, Time: day, Path: "/", CountryCode: "de"},
+		{VisitorID: 1, SessionID: 1, Time: day.Add(time.Minute), Path: "/pricing", CountryCode: "de"},
+		{VisitorID: 1, SessionID: 1, Time: day.Add(time.Minute * 2), Path: "/pricing", CountryCode: "de"},
+		{VisitorID: 1, SessionID: 1, Time: day.Add(time.Minute * 3), Path: "/signup", CountryCode: "de"},
+		{VisitorID: 2, SessionID: 1, Time: day, Path: "/blog", CountryCode: "gb"},
+		{VisitorID: 2, SessionID: 1, Time: day.Add(time.Minute), Path: "/pricing", CountryCode: "gb"},
+		{VisitorID: 3, SessionID: 1, Time: day, Path: "/pricing", CountryCode: "de"},
+		{VisitorID: 3, SessionID: 1, Time: day.Add(time.Minute), Path: "/signup", CountryCode: "de"},
+		{VisitorID: 3, SessionID: 1, Time: day.Add(time.Minute * 2), Path: "/", CountryCode: "de"},
+		{VisitorID: 4, SessionID: 1, Time: day, Path: "/", CountryCode: "de"},
+		{VisitorID: 4, SessionID: 1, Time: day.Add(time.Minute), Path: "/blog", CountryCode: "de"},
+	}))
+	time.Sleep(time.Millisecond * 20)
+}
+
 func TestGetPathList(t *testing.T) {
 	paths := getPathList([]model.PageStats{
 		{Path: "/"},
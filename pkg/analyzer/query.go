@@ -86,13 +86,20 @@ func (query *queryBuilder) getFields() []string {
 
 	query.appendField(&fields, FieldLanguage.Name, query.filter.Language)
 	query.appendField(&fields, FieldCountry.Name, query.filter.Country)
+	query.appendField(&fields, FieldRegion.Name, query.filter.Region)
 	query.appendField(&fields, FieldCity.Name, query.filter.City)
+	query.appendField(&fields, FieldTimeZone.Name, query.filter.TimeZone)
+	query.appendField(&fields, FieldASN.Name, query.filter.ASN)
+	query.appendField(&fields, FieldASOrganization.Name, query.filter.ASOrganization)
 	query.appendField(&fields, FieldReferrer.Name, query.filter.Referrer)
 	query.appendField(&fields, FieldReferrerName.Name, query.filter.ReferrerName)
 	query.appendField(&fields, FieldOS.Name, query.filter.OS)
 	query.appendField(&fields, FieldOSVersion.Name, query.filter.OSVersion)
 	query.appendField(&fields, FieldBrowser.Name, query.filter.Browser)
 	query.appendField(&fields, FieldBrowserVersion.Name, query.filter.BrowserVersion)
+	query.appendField(&fields, FieldDeviceType.Name, query.filter.DeviceType)
+	query.appendField(&fields, FieldDeviceVendor.Name, query.filter.DeviceVendor)
+	query.appendField(&fields, FieldDeviceModel.Name, query.filter.DeviceModel)
 	query.appendField(&fields, FieldScreenClass.Name, query.filter.ScreenClass)
 	query.appendField(&fields, FieldUTMSource.Name, query.filter.UTMSource)
 	query.appendField(&fields, FieldUTMMedium.Name, query.filter.UTMMedium)
@@ -100,6 +107,15 @@ func (query *queryBuilder) getFields() []string {
 	query.appendField(&fields, FieldUTMContent.Name, query.filter.UTMContent)
 	query.appendField(&fields, FieldUTMTerm.Name, query.filter.UTMTerm)
 
+	if query.filter.Tree != nil {
+		query.appendTreeFields(&fields, query.filter.Tree)
+	}
+
+	if len(query.filter.Ranges) != 0 {
+		query.appendFieldOnce(&fields, FieldVisitorID.Name)
+		query.appendFieldOnce(&fields, FieldSessionID.Name)
+	}
+
 	if query.filter.Platform != "" {
 		platform := query.filter.Platform
 
@@ -126,6 +142,34 @@ func (query *queryBuilder) appendField(fields *[]string, field string, value []s
 	}
 }
 
+func (query *queryBuilder) appendTreeFields(fields *[]string, tree *FilterTree) {
+	for i := range tree.Children {
+		query.appendTreeFields(fields, &tree.Children[i])
+	}
+
+	if len(tree.Children) == 0 {
+		required := []string{FieldVisitorID.Name, FieldSessionID.Name}
+
+		if tree.storedIn(query.from) {
+			required = []string{tree.Field.Name}
+		}
+
+		for _, field := range required {
+			query.appendFieldOnce(fields, field)
+		}
+	}
+}
+
+func (query *queryBuilder) appendFieldOnce(fields *[]string, field string) {
+	for _, f := range *fields {
+		if f == field {
+			return
+		}
+	}
+
+	*fields = append(*fields, field)
+}
+
 func (query *queryBuilder) selectFields() bool {
 	combineResults := false
 
@@ -308,13 +352,20 @@ func (query *queryBuilder) whereFields() {
 
 	query.whereField(FieldLanguage.Name, query.filter.Language)
 	query.whereField(FieldCountry.Name, query.filter.Country)
+	query.whereField(FieldRegion.Name, query.filter.Region)
 	query.whereField(FieldCity.Name, query.filter.City)
+	query.whereField(FieldTimeZone.Name, query.filter.TimeZone)
+	query.whereField(FieldASN.Name, query.filter.ASN)
+	query.whereField(FieldASOrganization.Name, query.filter.ASOrganization)
 	query.whereField(FieldReferrer.Name, query.filter.Referrer)
 	query.whereField(FieldReferrerName.Name, query.filter.ReferrerName)
 	query.whereField(FieldOS.Name, query.filter.OS)
 	query.whereField(FieldOSVersion.Name, query.filter.OSVersion)
 	query.whereField(FieldBrowser.Name, query.filter.Browser)
 	query.whereField(FieldBrowserVersion.Name, query.filter.BrowserVersion)
+	query.whereField(FieldDeviceType.Name, query.filter.DeviceType)
+	query.whereField(FieldDeviceVendor.Name, query.filter.DeviceVendor)
+	query.whereField(FieldDeviceModel.Name, query.filter.DeviceModel)
 	query.whereField(FieldScreenClass.Name, query.filter.ScreenClass)
 	query.whereField(FieldUTMSource.Name, query.filter.UTMSource)
 	query.whereField(FieldUTMMedium.Name, query.filter.UTMMedium)
@@ -327,31 +378,46 @@ func (query *queryBuilder) whereFields() {
 		query.whereFieldSearch(query.search[i].Field.Name, query.search[i].Input)
 	}
 
+	query.whereFieldTree()
+	query.whereFieldRanges()
+
 	if len(query.where) > 0 {
 		query.q.WriteString("AND ")
-		parts := make([]string, 0, len(query.where))
+		query.q.WriteString(query.whereConditions())
+	}
+}
 
-		for _, fields := range query.where {
-			if len(fields.eqContains) > 1 {
-				parts = append(parts, fmt.Sprintf("(%s) ", strings.Join(fields.eqContains, "OR ")))
-			} else if len(fields.eqContains) == 1 {
-				parts = append(parts, fields.eqContains[0])
-			}
+func (query *queryBuilder) whereConditions() string {
+	parts := make([]string, 0, len(query.where))
 
-			if len(fields.notEq) > 1 {
-				parts = append(parts, strings.Join(fields.notEq, " AND ")+" ")
-			} else if len(fields.notEq) == 1 {
-				parts = append(parts, fields.notEq[0])
-			}
+	for _, fields := range query.where {
+		if len(fields.eqContains) > 1 {
+			parts = append(parts, fmt.Sprintf("(%s) ", strings.Join(fields.eqContains, "OR ")))
+		} else if len(fields.eqContains) == 1 {
+			parts = append(parts, fields.eqContains[0])
 		}
 
-		query.q.WriteString(strings.Join(parts, "AND "))
+		if len(fields.notEq) > 1 {
+			parts = append(parts, strings.Join(fields.notEq, " AND ")+" ")
+		} else if len(fields.notEq) == 1 {
+			parts = append(parts, fields.notEq[0])
+		}
 	}
+
+	return strings.Join(parts, "AND ")
 }
 
 func (query *queryBuilder) whereField(field string, value []string) {
 	if len(value) != 0 {
 		var group where
+		column, null := field, ""
+
+		// numeric columns are compared as strings, so that they can be filtered like any other field,
+		// with null matching the default value zero
+		if field == FieldASN.Name {
+			column, null = fmt.Sprintf("toString(%s)", field), "0"
+		}
+
 		eqContainsArgs := make([]any, 0, len(value))
 		notEqArgs := make([]any, 0, len(value))
 
@@ -379,12 +445,16 @@ func (query *queryBuilder) whereField(field string, value []string) {
 				}
 			}
 
+			if strings.ToLower(v) == "null" {
+				v = null
+			}
+
 			if not {
-				notEqArgs = append(notEqArgs, query.nullValue(v))
-				group.notEq = append(group.notEq, fmt.Sprintf(comparator, field))
+				notEqArgs = append(notEqArgs, v)
+				group.notEq = append(group.notEq, fmt.Sprintf(comparator, column))
 			} else {
-				eqContainsArgs = append(eqContainsArgs, query.nullValue(v))
-				group.eqContains = append(group.eqContains, fmt.Sprintf(comparator, field))
+				eqContainsArgs = append(eqContainsArgs, v)
+				group.eqContains = append(group.eqContains, fmt.Sprintf(comparator, column))
 			}
 		}
 
@@ -554,6 +624,150 @@ func (query *queryBuilder) whereFieldPathIn() {
 	}
 }
 
+func (query *queryBuilder) whereFieldTree() {
+	if query.filter.Tree != nil {
+		condition, args := query.whereTree(query.filter.Tree)
+		query.args = append(query.args, args...)
+
+		// use eqContains because it doesn't matter for a single condition
+		query.where = append(query.where, where{eqContains: []string{condition}})
+	}
+}
+
+func (query *queryBuilder) whereTree(tree *FilterTree) (string, []any) {
+	var condition string
+	var args []any
+
+	if len(tree.Children) > 0 {
+		parts := make([]string, 0, len(tree.Children))
+
+		for i := range tree.Children {
+			part, partArgs := query.whereTree(&tree.Children[i])
+			parts = append(parts, part)
+			args = append(args, partArgs...)
+		}
+
+		operator := "AND "
+
+		if tree.Or {
+			operator = "OR "
+		}
+
+		condition = fmt.Sprintf("(%s) ", strings.Join(parts, operator))
+	} else if tree.storedIn(query.from) {
+		condition, args = query.whereTreeCondition(tree)
+	} else {
+		// the field is stored in another table, so the condition is checked for the sessions instead
+		source := tree.source()
+		sessionQuery := queryBuilder{
+			filter: query.filter.timeFilter(),
+			from:   source,
+		}
+		whereTime := sessionQuery.whereTime()
+		q, conditionArgs := sessionQuery.whereTreeCondition(tree)
+		having := ""
+
+		if source == sessions {
+			having = fmt.Sprintf("GROUP BY visitor_id, session_id, %s HAVING sum(sign) > 0 ", tree.Field.Name)
+		}
+
+		condition = fmt.Sprintf("((visitor_id, session_id) IN (SELECT visitor_id, session_id FROM %s %sAND %s%s)) ", source, whereTime, q, having)
+		args = append(sessionQuery.args, conditionArgs...)
+	}
+
+	if tree.Not {
+		condition = "NOT " + condition
+	}
+
+	return condition, args
+}
+
+func (query *queryBuilder) whereTreeCondition(tree *FilterTree) (string, []any) {
+	conditionQuery := queryBuilder{
+		filter: &Filter{PathPattern: tree.Values},
+		from:   query.from,
+	}
+
+	if tree.Pattern {
+		conditionQuery.whereFieldPathPattern()
+	} else {
+		conditionQuery.whereField(tree.Field.Name, tree.Values)
+	}
+
+	return fmt.Sprintf("(%s) ", conditionQuery.whereConditions()), conditionQuery.args
+}
+
+func (query *queryBuilder) whereFieldRanges() {
+	if len(query.filter.Ranges) != 0 {
+		sessionQuery := queryBuilder{
+			filter: query.filter.timeFilter(),
+			from:   sessions,
+		}
+		var q strings.Builder
+		q.WriteString(fmt.Sprintf("(visitor_id, session_id) IN (SELECT visitor_id, session_id FROM %s %s", sessions, sessionQuery.whereTime()))
+		q.WriteString(sessionQuery.whereRangeSubquery(events, query.filter.Ranges))
+		q.WriteString(sessionQuery.whereRangeSubquery(pageViews, query.filter.Ranges))
+		q.WriteString("GROUP BY visitor_id, session_id HAVING sum(sign) > 0 ")
+
+		for _, condition := range sessionQuery.rangeConditions(sessions, query.filter.Ranges) {
+			q.WriteString("AND " + condition)
+		}
+
+		q.WriteString(") ")
+		query.args = append(query.args, sessionQuery.args...)
+
+		// use eqContains because it doesn't matter for a single condition
+		query.where = append(query.where, where{eqContains: []string{q.String()}})
+	}
+}
+
+// whereRangeSubquery returns the condition for the ranges calculated from given table.
+// Sessions without page views or events are selected using NOT IN in case the ranges match zero.
+func (query *queryBuilder) whereRangeSubquery(from table, ranges []Range) string {
+	rangeQuery := queryBuilder{
+		filter: query.filter,
+		from:   from,
+	}
+	whereTime := rangeQuery.whereTime()
+	conditions := rangeQuery.rangeConditions(from, ranges)
+
+	if len(conditions) == 0 {
+		return ""
+	}
+
+	having := strings.Join(conditions, "AND ")
+	in := "IN"
+	matchesZero := true
+
+	for _, r := range ranges {
+		if r.source() == from && !r.matches(0) {
+			matchesZero = false
+			break
+		}
+	}
+
+	if matchesZero {
+		having = fmt.Sprintf("NOT (%s) ", having)
+		in = "NOT IN"
+	}
+
+	query.args = append(query.args, rangeQuery.args...)
+	return fmt.Sprintf("AND (visitor_id, session_id) %s (SELECT visitor_id, session_id FROM %s %sGROUP BY visitor_id, session_id HAVING %s) ", in, from, whereTime, having)
+}
+
+func (query *queryBuilder) rangeConditions(from table, ranges []Range) []string {
+	conditions := make([]string, 0, len(ranges))
+
+	for _, r := range ranges {
+		if r.source() == from {
+			query.args = append(query.args, r.Value)
+			conditions = append(conditions, fmt.Sprintf("%s %s ? ", rangeExpressions[r.Property], r.Operator))
+		}
+	}
+
+	return conditions
+}
+
 func (query *queryBuilder) nullValue(value string) string {
 	if strings.ToLower(value) == "null" {
 		return ""
@@ -200,3 +200,106 @@ func TestQueryCustomMetric(t *testing.T) {
 	assert.Equal(t, "Event", args[5])
 	assert.Equal(t, `SELECT ifNotFinite(avg(coalesce(toFloat64OrZero(event_meta_values[indexOf(event_meta_keys, ?)]))), 0) custom_metric_avg,sum(coalesce(toFloat64OrZero(event_meta_values[indexOf(event_meta_keys, ?)]))) custom_metric_total,uniq(t.visitor_id) visitors FROM "event" t WHERE client_id = ? AND toDate(time, 'UTC') >= toDate(?) AND toDate(time, 'UTC') <= toDate(?) AND event_name = ? `, queryStr)
 }
+
+func TestQueryFilterTree(t *testing.T) {
+	filter := &Filter{
+		ClientID: 42,
+		From:     util.PastDay(7),
+		To:       util.Today(),
+		Country:  []string{"de"},
+		Tree: &FilterTree{
+			Or: true,
+			Children: []FilterTree{
+				{Field: FieldUTMSource, Values: []string{"newsletter", "!null"}},
+				{Field: FieldPath, Values: []string{"^/blog/.*$"}, Pattern: true},
+				{
+					Not: true,
+					Children: []FilterTree{
+						{Field: FieldEntryPath, Values: []string{"/"}},
+						{Field: FieldEventName, Values: []string{"signup"}},
+					},
+				},
+			},
+		},
+	}
+	filter.validate()
+	q := queryBuilder{
+		filter: filter,
+		fields: []Field{FieldVisitors},
+		from:   sessions,
+	}
+	queryStr, args := q.query()
+	assert.Equal(t, []any{
+		int64(42),
+		util.PastDay(7).Format(dateFormat),
+		util.Today().Format(dateFormat),
+		"de",
+		"newsletter",
+		"",
+		int64(42),
+		util.PastDay(7).Format(dateFormat),
+		util.Today().Format(dateFormat),
+		"^/blog/.*$",
+		"/",
+		int64(42),
+		util.PastDay(7).Format(dateFormat),
+		util.Today().Format(dateFormat),
+		"signup",
+	}, args)
+	assert.Equal(t, `SELECT uniq(t.visitor_id) visitors FROM "session" t WHERE client_id = ? AND toDate(time, 'UTC') >= toDate(?) AND toDate(time, 'UTC') <= toDate(?) AND country_code = ? AND ((utm_source = ? AND utm_source != ? ) OR ((visitor_id, session_id) IN (SELECT visitor_id, session_id FROM "page_view" t WHERE client_id = ? AND toDate(time, 'UTC') >= toDate(?) AND toDate(time, 'UTC') <= toDate(?) AND (match("path", ?) = 1 ) )) OR NOT ((entry_path = ? ) AND ((visitor_id, session_id) IN (SELECT visitor_id, session_id FROM "event" t WHERE client_id = ? AND toDate(time, 'UTC') >= toDate(?) AND toDate(time, 'UTC') <= toDate(?) AND (event_name = ? ) )) ) ) HAVING sum(sign) > 0 `, queryStr)
+	fields := q.getFields()
+	assert.Equal(t, []string{"country_code", "utm_source", "visitor_id", "session_id", "entry_path"}, fields)
+}
+
+func TestQueryASN(t *testing.T) {
+	filter := &Filter{
+		ClientID: 42,
+		From:     util.PastDay(7),
+		To:       util.Today(),
+		ASN:      []string{"null", "!3320", "~33"},
+	}
+	filter.validate()
+	q := queryBuilder{
+		filter: filter,
+		fields: []Field{FieldVisitors},
+		from:   sessions,
+	}
+	queryStr, args := q.query()
+	assert.Equal(t, []any{
+		int64(42),
+		util.PastDay(7).Format(dateFormat),
+		util.Today().Format(dateFormat),
+		"0",
+		"%33%",
+		"3320",
+	}, args)
+	assert.Contains(t, queryStr, "(toString(asn) = ? OR ilike(toString(asn), ?) = 1 ) AND toString(asn) != ? ")
+}
+
+func TestQueryRanges(t *testing.T) {
+	filter := &Filter{
+		ClientID: 42,
+		From:     util.PastDay(7),
+		To:       util.Today(),
+		Ranges: []Range{
+			{Property: RangeSessionDuration, Operator: pkg.OperatorGreater, Value: 30},
+			{Property: RangeBounce, Operator: pkg.OperatorEqual, Value: 0},
+			{Property: RangeEvents, Operator: pkg.OperatorLess, Value: 2},
+			{Property: RangeTimeOnPage, Operator: pkg.OperatorGreaterOrEqual, Value: 10},
+			{Property: "unknown", Operator: pkg.OperatorEqual, Value: 1},
+			{Property: RangePageViews, Operator: "~", Value: 1},
+		},
+	}
+	filter.validate()
+	assert.Len(t, filter.Ranges, 4)
+	q := queryBuilder{
+		filter: filter,
+		fields: []Field{FieldVisitors},
+		from:   pageViews,
+	}
+	queryStr, args := q.query()
+	from, to := util.PastDay(7).Format(dateFormat), util.Today().Format(dateFormat)
+	assert.Equal(t, []any{int64(42), from, to, int64(42), from, to, int64(42), from, to, 2, int64(42), from, to, 10, 30, 0}, args)
+	assert.Equal(t, `SELECT uniq(t.visitor_id) visitors FROM "page_view" t WHERE client_id = ? AND toDate(time, 'UTC') >= toDate(?) AND toDate(time, 'UTC') <= toDate(?) AND (visitor_id, session_id) IN (SELECT visitor_id, session_id FROM "session" t WHERE client_id = ? AND toDate(time, 'UTC') >= toDate(?) AND toDate(time, 'UTC') <= toDate(?) AND (visitor_id, session_id) NOT IN (SELECT visitor_id, session_id FROM "event" t WHERE client_id = ? AND toDate(time, 'UTC') >= toDate(?) AND toDate(time, 'UTC') <= toDate(?) GROUP BY visitor_id, session_id HAVING NOT (count(*) < ? ) ) AND (visitor_id, session_id) IN (SELECT visitor_id, session_id FROM "page_view" t WHERE client_id = ? AND toDate(time, 'UTC') >= toDate(?) AND toDate(time, 'UTC') <= toDate(?) GROUP BY visitor_id, session_id HAVING ifNull(toUInt64(avg(nullIf(duration_seconds, 0))), 0) >= ? ) GROUP BY visitor_id, session_id HAVING sum(sign) > 0 AND sum(duration_seconds*sign) > ? AND sum(is_bounce*sign) = ? ) `, queryStr)
+	assert.Equal(t, []string{"visitor_id", "session_id"}, q.getFields())
+}
@@ -13,30 +13,50 @@ type UTM struct {
 
 // Source returns the visitor count grouped by utm source.
 func (utm *UTM) Source(filter *Filter) ([]model.UTMSourceStats, error) {
-	q, args := utm.analyzer.selectByAttribute(filter, FieldUTMSource)
-	return utm.store.SelectUTMSourceStats(q, args...)
+	return compare(utm.analyzer, filter, func(filter *Filter) ([]model.UTMSourceStats, error) {
+		q, args := utm.analyzer.selectByAttribute(filter, FieldUTMSource)
+		return utm.store.SelectUTMSourceStats(q, args...)
+	}, func(stats model.UTMSourceStats) string {
+		return stats.UTMSource
+	})
 }
 
 // Medium returns the visitor count grouped by utm medium.
 func (utm *UTM) Medium(filter *Filter) ([]model.UTMMediumStats, error) {
-	q, args := utm.analyzer.selectByAttribute(filter, FieldUTMMedium)
-	return utm.store.SelectUTMMediumStats(q, args...)
+	return compare(utm.analyzer, filter, func(filter *Filter) ([]model.UTMMediumStats, error) {
+		q, args := utm.analyzer.selectByAttribute(filter, FieldUTMMedium)
+		return utm.store.SelectUTMMediumStats(q, args...)
+	}, func(stats model.UTMMediumStats) string {
+		return stats.UTMMedium
+	})
 }
 
 // Campaign returns the visitor count grouped by utm source.
 func (utm *UTM) Campaign(filter *Filter) ([]model.UTMCampaignStats, error) {
-	q, args := utm.analyzer.selectByAttribute(filter, FieldUTMCampaign)
-	return utm.store.SelectUTMCampaignStats(q, args...)
+	return compare(utm.analyzer, filter, func(filter *Filter) ([]model.UTMCampaignStats, error) {
+		q, args := utm.analyzer.selectByAttribute(filter, FieldUTMCampaign)
+		return utm.store.SelectUTMCampaignStats(q, args...)
+	}, func(stats model.UTMCampaignStats) string {
+		return stats.UTMCampaign
+	})
 }
 
 // Content returns the visitor count grouped by utm source.
 func (utm *UTM) Content(filter *Filter) ([]model.UTMContentStats, error) {
-	q, args := utm.analyzer.selectByAttribute(filter, FieldUTMContent)
-	return utm.store.SelectUTMContentStats(q, args...)
+	return compare(utm.analyzer, filter, func(filter *Filter) ([]model.UTMContentStats, error) {
+		q, args := utm.analyzer.selectByAttribute(filter, FieldUTMContent)
+		return utm.store.SelectUTMContentStats(q, args...)
+	}, func(stats model.UTMContentStats) string {
+		return stats.UTMContent
+	})
 }
 
 // Term returns the visitor count grouped by utm source.
 func (utm *UTM) Term(filter *Filter) ([]model.UTMTermStats, error) {
-	q, args := utm.analyzer.selectByAttribute(filter, FieldUTMTerm)
-	return utm.store.SelectUTMTermStats(q, args...)
+	return compare(utm.analyzer, filter, func(filter *Filter) ([]model.UTMTermStats, error) {
+		q, args := utm.analyzer.selectByAttribute(filter, FieldUTMTerm)
+		return utm.store.SelectUTMTermStats(q, args...)
+	}, func(stats model.UTMTermStats) string {
+		return stats.UTMTerm
+	})
 }
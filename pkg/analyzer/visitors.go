@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"github.com/pirsch-analytics/pirsch/v6/pkg/db"
 	"github.com/pirsch-analytics/pirsch/v6/pkg/model"
-	"github.com/pirsch-analytics/pirsch/v6/pkg/util"
 	"strings"
 	"time"
 )
@@ -105,8 +104,13 @@ func (visitors *Visitors) TotalVisitorsPageViews(filter *Filter) (*model.TotalVi
 		return nil, err
 	}
 
-	visitors.getPreviousPeriod(filter)
-	q, args = filter.buildQuery([]Field{
+	previousFilter, err := filter.comparisonPeriod()
+
+	if err != nil {
+		return nil, err
+	}
+
+	q, args = previousFilter.buildQuery([]Field{
 		FieldVisitors,
 		FieldViews,
 	}, nil, nil)
@@ -201,8 +205,11 @@ func (visitors *Visitors) ByHour(filter *Filter) ([]model.VisitorHourStats, erro
 	return stats, nil
 }
 
-// Growth returns the growth rate for visitor count, session count, bounces, views, and average session duration or average time on page (if path is set).
-// The growth rate is relative to the previous time range or day.
+// Growth returns the growth rate for visitor count, session count, bounces, views, average session duration or average time on page (if path is set),
+// conversion rate (if Filter.IncludeCR is set), and custom metric (if Filter.CustomMetricKey and Filter.CustomMetricType are set),
+// alongside the values of the comparison period.
+// The growth rate is relative to the period selected by Filter.Compare. By default, a day is compared to the same day one week earlier,
+// and a range to the preceding range of equal length.
 // The period or day for the filter must be set, else an error is returned.
 func (visitors *Visitors) Growth(filter *Filter) (*model.Growth, error) {
 	filter = visitors.analyzer.getFilter(filter)
@@ -211,7 +218,6 @@ func (visitors *Visitors) Growth(filter *Filter) (*model.Growth, error) {
 		return nil, ErrNoPeriodOrDay
 	}
 
-	// get current statistics
 	fields := []Field{
 		FieldVisitors,
 		FieldSessions,
@@ -231,63 +237,53 @@ func (visitors *Visitors) Growth(filter *Filter) (*model.Growth, error) {
 		includeCustomMetric = true
 	}
 
-	q, args := filter.buildQuery(fields, nil, nil)
-	current, err := visitors.store.GetGrowthStats(q, filter.IncludeCR, includeCustomMetric, args...)
+	current, currentTimeSpent, err := visitors.growthStats(filter, fields, includeCustomMetric)
 
 	if err != nil {
 		return nil, err
 	}
 
-	var currentTimeSpent int
-
-	if len(filter.EventName) != 0 {
-		currentTimeSpent, err = visitors.totalEventDuration(filter)
-	} else if len(filter.Path) == 0 {
-		currentTimeSpent, err = visitors.totalSessionDuration(filter)
-	} else {
-		currentTimeSpent, err = visitors.totalTimeOnPage(filter)
-	}
+	previousFilter, err := filter.comparisonPeriod()
 
 	if err != nil {
 		return nil, err
 	}
 
-	visitors.getPreviousPeriod(filter)
-	q, args = filter.buildQuery(fields, nil, nil)
-	previous, err := visitors.store.GetGrowthStats(q, filter.IncludeCR, includeCustomMetric, args...)
-
-	if err != nil {
-		return nil, err
-	}
-
-	var previousTimeSpent int
-
-	if len(filter.EventName) != 0 {
-		previousTimeSpent, err = visitors.totalEventDuration(filter)
-	} else if len(filter.Path) == 0 {
-		previousTimeSpent, err = visitors.totalSessionDuration(filter)
-	} else {
-		previousTimeSpent, err = visitors.totalTimeOnPage(filter)
-	}
+	previous, previousTimeSpent, err := visitors.growthStats(previousFilter, fields, includeCustomMetric)
 
 	if err != nil {
 		return nil, err
 	}
 
 	return &model.Growth{
-		VisitorsGrowth:          calculateGrowth(current.Visitors, previous.Visitors),
-		ViewsGrowth:             calculateGrowth(current.Views, previous.Views),
-		SessionsGrowth:          calculateGrowth(current.Sessions, previous.Sessions),
-		BouncesGrowth:           calculateGrowth(current.BounceRate, previous.BounceRate),
-		TimeSpentGrowth:         calculateGrowth(currentTimeSpent, previousTimeSpent),
-		CRGrowth:                calculateGrowth(current.CR, previous.CR),
-		CustomMetricAvgGrowth:   calculateGrowth(current.CustomMetricAvg, previous.CustomMetricAvg),
-		CustomMetricTotalGrowth: calculateGrowth(current.CustomMetricTotal, previous.CustomMetricTotal),
+		VisitorsGrowth:            calculateGrowth(current.Visitors, previous.Visitors),
+		ViewsGrowth:               calculateGrowth(current.Views, previous.Views),
+		SessionsGrowth:            calculateGrowth(current.Sessions, previous.Sessions),
+		BouncesGrowth:             calculateGrowth(current.BounceRate, previous.BounceRate),
+		TimeSpentGrowth:           calculateGrowth(currentTimeSpent, previousTimeSpent),
+		CRGrowth:                  calculateGrowth(current.CR, previous.CR),
+		CustomMetricAvgGrowth:     calculateGrowth(current.CustomMetricAvg, previous.CustomMetricAvg),
+		CustomMetricTotalGrowth:   calculateGrowth(current.CustomMetricTotal, previous.CustomMetricTotal),
+		PreviousVisitors:          previous.Visitors,
+		PreviousViews:             previous.Views,
+		PreviousSessions:          previous.Sessions,
+		PreviousBounces:           previous.Bounces,
+		PreviousBounceRate:        previous.BounceRate,
+		PreviousTimeSpentSeconds:  previousTimeSpent,
+		PreviousCR:                previous.CR,
+		PreviousCustomMetricAvg:   previous.CustomMetricAvg,
+		PreviousCustomMetricTotal: previous.CustomMetricTotal,
 	}, nil
 }
 
 // Referrer returns the visitor count and bounce rate grouped by referrer.
 func (visitors *Visitors) Referrer(filter *Filter) ([]model.ReferrerStats, error) {
+	return compare(visitors.analyzer, filter, visitors.referrer, func(stats model.ReferrerStats) string {
+		return compareKey(stats.ReferrerName, stats.Referrer)
+	})
+}
+
+func (visitors *Visitors) referrer(filter *Filter) ([]model.ReferrerStats, error) {
 	filter = visitors.analyzer.getFilter(filter)
 	fields := []Field{
 		FieldReferrerName,
@@ -324,27 +320,29 @@ func (visitors *Visitors) Referrer(filter *Filter) ([]model.ReferrerStats, error
 	return stats, nil
 }
 
-func (visitors *Visitors) getPreviousPeriod(filter *Filter) {
-	if filter.From.Equal(filter.To) {
-		if filter.To.Equal(util.Today()) {
-			filter.From = filter.From.Add(-time.Hour * 24 * 7)
-			filter.To = time.Now().UTC().Add(-time.Hour * 24 * 7)
-			filter.IncludeTime = true
-		} else {
-			filter.From = filter.From.Add(-time.Hour * 24 * 7)
-			filter.To = filter.To.Add(-time.Hour * 24 * 7)
-		}
+func (visitors *Visitors) growthStats(filter *Filter, fields []Field, includeCustomMetric bool) (*model.GrowthStats, int, error) {
+	q, args := filter.buildQuery(fields, nil, nil)
+	stats, err := visitors.store.GetGrowthStats(q, filter.IncludeCR, includeCustomMetric, args...)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var timeSpent int
+
+	if len(filter.EventName) != 0 {
+		timeSpent, err = visitors.totalEventDuration(filter)
+	} else if len(filter.Path) == 0 {
+		timeSpent, err = visitors.totalSessionDuration(filter)
 	} else {
-		days := filter.To.Sub(filter.From)
+		timeSpent, err = visitors.totalTimeOnPage(filter)
+	}
 
-		if days >= time.Hour*24 {
-			filter.To = filter.From.Add(-time.Hour * 24)
-			filter.From = filter.To.Add(-days)
-		} else {
-			filter.From = filter.From.Add(-time.Hour * 24)
-			filter.To = filter.To.Add(-time.Hour * 24)
-		}
+	if err != nil {
+		return nil, 0, err
 	}
+
+	return stats, timeSpent, nil
 }
 
 func (visitors *Visitors) totalSessionDuration(filter *Filter) (int, error) {
@@ -1025,6 +1025,60 @@ func TestAnalyzer_Growth(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestAnalyzer_GrowthCompare(t *testing.T) {
+	db.CleanupDB(t, dbClient)
+	days := []struct {
+		day      time.Time
+		visitors int
+	}{
+		{util.PastDay(14), 3},
+		{util.PastDay(8), 2},
+		{util.PastDay(2), 1},
+		{util.PastDay(1), 4},
+	}
+	sessions := make([]model.Session, 0)
+	visitorID := uint64(1)
+
+	for _, d := range days {
+		for i := 0; i < d.visitors; i++ {
+			sessions = append(sessions, model.Session{Sign: 1, VisitorID: visitorID, Time: d.day, Start: time.Now(), ExitPath: "/", PageViews: 2, IsBounce: i == 0})
+			visitorID++
+		}
+	}
+
+	assert.NoError(t, dbClient.SaveSessions(sessions))
+	time.Sleep(time.Millisecond * 20)
+	analyzer := NewAnalyzer(dbClient)
+	modes := []pkg.CompareMode{pkg.CompareNone, pkg.ComparePrevious, pkg.ComparePreviousWeekday, pkg.CompareYear, pkg.CompareCustom}
+	expected := []int{2, 1, 2, 0, 3}
+
+	for i, mode := range modes {
+		growth, err := analyzer.Visitors.Growth(&Filter{
+			From:        util.PastDay(1),
+			To:          util.PastDay(1),
+			Compare:     mode,
+			CompareFrom: util.PastDay(14),
+			CompareTo:   util.PastDay(14),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, expected[i], growth.PreviousVisitors)
+		assert.Equal(t, expected[i], growth.PreviousSessions)
+		assert.Equal(t, expected[i]*2, growth.PreviousViews)
+		assert.InDelta(t, calculateGrowth(4, expected[i]), growth.VisitorsGrowth, 0.001)
+
+		if expected[i] > 0 {
+			assert.Equal(t, 1, growth.PreviousBounces)
+			assert.InDelta(t, 1/float64(expected[i]), growth.PreviousBounceRate, 0.001)
+		}
+	}
+
+	stats, err := analyzer.Visitors.TotalVisitorsPageViews(&Filter{From: util.PastDay(1), To: util.PastDay(1), Compare: pkg.ComparePrevious})
+	assert.NoError(t, err)
+	assert.InDelta(t, 3, stats.VisitorsGrowth, 0.001)
+	_, err = analyzer.Visitors.Growth(&Filter{From: util.PastDay(1), To: util.PastDay(1), Compare: pkg.CompareCustom})
+	assert.ErrorIs(t, err, ErrNoComparisonPeriod)
+}
+
 func TestAnalyzer_GrowthDay(t *testing.T) {
 	db.CleanupDB(t, dbClient)
 	assert.NoError(t, dbClient.SaveSessions([]model.Session{
@@ -19,6 +19,39 @@ const (
 	// BrowserIE represents the Internet Explorer browser.
 	BrowserIE = "IE"
 
+	// BrowserSamsung represents the Samsung Internet browser.
+	BrowserSamsung = "Samsung Internet"
+
+	// BrowserBrave represents the Brave browser.
+	BrowserBrave = "Brave"
+
+	// BrowserVivaldi represents the Vivaldi browser.
+	BrowserVivaldi = "Vivaldi"
+
+	// BrowserYandex represents the Yandex browser.
+	BrowserYandex = "Yandex"
+
+	// BrowserUC represents the UC browser.
+	BrowserUC = "UC Browser"
+
+	// BrowserDuckDuckGo represents the DuckDuckGo browser.
+	BrowserDuckDuckGo = "DuckDuckGo"
+
+	// BrowserInstagram represents the in-app browser of the Instagram app.
+	BrowserInstagram = "Instagram"
+
+	// BrowserFacebook represents the in-app browser of the Facebook and Messenger apps.
+	BrowserFacebook = "Facebook"
+
+	// BrowserTikTok represents the in-app browser of the TikTok app.
+	BrowserTikTok = "TikTok"
+
+	// BrowserAndroidWebView represents websites opened inside an Android app.
+	BrowserAndroidWebView = "Android WebView"
+
+	// BrowserIOSWebView represents websites opened inside an iOS app (WKWebView).
+	BrowserIOSWebView = "iOS WebView"
+
 	// OSWindows represents the Windows operating system.
 	OSWindows = "Windows"
 
@@ -49,6 +82,24 @@ const (
 	// PlatformUnknown filters for everything where the platform is unspecified.
 	PlatformUnknown = "unknown"
 
+	// DeviceTypeDesktop represents desktop and laptop computers.
+	DeviceTypeDesktop = "desktop"
+
+	// DeviceTypeMobile represents smartphones.
+	DeviceTypeMobile = "mobile"
+
+	// DeviceTypeTablet represents tablets and e-readers.
+	DeviceTypeTablet = "tablet"
+
+	// DeviceTypeTV represents smart TVs and streaming devices.
+	DeviceTypeTV = "tv"
+
+	// DeviceTypeConsole represents gaming consoles.
+	DeviceTypeConsole = "console"
+
+	// DeviceTypeWearable represents smartwatches and other wearables.
+	DeviceTypeWearable = "wearable"
+
 	// Unknown filters for an unknown (empty) value.
 	// This is a synonym for "null".
 	Unknown = "null"
@@ -64,6 +115,30 @@ const (
 
 	// CustomMetricTypeFloat transforms the metadata value of an event to a floating point value (64 bit).
 	CustomMetricTypeFloat = CustomMetricType("toFloat64OrZero")
+
+	// TopMoversAbsolute sorts compared results by the absolute change in visitors.
+	TopMoversAbsolute = TopMovers("absolute")
+
+	// TopMoversRelative sorts compared results by the relative change in visitors.
+	TopMoversRelative = TopMovers("relative")
+
+	// OperatorEqual compares a value to be equal.
+	OperatorEqual = Operator("=")
+
+	// OperatorNotEqual compares a value to be not equal.
+	OperatorNotEqual = Operator("!=")
+
+	// OperatorGreater compares a value to be greater.
+	OperatorGreater = Operator(">")
+
+	// OperatorGreaterOrEqual compares a value to be greater or equal.
+	OperatorGreaterOrEqual = Operator(">=")
+
+	// OperatorLess compares a value to be less.
+	OperatorLess = Operator("<")
+
+	// OperatorLessOrEqual compares a value to be less or equal.
+	OperatorLessOrEqual = Operator("<=")
 )
 
 const (
@@ -83,11 +158,39 @@ const (
 // Period is used to group results.
 type Period int
 
+const (
+	// CompareNone disables the comparison to another period for breakdowns.
+	// Analyzer.Visitors.Growth compares single days to the same day one week earlier, and ranges to the preceding range of equal length.
+	CompareNone = CompareMode(iota)
+
+	// ComparePrevious compares results to the previous period.
+	// Single days are compared to the same day one week earlier, ranges to the preceding range of equal length.
+	ComparePrevious
+
+	// CompareYear compares results to the same period one year earlier.
+	CompareYear
+
+	// CompareCustom compares results to a custom period.
+	CompareCustom
+
+	// ComparePreviousWeekday compares results to the previous period of equal length, shifted by whole weeks so that the weekdays align.
+	ComparePreviousWeekday
+)
+
+// CompareMode sets the period results are compared to.
+type CompareMode int
+
+// TopMovers is used to sort compared results by their change.
+type TopMovers string
+
 // Direction is used to sort results.
 type Direction string
 
 // CustomMetricType is used to set the type of a custom metric event meta value.
 type CustomMetricType string
 
+// Operator is used to compare numeric values.
+type Operator string
+
 // NullClient is a placeholder for no client (0).
 var NullClient = int64(0)
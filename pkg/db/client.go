@@ -11,6 +11,7 @@ import (
 	"github.com/pirsch-analytics/pirsch/v6/pkg/model"
 	"log/slog"
 	"os"
+	"reflect"
 	"time"
 )
 
@@ -149,9 +150,9 @@ func (client *Client) SavePageViews(pageViews []model.PageView) error {
 	}
 
 	query, err := tx.Prepare(`INSERT INTO "page_view" (client_id, visitor_id, session_id, time, duration_seconds,
-		path, title, language, country_code, city, referrer, referrer_name, referrer_icon, os, os_version,
-		browser, browser_version, desktop, mobile, screen_class,
-		utm_source, utm_medium, utm_campaign, utm_content, utm_term) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`)
+		path, title, language, country_code, region, city, time_zone, asn, as_organization, referrer, referrer_name, referrer_icon, os, os_version,
+		browser, browser_version, desktop, mobile, device_type, device_vendor, device_model, screen_class,
+		utm_source, utm_medium, utm_campaign, utm_content, utm_term) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`)
 
 	if err != nil {
 		return err
@@ -167,7 +168,11 @@ func (client *Client) SavePageViews(pageViews []model.PageView) error {
 			pageView.Title,
 			pageView.Language,
 			pageView.CountryCode,
+			pageView.Region,
 			pageView.City,
+			pageView.TimeZone,
+			pageView.ASN,
+			pageView.ASOrganization,
 			pageView.Referrer,
 			pageView.ReferrerName,
 			pageView.ReferrerIcon,
@@ -177,6 +182,9 @@ func (client *Client) SavePageViews(pageViews []model.PageView) error {
 			pageView.BrowserVersion,
 			client.boolean(pageView.Desktop),
 			client.boolean(pageView.Mobile),
+			pageView.DeviceType,
+			pageView.DeviceVendor,
+			pageView.DeviceModel,
 			pageView.ScreenClass,
 			pageView.UTMSource,
 			pageView.UTMMedium,
@@ -213,10 +221,10 @@ func (client *Client) SaveSessions(sessions []model.Session) error {
 	}
 
 	query, err := tx.Prepare(`INSERT INTO "session" (sign, client_id, visitor_id, session_id, time, start, duration_seconds,
-		entry_path, exit_path, page_views, is_bounce, entry_title, exit_title, language, country_code, city, referrer, referrer_name, referrer_icon, os, os_version,
-		browser, browser_version, desktop, mobile, screen_class,
+		entry_path, exit_path, page_views, is_bounce, entry_title, exit_title, language, country_code, region, city, time_zone, asn, as_organization, referrer, referrer_name, referrer_icon, os, os_version,
+		browser, browser_version, desktop, mobile, device_type, device_vendor, device_model, screen_class,
 		utm_source, utm_medium, utm_campaign, utm_content, utm_term, extended)
-		VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`)
+		VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`)
 
 	if err != nil {
 		return err
@@ -238,7 +246,11 @@ func (client *Client) SaveSessions(sessions []model.Session) error {
 			session.ExitTitle,
 			session.Language,
 			session.CountryCode,
+			session.Region,
 			session.City,
+			session.TimeZone,
+			session.ASN,
+			session.ASOrganization,
 			session.Referrer,
 			session.ReferrerName,
 			session.ReferrerIcon,
@@ -248,6 +260,9 @@ func (client *Client) SaveSessions(sessions []model.Session) error {
 			session.BrowserVersion,
 			client.boolean(session.Desktop),
 			client.boolean(session.Mobile),
+			session.DeviceType,
+			session.DeviceVendor,
+			session.DeviceModel,
 			session.ScreenClass,
 			session.UTMSource,
 			session.UTMMedium,
@@ -285,9 +300,9 @@ func (client *Client) SaveEvents(events []model.Event) error {
 	}
 
 	query, err := tx.Prepare(`INSERT INTO "event" (client_id, visitor_id, time, session_id, event_name, event_meta_keys, event_meta_values, duration_seconds,
-		path, title, language, country_code, city, referrer, referrer_name, referrer_icon, os, os_version,
-		browser, browser_version, desktop, mobile, screen_class,
-		utm_source, utm_medium, utm_campaign, utm_content, utm_term) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`)
+		path, title, language, country_code, region, city, time_zone, asn, as_organization, referrer, referrer_name, referrer_icon, os, os_version,
+		browser, browser_version, desktop, mobile, device_type, device_vendor, device_model, screen_class,
+		utm_source, utm_medium, utm_campaign, utm_content, utm_term) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`)
 
 	if err != nil {
 		return err
@@ -306,7 +321,11 @@ func (client *Client) SaveEvents(events []model.Event) error {
 			event.Title,
 			event.Language,
 			event.CountryCode,
+			event.Region,
 			event.City,
+			event.TimeZone,
+			event.ASN,
+			event.ASOrganization,
 			event.Referrer,
 			event.ReferrerName,
 			event.ReferrerIcon,
@@ -316,6 +335,9 @@ func (client *Client) SaveEvents(events []model.Event) error {
 			event.BrowserVersion,
 			client.boolean(event.Desktop),
 			client.boolean(event.Mobile),
+			event.DeviceType,
+			event.DeviceVendor,
+			event.DeviceModel,
 			event.ScreenClass,
 			event.UTMSource,
 			event.UTMMedium,
@@ -433,7 +455,11 @@ func (client *Client) Session(clientID, fingerprint uint64, maxAge time.Time) (*
 		exit_title,
 		language,
 		country_code,
+		region,
 		city,
+		time_zone,
+		asn,
+		as_organization,
 		referrer,
 		referrer_name,
 		referrer_icon,
@@ -443,6 +469,9 @@ func (client *Client) Session(clientID, fingerprint uint64, maxAge time.Time) (*
 		browser_version,
 		desktop,
 		mobile,
+		device_type,
+		device_vendor,
+		device_model,
 		screen_class,
 		utm_source,
 		utm_medium,
@@ -472,7 +501,11 @@ func (client *Client) Session(clientID, fingerprint uint64, maxAge time.Time) (*
 		&session.ExitTitle,
 		&session.Language,
 		&session.CountryCode,
+		&session.Region,
 		&session.City,
+		&session.TimeZone,
+		&session.ASN,
+		&session.ASOrganization,
 		&session.Referrer,
 		&session.ReferrerName,
 		&session.ReferrerIcon,
@@ -482,6 +515,9 @@ func (client *Client) Session(clientID, fingerprint uint64, maxAge time.Time) (*
 		&session.BrowserVersion,
 		&session.Desktop,
 		&session.Mobile,
+		&session.DeviceType,
+		&session.DeviceVendor,
+		&session.DeviceModel,
 		&session.ScreenClass,
 		&session.UTMSource,
 		&session.UTMMedium,
@@ -1436,6 +1472,30 @@ func (client *Client) SelectCountryStats(query string, args ...any) ([]model.Cou
 	return results, nil
 }
 
+// SelectRegionStats implements the Store interface.
+func (client *Client) SelectRegionStats(query string, args ...any) ([]model.RegionStats, error) {
+	rows, err := client.Query(query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.closeRows(rows)
+	var results []model.RegionStats
+
+	for rows.Next() {
+		var result model.RegionStats
+
+		if err := rows.Scan(&result.Region, &result.Visitors, &result.RelativeVisitors); err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
 // SelectCityStats implements the Store interface.
 func (client *Client) SelectCityStats(query string, args ...any) ([]model.CityStats, error) {
 	rows, err := client.Query(query, args...)
@@ -1460,6 +1520,54 @@ func (client *Client) SelectCityStats(query string, args ...any) ([]model.CitySt
 	return results, nil
 }
 
+// SelectTimeZoneStats implements the Store interface.
+func (client *Client) SelectTimeZoneStats(query string, args ...any) ([]model.TimeZoneStats, error) {
+	rows, err := client.Query(query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.closeRows(rows)
+	var results []model.TimeZoneStats
+
+	for rows.Next() {
+		var result model.TimeZoneStats
+
+		if err := rows.Scan(&result.TimeZone, &result.Visitors, &result.RelativeVisitors); err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// SelectNetworkStats implements the Store interface.
+func (client *Client) SelectNetworkStats(query string, args ...any) ([]model.NetworkStats, error) {
+	rows, err := client.Query(query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.closeRows(rows)
+	var results []model.NetworkStats
+
+	for rows.Next() {
+		var result model.NetworkStats
+
+		if err := rows.Scan(&result.ASN, &result.ASOrganization, &result.Visitors, &result.RelativeVisitors); err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
 // SelectBrowserStats implements the Store interface.
 func (client *Client) SelectBrowserStats(query string, args ...any) ([]model.BrowserStats, error) {
 	rows, err := client.Query(query, args...)
@@ -1700,6 +1808,54 @@ func (client *Client) SelectBrowserVersionStats(query string, args ...any) ([]mo
 	return results, nil
 }
 
+// SelectDeviceTypeStats implements the Store interface.
+func (client *Client) SelectDeviceTypeStats(query string, args ...any) ([]model.DeviceTypeStats, error) {
+	rows, err := client.Query(query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.closeRows(rows)
+	var results []model.DeviceTypeStats
+
+	for rows.Next() {
+		var result model.DeviceTypeStats
+
+		if err := rows.Scan(&result.DeviceType, &result.Visitors, &result.RelativeVisitors); err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// SelectDeviceModelStats implements the Store interface.
+func (client *Client) SelectDeviceModelStats(query string, args ...any) ([]model.DeviceModelStats, error) {
+	rows, err := client.Query(query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.closeRows(rows)
+	var results []model.DeviceModelStats
+
+	for rows.Next() {
+		var result model.DeviceModelStats
+
+		if err := rows.Scan(&result.DeviceVendor, &result.DeviceModel, &result.Visitors, &result.RelativeVisitors); err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
 // SelectOptions implements the Store interface.
 func (client *Client) SelectOptions(query string, args ...any) ([]string, error) {
 	rows, err := client.Query(query, args...)
@@ -1737,3 +1893,168 @@ func (client *Client) closeRows(rows *sql.Rows) {
 		client.logger.Error("error closing rows", "err", err)
 	}
 }
+
+// SelectFunnelStepStats implements the Store interface.
+func (client *Client) SelectFunnelStepStats(query string, args ...any) ([]model.FunnelStepStats, error) {
+	rows, err := client.Query(query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.closeRows(rows)
+	var results []model.FunnelStepStats
+
+	for rows.Next() {
+		var result model.FunnelStepStats
+
+		if err := rows.Scan(&result.Step, &result.Visitors, &result.Sessions); err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// SelectPageTransitionStats implements the Store interface.
+func (client *Client) SelectPageTransitionStats(query string, args ...any) ([]model.PageTransitionStats, error) {
+	rows, err := client.Query(query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.closeRows(rows)
+	var results []model.PageTransitionStats
+
+	for rows.Next() {
+		var result model.PageTransitionStats
+
+		if err := rows.Scan(&result.Path, &result.Visitors, &result.Transitions, &result.RelativeTransitions); err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// SelectPathTreeStats implements the Store interface.
+func (client *Client) SelectPathTreeStats(query string, args ...any) ([]model.PathTreeStats, error) {
+	rows, err := client.Query(query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.closeRows(rows)
+	var results []model.PathTreeStats
+
+	for rows.Next() {
+		var result model.PathTreeStats
+
+		if err := rows.Scan(&result.Path, &result.Visitors, &result.Sessions); err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// SelectGoalConversionStats implements the Store interface.
+func (client *Client) SelectGoalConversionStats(query string, args ...any) ([]model.GoalConversionStats, error) {
+	rows, err := client.Query(query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.closeRows(rows)
+	var results []model.GoalConversionStats
+
+	for rows.Next() {
+		var result model.GoalConversionStats
+
+		if err := rows.Scan(&result.Dimension, &result.Goal, &result.Visitors, &result.Sessions); err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// SelectRows implements the Store interface.
+func (client *Client) SelectRows(query string, args ...any) ([]map[string]any, error) {
+	rows, err := client.Query(query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.closeRows(rows)
+	columns, err := rows.ColumnTypes()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var results []map[string]any
+
+	for rows.Next() {
+		values := make([]any, len(columns))
+
+		for i, column := range columns {
+			if scanType := column.ScanType(); scanType != nil {
+				values[i] = reflect.New(scanType).Interface()
+			} else {
+				values[i] = new(any)
+			}
+		}
+
+		if err := rows.Scan(values...); err != nil {
+			return nil, err
+		}
+
+		result := make(map[string]any, len(columns))
+
+		for i, column := range columns {
+			result[column.Name()] = client.rowValue(reflect.ValueOf(values[i]).Elem())
+		}
+
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (client *Client) rowValue(value reflect.Value) any {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		return value.Float()
+	default:
+		return value.Interface()
+	}
+}
@@ -262,11 +262,26 @@ func (client *ClientMock) SelectCountryStats(string, ...any) ([]model.CountrySta
 	return nil, nil
 }
 
+// SelectRegionStats implements the Store interface.
+func (client *ClientMock) SelectRegionStats(string, ...any) ([]model.RegionStats, error) {
+	return nil, nil
+}
+
 // SelectCityStats implements the Store interface.
 func (client *ClientMock) SelectCityStats(string, ...any) ([]model.CityStats, error) {
 	return nil, nil
 }
 
+// SelectTimeZoneStats implements the Store interface.
+func (client *ClientMock) SelectTimeZoneStats(string, ...any) ([]model.TimeZoneStats, error) {
+	return nil, nil
+}
+
+// SelectNetworkStats implements the Store interface.
+func (client *ClientMock) SelectNetworkStats(string, ...any) ([]model.NetworkStats, error) {
+	return nil, nil
+}
+
 // SelectBrowserStats implements the Store interface.
 func (client *ClientMock) SelectBrowserStats(string, ...any) ([]model.BrowserStats, error) {
 	return nil, nil
@@ -317,7 +332,42 @@ func (client *ClientMock) SelectBrowserVersionStats(string, ...any) ([]model.Bro
 	return nil, nil
 }
 
+// SelectDeviceTypeStats implements the Store interface.
+func (client *ClientMock) SelectDeviceTypeStats(string, ...any) ([]model.DeviceTypeStats, error) {
+	return nil, nil
+}
+
+// SelectDeviceModelStats implements the Store interface.
+func (client *ClientMock) SelectDeviceModelStats(string, ...any) ([]model.DeviceModelStats, error) {
+	return nil, nil
+}
+
 // SelectOptions implements the Store interface.
 func (client *ClientMock) SelectOptions(string, ...any) ([]string, error) {
 	return nil, nil
 }
+
+// SelectFunnelStepStats implements the Store interface.
+func (client *ClientMock) SelectFunnelStepStats(string, ...any) ([]model.FunnelStepStats, error) {
+	return nil, nil
+}
+
+// SelectPageTransitionStats implements the Store interface.
+func (client *ClientMock) SelectPageTransitionStats(string, ...any) ([]model.PageTransitionStats, error) {
+	return nil, nil
+}
+
+// SelectPathTreeStats implements the Store interface.
+func (client *ClientMock) SelectPathTreeStats(string, ...any) ([]model.PathTreeStats, error) {
+	return nil, nil
+}
+
+// SelectGoalConversionStats implements the Store interface.
+func (client *ClientMock) SelectGoalConversionStats(string, ...any) ([]model.GoalConversionStats, error) {
+	return nil, nil
+}
+
+// SelectRows implements the Store interface.
+func (client *ClientMock) SelectRows(string, ...any) ([]map[string]any, error) {
+	return nil, nil
+}
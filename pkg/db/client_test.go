@@ -263,6 +263,22 @@ func TestClient_Session(t *testing.T) {
 	assert.Equal(t, uint16(3), session.PageViews)
 }
 
+func TestClient_SelectRows(t *testing.T) {
+	CleanupDB(t, dbClient)
+	rows, err := dbClient.SelectRows(`SELECT toUInt8(1) a, toInt64(-2) b, 0.5 c, 'str' d, CAST(NULL AS Nullable(String)) e, toDate('2024-01-02') f`)
+	assert.NoError(t, err)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, 1, rows[0]["a"])
+	assert.Equal(t, -2, rows[0]["b"])
+	assert.InDelta(t, 0.5, rows[0]["c"], 0.001)
+	assert.Equal(t, "str", rows[0]["d"])
+	assert.Nil(t, rows[0]["e"])
+	assert.Equal(t, 2, rows[0]["f"].(time.Time).Day())
+	rows, err = dbClient.SelectRows(`SELECT 1 WHERE 1 = 0`)
+	assert.NoError(t, err)
+	assert.Empty(t, rows)
+}
+
 func TestClient_GetNoError(t *testing.T) {
 	CleanupDB(t, dbClient)
 	var sessions int
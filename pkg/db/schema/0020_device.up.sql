@@ -0,0 +1,9 @@
+ALTER TABLE `session` ADD COLUMN `device_type` LowCardinality(String) AFTER `mobile`;
+ALTER TABLE `session` ADD COLUMN `device_vendor` LowCardinality(String) AFTER `device_type`;
+ALTER TABLE `session` ADD COLUMN `device_model` LowCardinality(String) AFTER `device_vendor`;
+ALTER TABLE `page_view` ADD COLUMN `device_type` LowCardinality(String) AFTER `mobile`;
+ALTER TABLE `page_view` ADD COLUMN `device_vendor` LowCardinality(String) AFTER `device_type`;
+ALTER TABLE `page_view` ADD COLUMN `device_model` LowCardinality(String) AFTER `device_vendor`;
+ALTER TABLE `event` ADD COLUMN `device_type` LowCardinality(String) AFTER `mobile`;
+ALTER TABLE `event` ADD COLUMN `device_vendor` LowCardinality(String) AFTER `device_type`;
+ALTER TABLE `event` ADD COLUMN `device_model` LowCardinality(String) AFTER `device_vendor`;
@@ -0,0 +1,12 @@
+ALTER TABLE `session` ADD COLUMN `region` LowCardinality(String) AFTER `country_code`;
+ALTER TABLE `session` ADD COLUMN `time_zone` LowCardinality(String) AFTER `city`;
+ALTER TABLE `session` ADD COLUMN `asn` UInt32 AFTER `time_zone`;
+ALTER TABLE `session` ADD COLUMN `as_organization` LowCardinality(String) AFTER `asn`;
+ALTER TABLE `page_view` ADD COLUMN `region` LowCardinality(String) AFTER `country_code`;
+ALTER TABLE `page_view` ADD COLUMN `time_zone` LowCardinality(String) AFTER `city`;
+ALTER TABLE `page_view` ADD COLUMN `asn` UInt32 AFTER `time_zone`;
+ALTER TABLE `page_view` ADD COLUMN `as_organization` LowCardinality(String) AFTER `asn`;
+ALTER TABLE `event` ADD COLUMN `region` LowCardinality(String) AFTER `country_code`;
+ALTER TABLE `event` ADD COLUMN `time_zone` LowCardinality(String) AFTER `city`;
+ALTER TABLE `event` ADD COLUMN `asn` UInt32 AFTER `time_zone`;
+ALTER TABLE `event` ADD COLUMN `as_organization` LowCardinality(String) AFTER `asn`;
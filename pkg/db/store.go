@@ -89,9 +89,18 @@ type Store interface {
 	// SelectCountryStats selects CountryStats.
 	SelectCountryStats(string, ...any) ([]model.CountryStats, error)
 
+	// SelectRegionStats selects RegionStats.
+	SelectRegionStats(string, ...any) ([]model.RegionStats, error)
+
 	// SelectCityStats selects CityStats.
 	SelectCityStats(string, ...any) ([]model.CityStats, error)
 
+	// SelectTimeZoneStats selects TimeZoneStats.
+	SelectTimeZoneStats(string, ...any) ([]model.TimeZoneStats, error)
+
+	// SelectNetworkStats selects NetworkStats.
+	SelectNetworkStats(string, ...any) ([]model.NetworkStats, error)
+
 	// SelectBrowserStats selects BrowserStats.
 	SelectBrowserStats(string, ...any) ([]model.BrowserStats, error)
 
@@ -122,6 +131,28 @@ type Store interface {
 	// SelectBrowserVersionStats selects BrowserVersionStats.
 	SelectBrowserVersionStats(string, ...any) ([]model.BrowserVersionStats, error)
 
+	// SelectDeviceTypeStats selects DeviceTypeStats.
+	SelectDeviceTypeStats(string, ...any) ([]model.DeviceTypeStats, error)
+
+	// SelectDeviceModelStats selects DeviceModelStats.
+	SelectDeviceModelStats(string, ...any) ([]model.DeviceModelStats, error)
+
 	// SelectOptions selects a list of filter options.
 	SelectOptions(string, ...any) ([]string, error)
+
+	// SelectFunnelStepStats selects FunnelStepStats.
+	SelectFunnelStepStats(string, ...any) ([]model.FunnelStepStats, error)
+
+	// SelectPageTransitionStats selects PageTransitionStats.
+	SelectPageTransitionStats(string, ...any) ([]model.PageTransitionStats, error)
+
+	// SelectPathTreeStats selects PathTreeStats.
+	SelectPathTreeStats(string, ...any) ([]model.PathTreeStats, error)
+
+	// SelectGoalConversionStats selects GoalConversionStats.
+	SelectGoalConversionStats(string, ...any) ([]model.GoalConversionStats, error)
+
+	// SelectRows selects generic rows as values by column name.
+	// Integers are returned as int, floats as float64, and NULL as nil.
+	SelectRows(string, ...any) ([]map[string]any, error)
 }
@@ -16,7 +16,11 @@ type PageView struct {
 	Title           string    `json:"title"`
 	Language        string    `json:"language"`
 	CountryCode     string    `db:"country_code" json:"country_code"`
+	Region          string    `json:"region"`
 	City            string    `json:"city"`
+	TimeZone        string    `db:"time_zone" json:"time_zone"`
+	ASN             uint32    `json:"asn"`
+	ASOrganization  string    `db:"as_organization" json:"as_organization"`
 	Referrer        string    `json:"referrer"`
 	ReferrerName    string    `db:"referrer_name" json:"referrer_name"`
 	ReferrerIcon    string    `db:"referrer_icon" json:"referrer_icon"`
@@ -26,6 +30,9 @@ type PageView struct {
 	BrowserVersion  string    `db:"browser_version" json:"browser_version"`
 	Desktop         bool      `json:"desktop"`
 	Mobile          bool      `json:"mobile"`
+	DeviceType      string    `db:"device_type" json:"device_type"`
+	DeviceVendor    string    `db:"device_vendor" json:"device_vendor"`
+	DeviceModel     string    `db:"device_model" json:"device_model"`
 	ScreenClass     string    `db:"screen_class" json:"screen_class"`
 	UTMSource       string    `db:"utm_source" json:"utm_source"`
 	UTMMedium       string    `db:"utm_medium" json:"utm_medium"`
@@ -2,6 +2,7 @@ package model
 
 import (
 	"github.com/emvi/null"
+	"time"
 )
 
 // ActiveVisitorStats is the result type for active visitor statistics.
@@ -48,15 +49,25 @@ type VisitorStats struct {
 }
 
 // Growth represents the visitors, views, sessions, bounces, and average session duration growth between two time periods.
+// The Previous fields contain the values of the period compared to.
 type Growth struct {
-	VisitorsGrowth          float64 `json:"visitors_growth"`
-	ViewsGrowth             float64 `json:"views_growth"`
-	SessionsGrowth          float64 `json:"sessions_growth"`
-	BouncesGrowth           float64 `json:"bounces_growth"`
-	TimeSpentGrowth         float64 `json:"time_spent_growth"`
-	CRGrowth                float64 `json:"cr_growth"`
-	CustomMetricAvgGrowth   float64 `json:"custom_metric_avg_growth"`
-	CustomMetricTotalGrowth float64 `json:"custom_metric_total_growth"`
+	VisitorsGrowth            float64 `json:"visitors_growth"`
+	ViewsGrowth               float64 `json:"views_growth"`
+	SessionsGrowth            float64 `json:"sessions_growth"`
+	BouncesGrowth             float64 `json:"bounces_growth"`
+	TimeSpentGrowth           float64 `json:"time_spent_growth"`
+	CRGrowth                  float64 `json:"cr_growth"`
+	CustomMetricAvgGrowth     float64 `json:"custom_metric_avg_growth"`
+	CustomMetricTotalGrowth   float64 `json:"custom_metric_total_growth"`
+	PreviousVisitors          int     `json:"previous_visitors"`
+	PreviousViews             int     `json:"previous_views"`
+	PreviousSessions          int     `json:"previous_sessions"`
+	PreviousBounces           int     `json:"previous_bounces"`
+	PreviousBounceRate        float64 `json:"previous_bounce_rate"`
+	PreviousTimeSpentSeconds  int     `json:"previous_time_spent_seconds"`
+	PreviousCR                float64 `json:"previous_cr"`
+	PreviousCustomMetricAvg   float64 `json:"previous_custom_metric_avg"`
+	PreviousCustomMetricTotal float64 `json:"previous_custom_metric_total"`
 }
 
 // VisitorHourStats is the result type for visitor statistics grouped by time of day.
@@ -74,6 +85,7 @@ type VisitorHourStats struct {
 
 // PageStats is the result type for page statistics.
 type PageStats struct {
+	Comparison
 	Path                    string  `json:"path"`
 	Title                   string  `json:"title"`
 	Visitors                int     `json:"visitors"`
@@ -90,8 +102,24 @@ func (stats PageStats) GetPath() string {
 	return stats.Path
 }
 
+func (stats PageStats) GetVisitors() int {
+	return stats.Visitors
+}
+
+func (stats *PageStats) ResetMetrics() {
+	stats.Visitors = 0
+	stats.Views = 0
+	stats.Sessions = 0
+	stats.Bounces = 0
+	stats.RelativeVisitors = 0
+	stats.RelativeViews = 0
+	stats.BounceRate = 0
+	stats.AverageTimeSpentSeconds = 0
+}
+
 // EntryStats is the result type for entry page statistics.
 type EntryStats struct {
+	Comparison
 	Path                    string  `db:"entry_path" json:"path"`
 	Title                   string  `json:"title"`
 	Visitors                int     `json:"visitors"`
@@ -105,8 +133,21 @@ func (stats EntryStats) GetPath() string {
 	return stats.Path
 }
 
+func (stats EntryStats) GetVisitors() int {
+	return stats.Visitors
+}
+
+func (stats *EntryStats) ResetMetrics() {
+	stats.Visitors = 0
+	stats.Sessions = 0
+	stats.Entries = 0
+	stats.EntryRate = 0
+	stats.AverageTimeSpentSeconds = 0
+}
+
 // ExitStats is the result type for exit page statistics.
 type ExitStats struct {
+	Comparison
 	Path     string  `db:"exit_path" json:"path"`
 	Title    string  `json:"title"`
 	Visitors int     `json:"visitors"`
@@ -119,6 +160,17 @@ func (stats ExitStats) GetPath() string {
 	return stats.Path
 }
 
+func (stats ExitStats) GetVisitors() int {
+	return stats.Visitors
+}
+
+func (stats *ExitStats) ResetMetrics() {
+	stats.Visitors = 0
+	stats.Sessions = 0
+	stats.Exits = 0
+	stats.ExitRate = 0
+}
+
 // ConversionsStats is the result type for page conversions.
 type ConversionsStats struct {
 	Visitors          int     `json:"visitors"`
@@ -130,6 +182,7 @@ type ConversionsStats struct {
 
 // EventStats is the result type for custom events.
 type EventStats struct {
+	Comparison
 	Name                   string   `db:"event_name" json:"name"`
 	Visitors               int      `json:"visitors"`
 	Views                  int      `json:"views"`
@@ -139,6 +192,17 @@ type EventStats struct {
 	MetaValue              string   `db:"meta_value" json:"meta_value"`
 }
 
+func (stats EventStats) GetVisitors() int {
+	return stats.Visitors
+}
+
+func (stats *EventStats) ResetMetrics() {
+	stats.Visitors = 0
+	stats.Views = 0
+	stats.CR = 0
+	stats.AverageDurationSeconds = 0
+}
+
 // EventListStats is the result type for a custom event list.
 type EventListStats struct {
 	Name     string            `db:"event_name" json:"name"`
@@ -149,6 +213,7 @@ type EventListStats struct {
 
 // ReferrerStats is the result type for referrer statistics.
 type ReferrerStats struct {
+	Comparison
 	Referrer         string  `json:"referrer"`
 	ReferrerName     string  `db:"referrer_name" json:"referrer_name"`
 	ReferrerIcon     string  `db:"referrer_icon" json:"referrer_icon"`
@@ -159,7 +224,21 @@ type ReferrerStats struct {
 	BounceRate       float64 `db:"bounce_rate" json:"bounce_rate"`
 }
 
+func (stats ReferrerStats) GetVisitors() int {
+	return stats.Visitors
+}
+
+func (stats *ReferrerStats) ResetMetrics() {
+	stats.Visitors = 0
+	stats.Sessions = 0
+	stats.RelativeVisitors = 0
+	stats.Bounces = 0
+	stats.BounceRate = 0
+}
+
 // PlatformStats is the result type for platform statistics.
+//
+// Deprecated: use DeviceTypeStats instead.
 type PlatformStats struct {
 	PlatformDesktop         int     `db:"platform_desktop" json:"platform_desktop"`
 	PlatformMobile          int     `db:"platform_mobile" json:"platform_mobile"`
@@ -180,12 +259,34 @@ type TimeSpentStats struct {
 	AverageTimeSpentSeconds int       `db:"average_time_spent_seconds" json:"average_time_spent_seconds"`
 }
 
+// Comparison are the visitors of a row in the comparison period (see analyzer.Filter.Compare) and the change relative to them.
+// The fields are omitted from JSON if they are zero, which is always the case if no comparison has been requested.
+type Comparison struct {
+	PreviousVisitors int     `db:"previous_visitors" json:"previous_visitors,omitempty"`
+	VisitorsChange   int     `db:"visitors_change" json:"visitors_change,omitempty"`
+	VisitorsGrowth   float64 `db:"visitors_growth" json:"visitors_growth,omitempty"`
+}
+
+func (comparison *Comparison) GetComparison() *Comparison {
+	return comparison
+}
+
 // MetaStats is the base for meta result types (languages, countries, ...).
 type MetaStats struct {
+	Comparison
 	Visitors         int     `json:"visitors"`
 	RelativeVisitors float64 `db:"relative_visitors" json:"relative_visitors"`
 }
 
+func (stats MetaStats) GetVisitors() int {
+	return stats.Visitors
+}
+
+func (stats *MetaStats) ResetMetrics() {
+	stats.Visitors = 0
+	stats.RelativeVisitors = 0
+}
+
 // LanguageStats is the result type for language statistics.
 type LanguageStats struct {
 	MetaStats
@@ -198,6 +299,12 @@ type CountryStats struct {
 	CountryCode string `db:"country_code" json:"country_code"`
 }
 
+// RegionStats is the result type for region statistics.
+type RegionStats struct {
+	MetaStats
+	Region string `json:"region"`
+}
+
 // CityStats is the result type for city statistics.
 type CityStats struct {
 	MetaStats
@@ -205,6 +312,19 @@ type CityStats struct {
 	City        string `json:"city"`
 }
 
+// TimeZoneStats is the result type for time zone statistics.
+type TimeZoneStats struct {
+	MetaStats
+	TimeZone string `db:"time_zone" json:"time_zone"`
+}
+
+// NetworkStats is the result type for network (autonomous system) statistics.
+type NetworkStats struct {
+	MetaStats
+	ASN            uint32 `json:"asn"`
+	ASOrganization string `db:"as_organization" json:"as_organization"`
+}
+
 // BrowserStats is the result type for browser statistics.
 type BrowserStats struct {
 	MetaStats
@@ -231,6 +351,19 @@ type OSVersionStats struct {
 	OSVersion string `db:"os_version" json:"os_version"`
 }
 
+// DeviceTypeStats is the result type for device type statistics.
+type DeviceTypeStats struct {
+	MetaStats
+	DeviceType string `db:"device_type" json:"device_type"`
+}
+
+// DeviceModelStats is the result type for device model statistics.
+type DeviceModelStats struct {
+	MetaStats
+	DeviceVendor string `db:"device_vendor" json:"device_vendor"`
+	DeviceModel  string `db:"device_model" json:"device_model"`
+}
+
 // ScreenClassStats is the result type for screen class statistics.
 type ScreenClassStats struct {
 	MetaStats
@@ -279,6 +412,75 @@ type GrowthStats struct {
 	CustomMetricTotal float64 `db:"custom_metric_total" json:"custom_metric_total"`
 }
 
+// FunnelStepStats is the result type for a step in a conversion funnel.
+type FunnelStepStats struct {
+	Step             int     `json:"step"`
+	Name             string  `json:"name"`
+	Visitors         int     `json:"visitors"`
+	Sessions         int     `json:"sessions"`
+	RelativeVisitors float64 `db:"relative_visitors" json:"relative_visitors"`
+	DropOff          int     `db:"drop_off" json:"drop_off"`
+	DropOffRate      float64 `db:"drop_off_rate" json:"drop_off_rate"`
+}
+
+// GoalStats is the result type for a conversion goal.
+type GoalStats struct {
+	Goal      string  `json:"goal"`
+	Dimension string  `json:"dimension"`
+	Visitors  int     `json:"visitors"`
+	Sessions  int     `json:"sessions"`
+	CR        float64 `json:"cr"`
+	Value     float64 `json:"value"`
+}
+
+// AnomalyStats is the result type for an anomaly in a daily or hourly series.
+// Score is the robust z-score of the value compared to the seasonal baseline.
+// A positive score is a spike, a negative score a drop.
+type AnomalyStats struct {
+	Time     time.Time `json:"time"`
+	Metric   string    `json:"metric"`
+	Value    int       `json:"value"`
+	Expected float64   `json:"expected"`
+	Score    float64   `json:"score"`
+}
+
+// QueryStats is the result type for a row returned by Analyzer.Query.
+// Dimensions and Metrics contain the values by field name.
+type QueryStats struct {
+	Dimensions map[string]any     `json:"dimensions"`
+	Metrics    map[string]float64 `json:"metrics"`
+}
+
+// PageFlowStats is the result type for the pages visited before and after a page.
+type PageFlowStats struct {
+	Path     string                `json:"path"`
+	Previous []PageTransitionStats `json:"previous"`
+	Next     []PageTransitionStats `json:"next"`
+}
+
+// PageTransitionStats is the result type for a page visited directly before or after another page.
+type PageTransitionStats struct {
+	Path                string  `json:"path"`
+	Visitors            int     `json:"visitors"`
+	Transitions         int     `json:"transitions"`
+	RelativeTransitions float64 `db:"relative_transitions" json:"relative_transitions"`
+}
+
+// PathTreeNode is a page in a path tree, including the most common pages visited next.
+type PathTreeNode struct {
+	Path     string         `json:"path"`
+	Visitors int            `json:"visitors"`
+	Sessions int            `json:"sessions"`
+	Children []PathTreeNode `json:"children"`
+}
+
+// PathTreeStats are the visitors and sessions following a path.
+type PathTreeStats struct {
+	Path     []string
+	Visitors int
+	Sessions int
+}
+
 // TotalVisitorSessionStats are the total amount of visitors, views, and sessions for a page.
 type TotalVisitorSessionStats struct {
 	Path     string
@@ -287,6 +489,15 @@ type TotalVisitorSessionStats struct {
 	Sessions int
 }
 
+// GoalConversionStats are the visitors and sessions converting for a goal and dimension value.
+// Goal 0 contains the total visitors and sessions.
+type GoalConversionStats struct {
+	Dimension string
+	Goal      int
+	Visitors  int
+	Sessions  int
+}
+
 // AvgTimeSpentStats is the average time spent on a page.
 type AvgTimeSpentStats struct {
 	Path                    string
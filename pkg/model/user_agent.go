@@ -30,10 +30,23 @@ type UserAgent struct {
 	// Mobile indicated whether this is a mobile device from client hint headers.
 	// It'll be set to null if the header is not present or empty.
 	Mobile null.Bool `db:"-"`
+
+	// DeviceType is the type of device (desktop, mobile, tablet, tv, console, wearable).
+	DeviceType string `db:"-"`
+
+	// DeviceVendor is the device manufacturer.
+	DeviceVendor string `db:"-"`
+
+	// DeviceModel is the device model.
+	DeviceModel string `db:"-"`
 }
 
 // IsDesktop returns true if the user agent is a desktop device.
 func (ua *UserAgent) IsDesktop() bool {
+	if ua.DeviceType != "" {
+		return ua.DeviceType == pkg.DeviceTypeDesktop
+	}
+
 	if ua.Mobile.Valid {
 		return !ua.Mobile.Bool
 	}
@@ -42,7 +55,12 @@ func (ua *UserAgent) IsDesktop() bool {
 }
 
 // IsMobile returns true if the user agent is a mobile device.
+// Tablets and wearables are considered mobile devices, TVs and consoles are neither desktop nor mobile.
 func (ua *UserAgent) IsMobile() bool {
+	if ua.DeviceType != "" {
+		return ua.DeviceType == pkg.DeviceTypeMobile || ua.DeviceType == pkg.DeviceTypeTablet || ua.DeviceType == pkg.DeviceTypeWearable
+	}
+
 	if ua.Mobile.Valid {
 		return ua.Mobile.Bool
 	}
@@ -0,0 +1,81 @@
+package tracker
+
+import (
+	"github.com/pirsch-analytics/pirsch/v6/pkg/model"
+)
+
+// batch collects data received by a worker until it is saved.
+type batch struct {
+	tracker    *Tracker
+	sessions   []model.Session
+	pageViews  []model.PageView
+	events     []model.Event
+	userAgents []model.UserAgent
+	bots       []model.Bot
+	items      int64
+}
+
+func (tracker *Tracker) newBatch() *batch {
+	bufferSize := tracker.config.WorkerBufferSize
+	return &batch{
+		tracker:    tracker,
+		sessions:   make([]model.Session, 0, bufferSize*2),
+		pageViews:  make([]model.PageView, 0, bufferSize),
+		events:     make([]model.Event, 0, bufferSize),
+		userAgents: make([]model.UserAgent, 0, bufferSize),
+		bots:       make([]model.Bot, 0, bufferSize),
+	}
+}
+
+func (b *batch) add(data data) {
+	b.items++
+	b.tracker.buffered.Add(1)
+
+	if data.cancelSession != nil {
+		b.sessions = append(b.sessions, *data.cancelSession)
+	}
+
+	if data.session != nil {
+		b.sessions = append(b.sessions, *data.session)
+	}
+
+	if data.pageView != nil {
+		b.pageViews = append(b.pageViews, *data.pageView)
+	}
+
+	if data.event != nil {
+		b.events = append(b.events, *data.event)
+	}
+
+	if data.ua != nil {
+		b.userAgents = append(b.userAgents, *data.ua)
+	}
+
+	if data.bot != nil {
+		b.bots = append(b.bots, *data.bot)
+	}
+}
+
+func (b *batch) full() bool {
+	bufferSize := b.tracker.config.WorkerBufferSize
+	return len(b.sessions)+2 >= bufferSize*2 ||
+		len(b.pageViews)+1 >= bufferSize ||
+		len(b.events)+1 >= bufferSize ||
+		len(b.userAgents)+1 >= bufferSize ||
+		len(b.bots)+1 >= bufferSize
+}
+
+func (b *batch) save() {
+	b.tracker.saveSessions(b.sessions)
+	b.tracker.savePageViews(b.pageViews)
+	b.tracker.saveEvents(b.events)
+	b.tracker.saveUserAgents(b.userAgents)
+	b.tracker.saveBots(b.bots)
+	b.sessions = b.sessions[:0]
+	b.pageViews = b.pageViews[:0]
+	b.events = b.events[:0]
+	b.userAgents = b.userAgents[:0]
+	b.bots = b.bots[:0]
+	b.tracker.buffered.Add(-b.items)
+	b.items = 0
+}
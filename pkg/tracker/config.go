@@ -33,9 +33,34 @@ type Config struct {
 	HeaderParser        []ip.HeaderParser
 	AllowedProxySubnets []net.IPNet
 	MaxPageViews        uint16
-	GeoDB               *geodb.GeoDB
 	IPFilter            ip.Filter
 	Logger              *slog.Logger
+
+	// TrustedProxies enables the right-most untrusted client IP resolution (see ip.GetUntrusted) for the HeaderParser if set.
+	// Use it if requests pass through multiple proxies, as clients can otherwise spoof the addresses of earlier hops.
+	// AllowedProxySubnets is ignored in this case.
+	TrustedProxies *ip.TrustedProxies
+
+	// GeoProvider looks up the location and network of visitors.
+	// Use geodb.GeoProviders to chain multiple providers, like location headers set by a CDN with a database as the fallback.
+	GeoProvider geodb.GeoProvider
+
+	// GeoDB is used as the GeoProvider if none is set.
+	//
+	// Deprecated: use GeoProvider instead.
+	GeoDB *geodb.GeoDB
+
+	// SessionSnapshotPath is the file the session cache is written to on Stop and restored from when the Tracker is created.
+	// This only has an effect if the SessionCache implements session.Snapshotter.
+	// The Salt and fingerprint keys must be set for restored sessions to match returning visitors.
+	SessionSnapshotPath string
+
+	// SessionSnapshotInterval additionally writes a snapshot periodically if set.
+	SessionSnapshotInterval time.Duration
+
+	// UserAgentCacheSize is the number of parsed User-Agents kept in memory.
+	// Defaults to 10,000.
+	UserAgentCacheSize int
 }
 
 func (config *Config) validate() {
@@ -69,6 +94,10 @@ func (config *Config) validate() {
 		config.SessionCache = session.NewMemCache(config.Store, 0)
 	}
 
+	if config.GeoProvider == nil && config.GeoDB != nil {
+		config.GeoProvider = config.GeoDB
+	}
+
 	if config.MaxPageViews == 0 {
 		config.MaxPageViews = defaultMaxPageViews
 	}
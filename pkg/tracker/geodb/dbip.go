@@ -0,0 +1,84 @@
+package geodb
+
+import (
+	"github.com/oschwald/maxminddb-golang"
+	"net"
+	"net/http"
+	"sync"
+)
+
+// DBIP maps IPs to their geological location and network based on the DB-IP databases in MMDB format.
+// The free IP to City Lite and IP to ASN Lite databases can be downloaded from https://db-ip.com/db/lite.php
+// and require attribution. The lite databases don't contain the region and time zone.
+type DBIP struct {
+	db    *maxminddb.Reader
+	asnDB *maxminddb.Reader
+	m     sync.RWMutex
+}
+
+// NewDBIP creates a new DBIP for given city and ASN database files.
+// Both paths are optional and the files can be gzip compressed, as they are distributed.
+func NewDBIP(path, asnPath string) (*DBIP, error) {
+	db := new(DBIP)
+
+	if path != "" {
+		if err := db.UpdateFromFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if asnPath != "" {
+		if err := db.UpdateASNFromFile(asnPath); err != nil {
+			return nil, err
+		}
+	}
+
+	return db, nil
+}
+
+// Lookup looks up the Location for given IP.
+// If the IP is invalid or not found it will return an empty Location.
+func (db *DBIP) Lookup(ip string) Location {
+	parsedIP := net.ParseIP(ip)
+
+	if parsedIP == nil {
+		return Location{}
+	}
+
+	db.m.RLock()
+	defer db.m.RUnlock()
+	return lookup(db.db, db.asnDB, parsedIP)
+}
+
+// Locate implements the GeoProvider interface.
+func (db *DBIP) Locate(_ *http.Request, ip string) Location {
+	return db.Lookup(ip)
+}
+
+// UpdateFromFile updates the city database from given file.
+func (db *DBIP) UpdateFromFile(path string) error {
+	cityDB, err := openMMDB(path)
+
+	if err != nil {
+		return err
+	}
+
+	db.m.Lock()
+	defer db.m.Unlock()
+	db.db = cityDB
+	return nil
+}
+
+// UpdateASNFromFile updates the ASN database from given file.
+func (db *DBIP) UpdateASNFromFile(path string) error {
+	asnDB, err := openMMDB(path)
+
+	if err != nil {
+		return err
+	}
+
+	db.m.Lock()
+	defer db.m.Unlock()
+	db.asnDB = asnDB
+	return nil
+}
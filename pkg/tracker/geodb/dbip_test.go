@@ -0,0 +1,39 @@
+package geodb
+
+import (
+	"compress/gzip"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDBIP_Lookup(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "dbip-city-lite.mmdb.gz")
+	f, err := os.Create(path)
+	assert.NoError(t, err)
+	w := gzip.NewWriter(f)
+	_, err = w.Write(writeMMDB("DBIP-City-Lite", []mmdbNetwork{
+		{"81.2.69.0/24", map[string]any{
+			"city":         map[string]any{"names": map[string]any{"en": "London"}},
+			"country":      map[string]any{"iso_code": "GB"},
+			"subdivisions": []any{map[string]any{"names": map[string]any{"en": "England"}}},
+		}},
+	}))
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+	assert.NoError(t, f.Close())
+	db, err := NewDBIP(path, asnTestDB)
+	assert.NoError(t, err)
+	assert.Equal(t, Location{
+		CountryCode:    "gb",
+		City:           "London",
+		ASN:            20712,
+		ASOrganization: "Andrews & Arnold Ltd",
+	}, db.Lookup("81.2.69.142"))
+	assert.Equal(t, Location{ASN: 24940, ASOrganization: "Hetzner Online GmbH"}, db.Lookup("5.9.10.20"))
+	assert.Equal(t, Location{}, db.Lookup("invalid"))
+	_, err = NewDBIP(filepath.Join(dir, "does-not-exist.mmdb"), "")
+	assert.Error(t, err)
+}
@@ -2,7 +2,6 @@ package geodb
 
 import (
 	"archive/tar"
-	"bytes"
 	"compress/gzip"
 	"fmt"
 	"github.com/oschwald/maxminddb-golang"
@@ -13,20 +12,46 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 )
 
 const (
-	geoLite2Permalink     = "https://download.maxmind.com/app/geoip_download?edition_id=GeoLite2-City&license_key=LICENSE_KEY&suffix=tar.gz"
+	geoLite2Permalink     = "https://download.maxmind.com/app/geoip_download?edition_id=EDITION&license_key=LICENSE_KEY&suffix=tar.gz"
+	geoLite2Edition       = "EDITION"
 	geoLite2LicenseKey    = "LICENSE_KEY"
-	geoLite2TarGzFilename = "GeoLite2-City.tar.gz"
-	geoLite2Filename      = "GeoLite2-City.mmdb"
+	geoLite2CityEdition   = "GeoLite2-City"
+	geoLite2ASNEdition    = "GeoLite2-ASN"
+	geoLite2TarGzFilename = geoLite2CityEdition + ".tar.gz"
 )
 
+// Location is the geological location and network for an IP address.
+type Location struct {
+	// CountryCode is the ISO 3166-1 country code in lowercase.
+	CountryCode string
+
+	// Region is the ISO 3166-2 code of the first subdivision in lowercase (like gb-eng).
+	Region string
+
+	// City is the English city name. The subdivision is appended for cities in the US.
+	City string
+
+	// TimeZone is the IANA time zone (like Europe/London).
+	TimeZone string
+
+	// ASN is the autonomous system number. It's only set if an ASN database has been loaded.
+	ASN uint32
+
+	// ASOrganization is the organization owning the autonomous system. It's only set if an ASN database has been loaded.
+	ASOrganization string
+}
+
 // GeoDB maps IPs to their geological location based on MaxMinds GeoLite2 or GeoIP2 database.
+// Optionally, the network is looked up from a GeoLite2 or GeoIP2 ASN database.
 type GeoDB struct {
 	licenseKey   string
 	downloadPath string
 	db           *maxminddb.Reader
+	asnDB        *maxminddb.Reader
 	m            sync.RWMutex
 }
 
@@ -46,64 +71,78 @@ func NewGeoDB(licenseKey, downloadPath string) (*GeoDB, error) {
 	return geoDB, nil
 }
 
-// GetLocation looks up the country code, subdivision (region), and city for given IP.
+// GetLocation looks up the country code and city for given IP.
 // If the IP is invalid it will return an empty string.
 // The country code is returned in lowercase.
 func (db *GeoDB) GetLocation(ip string) (string, string) {
+	location := db.Lookup(ip)
+	return location.CountryCode, location.City
+}
+
+// Lookup looks up the Location for given IP.
+// If the IP is invalid or not found it will return an empty Location.
+func (db *GeoDB) Lookup(ip string) Location {
 	parsedIP := net.ParseIP(ip)
 
 	if parsedIP == nil {
-		return "", ""
-	}
-
-	record := struct {
-		Country struct {
-			ISOCode string `maxminddb:"iso_code"`
-		} `maxminddb:"country"`
-		Subdivisions []struct {
-			ISOCode string `maxminddb:"iso_code"`
-		} `maxminddb:"subdivisions"`
-		City struct {
-			Names struct {
-				En string `maxminddb:"en"`
-			} `maxminddb:"names"`
-		} `maxminddb:"city"`
-	}{}
+		return Location{}
+	}
 
 	db.m.RLock()
 	defer db.m.RUnlock()
+	return lookup(db.db, db.asnDB, parsedIP)
+}
 
-	if err := db.db.Lookup(parsedIP, &record); err != nil {
-		return "", ""
-	}
+// Locate implements the GeoProvider interface.
+func (db *GeoDB) Locate(_ *http.Request, ip string) Location {
+	return db.Lookup(ip)
+}
 
-	if record.Country.ISOCode == "US" && len(record.Subdivisions) > 0 && record.Subdivisions[0].ISOCode != "" {
-		record.City.Names.En += fmt.Sprintf(" (%s)", record.Subdivisions[0].ISOCode)
-	}
+// BuildDate returns the build date of the loaded location database or zero if none is loaded.
+func (db *GeoDB) BuildDate() time.Time {
+	db.m.RLock()
+	defer db.m.RUnlock()
+	return buildDate(db.db)
+}
 
-	return strings.ToLower(record.Country.ISOCode), record.City.Names.En
+// ASNBuildDate returns the build date of the loaded ASN database or zero if none is loaded.
+func (db *GeoDB) ASNBuildDate() time.Time {
+	db.m.RLock()
+	defer db.m.RUnlock()
+	return buildDate(db.asnDB)
 }
 
 // Update downloads and unpacks the MaxMind GeoLite2 database.
 func (db *GeoDB) Update() error {
-	if err := db.download(); err != nil {
-		return err
-	}
+	geoDB, err := db.downloadAndUnpack(geoLite2CityEdition)
 
-	if err := db.unpackAndUpdate(); err != nil {
+	if err != nil {
 		return err
 	}
 
-	if err := os.Remove(filepath.Join(db.downloadPath, geoLite2TarGzFilename)); err != nil {
+	db.m.Lock()
+	defer db.m.Unlock()
+	db.db = geoDB
+	return nil
+}
+
+// UpdateASN downloads and unpacks the MaxMind GeoLite2 ASN database.
+func (db *GeoDB) UpdateASN() error {
+	asnDB, err := db.downloadAndUnpack(geoLite2ASNEdition)
+
+	if err != nil {
 		return err
 	}
 
+	db.m.Lock()
+	defer db.m.Unlock()
+	db.asnDB = asnDB
 	return nil
 }
 
 // UpdateFromFile updates GeoDB from given file instead of downloading the database.
 func (db *GeoDB) UpdateFromFile(path string) error {
-	data, err := os.ReadFile(path)
+	geoDB, err := openMMDB(path)
 
 	if err != nil {
 		return err
@@ -111,84 +150,127 @@ func (db *GeoDB) UpdateFromFile(path string) error {
 
 	db.m.Lock()
 	defer db.m.Unlock()
-	geoDB, err := maxminddb.FromBytes(data)
+	db.db = geoDB
+	return nil
+}
+
+// UpdateASNFromFile updates the ASN database from given file instead of downloading it.
+func (db *GeoDB) UpdateASNFromFile(path string) error {
+	asnDB, err := openMMDB(path)
 
 	if err != nil {
 		return err
 	}
 
-	db.db = geoDB
+	db.m.Lock()
+	defer db.m.Unlock()
+	db.asnDB = asnDB
 	return nil
 }
 
-func (db *GeoDB) download() error {
+// swap replaces the location or ASN database and returns the previous one.
+func (db *GeoDB) swap(asn bool, reader *maxminddb.Reader) *maxminddb.Reader {
+	db.m.Lock()
+	defer db.m.Unlock()
+	var previous *maxminddb.Reader
+
+	if asn {
+		previous, db.asnDB = db.asnDB, reader
+	} else {
+		previous, db.db = db.db, reader
+	}
+
+	return previous
+}
+
+func (db *GeoDB) downloadAndUnpack(edition string) (*maxminddb.Reader, error) {
+	if err := db.download(edition); err != nil {
+		return nil, err
+	}
+
+	reader, err := db.unpack(edition)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.Remove(filepath.Join(db.downloadPath, edition+".tar.gz")); err != nil {
+		return nil, err
+	}
+
+	return reader, nil
+}
+
+func (db *GeoDB) download(edition string) error {
 	if err := os.MkdirAll(db.downloadPath, 0755); err != nil {
 		return err
 	}
 
-	resp, err := http.Get(strings.Replace(geoLite2Permalink, geoLite2LicenseKey, db.licenseKey, 1))
+	u := strings.Replace(geoLite2Permalink, geoLite2Edition, edition, 1)
+	resp, err := http.Get(strings.Replace(u, geoLite2LicenseKey, db.licenseKey, 1))
 
 	if err != nil {
 		return err
 	}
 
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error downloading %s: %s", edition, resp.Status)
+	}
+
 	tarGz, err := io.ReadAll(resp.Body)
 
 	if err != nil {
 		return err
 	}
 
-	if err := os.WriteFile(filepath.Join(db.downloadPath, geoLite2TarGzFilename), tarGz, 0755); err != nil {
+	if err := os.WriteFile(filepath.Join(db.downloadPath, edition+".tar.gz"), tarGz, 0755); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (db *GeoDB) unpackAndUpdate() error {
-	file, err := os.Open(filepath.Join(db.downloadPath, geoLite2TarGzFilename))
+func (db *GeoDB) unpack(edition string) (*maxminddb.Reader, error) {
+	file, err := os.Open(filepath.Join(db.downloadPath, edition+".tar.gz"))
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	defer file.Close()
-	gzipFile, err := gzip.NewReader(file)
+	data, err := unpackMMDB(file, edition)
 
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	return maxminddb.FromBytes(data)
+}
+
+// unpackMMDB extracts the .mmdb file for given edition from a MaxMind tar.gz archive.
+func unpackMMDB(in io.Reader, edition string) ([]byte, error) {
+	gzipFile, err := gzip.NewReader(in)
+
+	if err != nil {
+		return nil, err
 	}
 
 	defer gzipFile.Close()
 	r := tar.NewReader(gzipFile)
-	var out bytes.Buffer
 
 	for {
 		header, err := r.Next()
 
 		if err == io.EOF {
-			break
+			return nil, fmt.Errorf("%s.mmdb not found in archive", edition)
 		} else if err != nil {
-			return err
+			return nil, err
 		}
 
-		if filepath.Base(header.Name) == geoLite2Filename {
-			if _, err := io.Copy(&out, r); err != nil {
-				return err
-			}
-
-			break
+		if filepath.Base(header.Name) == edition+".mmdb" {
+			return io.ReadAll(r)
 		}
 	}
-
-	db.m.Lock()
-	defer db.m.Unlock()
-	geoDB, err := maxminddb.FromBytes(out.Bytes())
-
-	if err != nil {
-		return err
-	}
-
-	db.db = geoDB
-	return nil
 }
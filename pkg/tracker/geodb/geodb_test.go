@@ -29,3 +29,49 @@ func TestGeoDB_GetLocation(t *testing.T) {
 	assert.Equal(t, "gb", countryCode)
 	assert.Equal(t, "London", city)
 }
+
+func TestGeoDB_Lookup(t *testing.T) {
+	geoDB, _ := NewGeoDB("", "")
+	assert.Equal(t, Location{}, geoDB.Lookup("81.2.69.142"))
+	assert.NoError(t, geoDB.UpdateFromFile("../../../test/GeoIP2-City-Test.mmdb"))
+	assert.Equal(t, Location{
+		CountryCode: "gb",
+		Region:      "gb-eng",
+		City:        "London",
+		TimeZone:    "Europe/London",
+	}, geoDB.Lookup("81.2.69.142"))
+	assert.NoError(t, geoDB.UpdateASNFromFile(asnTestDB))
+	input := []string{
+		"81.2.69.142",
+		"216.160.83.56",
+		"89.160.20.112",
+		"67.43.156.1",
+		"5.9.10.20",
+		"2a01:4f8:1::1",
+		"invalid",
+	}
+	expected := []Location{
+		{"gb", "gb-eng", "London", "Europe/London", 20712, "Andrews & Arnold Ltd"},
+		{"us", "us-wa", "Milton (WA)", "America/Los_Angeles", 209, "CenturyLink Communications, LLC"},
+		{"se", "se-e", "Linköping", "Europe/Stockholm", 0, ""},
+		{"bt", "", "", "Asia/Thimphu", 0, ""},
+		{"", "", "", "", 24940, "Hetzner Online GmbH"},
+		{"", "", "", "", 24940, "Hetzner Online GmbH"},
+		{},
+	}
+
+	for i, in := range input {
+		assert.Equal(t, expected[i], geoDB.Lookup(in), in)
+	}
+}
+
+func TestGeoDB_UpdateASNFromFile(t *testing.T) {
+	geoDB, _ := NewGeoDB("", "")
+	assert.Error(t, geoDB.UpdateASNFromFile("../../../test/does-not-exist.mmdb"))
+	assert.NoError(t, geoDB.UpdateASNFromFile(asnTestDB))
+	location := geoDB.Lookup("5.9.10.20")
+	assert.Equal(t, uint32(24940), location.ASN)
+	countryCode, city := geoDB.GetLocation("5.9.10.20")
+	assert.Empty(t, countryCode)
+	assert.Empty(t, city)
+}
@@ -0,0 +1,131 @@
+package geodb
+
+import (
+	"github.com/pirsch-analytics/pirsch/v6/pkg/tracker/ip"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/util"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var (
+	// CloudflareHeaders are the location headers set by Cloudflare.
+	// The region, city, and time zone require the "Add visitor location headers" managed transform.
+	CloudflareHeaders = Headers{
+		Country:  "CF-IPCountry",
+		Region:   "CF-Region-Code",
+		City:     "CF-IPCity",
+		TimeZone: "CF-Timezone",
+	}
+
+	// CloudFrontHeaders are the location headers set by Amazon CloudFront.
+	CloudFrontHeaders = Headers{
+		Country:  "CloudFront-Viewer-Country",
+		Region:   "CloudFront-Viewer-Country-Region",
+		City:     "CloudFront-Viewer-City",
+		TimeZone: "CloudFront-Viewer-Time-Zone",
+	}
+
+	// VercelHeaders are the location headers set by Vercel.
+	VercelHeaders = Headers{
+		Country:  "X-Vercel-IP-Country",
+		Region:   "X-Vercel-IP-Country-Region",
+		City:     "X-Vercel-IP-City",
+		TimeZone: "X-Vercel-IP-Timezone",
+	}
+)
+
+// Headers are the names of the request headers a CDN or proxy uses to pass on the visitor's location.
+// All headers but the Country are optional.
+type Headers struct {
+	// Country is the ISO 3166-1 alpha-2 country code.
+	Country string
+
+	// Region is the ISO 3166-2 subdivision code, with or without the country code prefix (like eng or gb-eng).
+	Region string
+
+	// City is the city name. It may be URL encoded.
+	City string
+
+	// TimeZone is the IANA time zone.
+	TimeZone string
+}
+
+// HeaderProvider implements the GeoProvider interface for the location headers set by a CDN or proxy.
+// The headers are only trusted for requests from a trusted proxy, so that they cannot be set by visitors.
+type HeaderProvider struct {
+	headers Headers
+	trusted func(*http.Request) bool
+}
+
+// NewHeaderProvider creates a new HeaderProvider for given headers and allowed proxy subnets.
+// The subnets should usually be the same as the AllowedProxySubnets in the tracker configuration.
+// Unlike ip.Get, no headers are trusted if no subnets are configured.
+func NewHeaderProvider(headers Headers, allowed []net.IPNet) *HeaderProvider {
+	return &HeaderProvider{
+		headers: headers,
+		trusted: func(r *http.Request) bool {
+			return len(allowed) > 0 && ip.TrustedProxy(r, allowed)
+		},
+	}
+}
+
+// NewHeaderProviderWithTrustedProxies creates a new HeaderProvider for given headers and trusted proxies.
+// The trusted proxies should usually be the same as the TrustedProxies in the tracker configuration.
+func NewHeaderProviderWithTrustedProxies(headers Headers, trusted ip.TrustedProxies) *HeaderProvider {
+	return &HeaderProvider{
+		headers: headers,
+		trusted: trusted.TrustsRemoteAddr,
+	}
+}
+
+// Locate implements the GeoProvider interface.
+// The client IP address is not used.
+func (provider *HeaderProvider) Locate(r *http.Request, _ string) Location {
+	if provider.headers.Country == "" || !provider.trusted(r) {
+		return Location{}
+	}
+
+	countryCode := strings.ToLower(strings.TrimSpace(r.Header.Get(provider.headers.Country)))
+
+	// XX is used for unknown countries and T1 for the Tor network
+	if !isCountryCode(countryCode) || countryCode == "xx" || countryCode == "t1" {
+		return Location{}
+	}
+
+	location := Location{CountryCode: countryCode}
+	subdivision := provider.header(r, provider.headers.Region, 10)
+
+	if subdivision != "" {
+		if prefix, code, found := strings.Cut(subdivision, "-"); found && strings.EqualFold(prefix, countryCode) {
+			subdivision = code
+		}
+
+		location.Region = strings.ToLower(countryCode + "-" + subdivision)
+	}
+
+	location.City = cityName(countryCode, subdivision, provider.header(r, provider.headers.City, 200))
+	location.TimeZone = provider.header(r, provider.headers.TimeZone, 100)
+	return location
+}
+
+func (provider *HeaderProvider) header(r *http.Request, header string, maxLen int) string {
+	if header == "" {
+		return ""
+	}
+
+	value := strings.TrimSpace(r.Header.Get(header))
+
+	if strings.Contains(value, "%") {
+		if unescaped, err := url.QueryUnescape(value); err == nil {
+			value = unescaped
+		}
+	}
+
+	return util.ShortenString(value, maxLen)
+}
+
+func isCountryCode(code string) bool {
+	return len(code) == 2 && code[0] >= 'a' && code[0] <= 'z' && code[1] >= 'a' && code[1] <= 'z'
+}
@@ -0,0 +1,79 @@
+package geodb
+
+import (
+	"github.com/pirsch-analytics/pirsch/v6/pkg/tracker/ip"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHeaderProvider_Locate(t *testing.T) {
+	_, subnet, _ := net.ParseCIDR("10.0.0.0/8")
+	provider := NewHeaderProvider(CloudflareHeaders, []net.IPNet{*subnet})
+	input := []map[string]string{
+		{},
+		{"CF-IPCountry": "DE"},
+		{"CF-IPCountry": "US", "CF-Region-Code": "WA", "CF-IPCity": "Milton", "CF-Timezone": "America/Los_Angeles"},
+		{"CF-IPCountry": "gb", "CF-Region-Code": "GB-ENG", "CF-IPCity": "London"},
+		{"CF-IPCountry": "JP", "CF-IPCity": "T%C5%8Dky%C5%8D"},
+		{"CF-IPCountry": "XX", "CF-IPCity": "Berlin"},
+		{"CF-IPCountry": "T1"},
+		{"CF-IPCountry": "invalid"},
+	}
+	expected := []Location{
+		{},
+		{CountryCode: "de"},
+		{CountryCode: "us", Region: "us-wa", City: "Milton (WA)", TimeZone: "America/Los_Angeles"},
+		{CountryCode: "gb", Region: "gb-eng", City: "London"},
+		{CountryCode: "jp", City: "Tōkyō"},
+		{},
+		{},
+		{},
+	}
+
+	for i, headers := range input {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = "10.1.2.3:12345"
+
+		for k, v := range headers {
+			r.Header.Set(k, v)
+		}
+
+		assert.Equal(t, expected[i], provider.Locate(r, ""), i)
+	}
+}
+
+func TestHeaderProvider_LocateTrustedProxy(t *testing.T) {
+	_, subnet, _ := net.ParseCIDR("10.0.0.0/8")
+	provider := NewHeaderProvider(CloudFrontHeaders, []net.IPNet{*subnet})
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("CloudFront-Viewer-Country", "DE")
+	r.Header.Set("CloudFront-Viewer-Country-Region", "BE")
+	r.RemoteAddr = "81.2.69.142:12345"
+	assert.Equal(t, Location{}, provider.Locate(r, "81.2.69.142"))
+	r.RemoteAddr = "10.1.2.3:12345"
+	assert.Equal(t, Location{CountryCode: "de", Region: "de-be"}, provider.Locate(r, "81.2.69.142"))
+}
+
+func TestHeaderProvider_LocateTrustedProxies(t *testing.T) {
+	_, subnet, _ := net.ParseCIDR("10.0.0.0/8")
+	provider := NewHeaderProviderWithTrustedProxies(CloudflareHeaders, ip.TrustedProxies{Subnets: []net.IPNet{*subnet}})
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("CF-IPCountry", "DE")
+	r.RemoteAddr = "81.2.69.142:12345"
+	assert.Equal(t, Location{}, provider.Locate(r, "81.2.69.142"))
+	r.RemoteAddr = "10.1.2.3:12345"
+	assert.Equal(t, Location{CountryCode: "de"}, provider.Locate(r, "81.2.69.142"))
+	provider = NewHeaderProviderWithTrustedProxies(CloudflareHeaders, ip.TrustedProxies{Hops: 1})
+	r.RemoteAddr = "81.2.69.142:12345"
+	assert.Equal(t, Location{CountryCode: "de"}, provider.Locate(r, "81.2.69.142"))
+}
+
+func TestHeaderProvider_LocateSpoofed(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("CF-IPCountry", "DE")
+	r.RemoteAddr = "81.2.69.142:12345"
+	assert.Equal(t, Location{}, NewHeaderProvider(CloudflareHeaders, nil).Locate(r, "81.2.69.142"))
+	assert.Equal(t, Location{}, NewHeaderProviderWithTrustedProxies(CloudflareHeaders, ip.TrustedProxies{}).Locate(r, "81.2.69.142"))
+}
@@ -0,0 +1,108 @@
+package geodb
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"github.com/oschwald/maxminddb-golang"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// cityRecord is the location record of MaxMind and DB-IP city databases.
+type cityRecord struct {
+	Country struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+	Subdivisions []struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"subdivisions"`
+	City struct {
+		Names struct {
+			En string `maxminddb:"en"`
+		} `maxminddb:"names"`
+	} `maxminddb:"city"`
+	Location struct {
+		TimeZone string `maxminddb:"time_zone"`
+	} `maxminddb:"location"`
+}
+
+// asnRecord is the network record of MaxMind and DB-IP ASN databases.
+type asnRecord struct {
+	ASN            uint32 `maxminddb:"autonomous_system_number"`
+	ASOrganization string `maxminddb:"autonomous_system_organization"`
+}
+
+// lookup looks up the Location for given IP in the city and ASN databases.
+// Both databases are optional.
+func lookup(cityDB, asnDB *maxminddb.Reader, ip net.IP) Location {
+	var location Location
+	var city cityRecord
+	var asn asnRecord
+
+	if cityDB != nil && cityDB.Lookup(ip, &city) == nil {
+		location.CountryCode = strings.ToLower(city.Country.ISOCode)
+		location.City = city.City.Names.En
+		location.TimeZone = city.Location.TimeZone
+
+		if city.Country.ISOCode != "" && len(city.Subdivisions) > 0 && city.Subdivisions[0].ISOCode != "" {
+			location.Region = strings.ToLower(city.Country.ISOCode + "-" + city.Subdivisions[0].ISOCode)
+			location.City = cityName(location.CountryCode, city.Subdivisions[0].ISOCode, location.City)
+		}
+	}
+
+	if asnDB != nil && asnDB.Lookup(ip, &asn) == nil {
+		location.ASN = asn.ASN
+		location.ASOrganization = asn.ASOrganization
+	}
+
+	return location
+}
+
+// cityName appends the subdivision to cities in the US, as many of them share the same name.
+func cityName(countryCode, subdivision, city string) string {
+	if city != "" && countryCode == "us" && subdivision != "" {
+		return fmt.Sprintf("%s (%s)", city, strings.ToUpper(subdivision))
+	}
+
+	return city
+}
+
+// buildDate returns the build date of given database or zero if it is nil.
+func buildDate(reader *maxminddb.Reader) time.Time {
+	if reader == nil {
+		return time.Time{}
+	}
+
+	return time.Unix(int64(reader.Metadata.BuildEpoch), 0).UTC()
+}
+
+// openMMDB reads the database from given file into memory.
+// The file can optionally be compressed using gzip.
+func openMMDB(path string) (*maxminddb.Reader, error) {
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		r, err := gzip.NewReader(bytes.NewReader(data))
+
+		if err != nil {
+			return nil, err
+		}
+
+		defer r.Close()
+		data, err = io.ReadAll(r)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return maxminddb.FromBytes(data)
+}
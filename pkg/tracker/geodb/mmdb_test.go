@@ -0,0 +1,206 @@
+package geodb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"flag"
+	"net/netip"
+	"os"
+	"sort"
+	"testing"
+)
+
+// update regenerates the .mmdb fixtures in the test directory.
+// Run go test ./pkg/tracker/geodb -run TestFixtures -update from the root directory.
+var update = flag.Bool("update", false, "update the .mmdb test fixtures")
+
+const asnTestDB = "../../../test/GeoLite2-ASN-Test.mmdb"
+
+// mmdbNetwork is a network and the record stored for it in a test database.
+type mmdbNetwork struct {
+	prefix string
+	record map[string]any
+}
+
+var asnTestNetworks = []mmdbNetwork{
+	{"5.9.0.0/16", map[string]any{"autonomous_system_number": uint32(24940), "autonomous_system_organization": "Hetzner Online GmbH"}},
+	{"81.2.69.0/24", map[string]any{"autonomous_system_number": uint32(20712), "autonomous_system_organization": "Andrews & Arnold Ltd"}},
+	{"216.160.83.0/24", map[string]any{"autonomous_system_number": uint32(209), "autonomous_system_organization": "CenturyLink Communications, LLC"}},
+	{"2a01:4f8::/32", map[string]any{"autonomous_system_number": uint32(24940), "autonomous_system_organization": "Hetzner Online GmbH"}},
+}
+
+func TestFixtures(t *testing.T) {
+	if *update {
+		data := writeMMDB("GeoLite2-ASN", asnTestNetworks)
+
+		if err := os.WriteFile(asnTestDB, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := os.ReadFile(asnTestDB)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(data, writeMMDB("GeoLite2-ASN", asnTestNetworks)) {
+		t.Fatal("test fixture is out of date, run the test with -update")
+	}
+}
+
+var metadataStart = []byte("\xab\xcd\xefMaxMind.com")
+
+// writeMMDB creates a MaxMind DB for given networks with a fixed build date, so that the output is reproducible.
+func writeMMDB(databaseType string, networks []mmdbNetwork) []byte {
+	return writeMMDBBuild(databaseType, networks, 1704067200) // 2024-01-01
+}
+
+// writeMMDBBuild creates a MaxMind DB for given networks and build date (Unix timestamp).
+// The networks must not overlap. IPv4 networks are stored in the IPv4-mapped IPv6 subtree (::/96).
+func writeMMDBBuild(databaseType string, networks []mmdbNetwork, buildEpoch uint64) []byte {
+	type node struct {
+		children [2]int // node index, or -1 for no data, or -(2+data index) for data
+	}
+
+	nodes := []node{{[2]int{-1, -1}}}
+	var data bytes.Buffer
+	dataOffsets := make([]int, 0, len(networks))
+
+	for i, network := range networks {
+		prefix := netip.MustParsePrefix(network.prefix)
+		addr := prefix.Addr().As16()
+		bits := prefix.Bits()
+
+		if prefix.Addr().Is4() {
+			// IPv4 addresses are looked up in ::/96 and not in the IPv4-mapped ::ffff:0:0/96
+			addr = [16]byte{}
+			copy(addr[12:], prefix.Addr().AsSlice())
+			bits += 96
+		}
+
+		dataOffsets = append(dataOffsets, data.Len())
+		encodeMMDBValue(&data, network.record)
+		current := 0
+
+		for bit := 0; bit < bits; bit++ {
+			b := (addr[bit/8] >> (7 - bit%8)) & 1
+
+			if bit == bits-1 {
+				nodes[current].children[b] = -(2 + i)
+			} else {
+				if nodes[current].children[b] < 0 {
+					nodes = append(nodes, node{[2]int{-1, -1}})
+					nodes[current].children[b] = len(nodes) - 1
+				}
+
+				current = nodes[current].children[b]
+			}
+		}
+	}
+
+	var out bytes.Buffer
+	nodeCount := len(nodes)
+
+	for _, n := range nodes {
+		for _, child := range n.children {
+			record := nodeCount
+
+			if child >= 0 {
+				record = child
+			} else if child < -1 {
+				record = nodeCount + 16 + dataOffsets[-child-2]
+			}
+
+			out.Write([]byte{byte(record >> 16), byte(record >> 8), byte(record)})
+		}
+	}
+
+	out.Write(make([]byte, 16))
+	out.Write(data.Bytes())
+	out.Write(metadataStart)
+	encodeMMDBValue(&out, map[string]any{
+		"binary_format_major_version": uint16(2),
+		"binary_format_minor_version": uint16(0),
+		"build_epoch":                 buildEpoch,
+		"database_type":               databaseType,
+		"description":                 map[string]any{"en": "Test database generated by pirsch"},
+		"ip_version":                  uint16(6),
+		"languages":                   []any{"en"},
+		"node_count":                  uint32(nodeCount),
+		"record_size":                 uint16(24),
+	})
+	return out.Bytes()
+}
+
+func encodeMMDBValue(out *bytes.Buffer, value any) {
+	switch v := value.(type) {
+	case string:
+		writeMMDBControl(out, 2, len(v))
+		out.WriteString(v)
+	case uint16:
+		writeMMDBUint(out, 5, uint64(v))
+	case uint32:
+		writeMMDBUint(out, 6, uint64(v))
+	case uint64:
+		writeMMDBUint(out, 9, v)
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+
+		for key := range v {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+		writeMMDBControl(out, 7, len(v))
+
+		for _, key := range keys {
+			encodeMMDBValue(out, key)
+			encodeMMDBValue(out, v[key])
+		}
+	case []any:
+		writeMMDBControl(out, 11, len(v))
+
+		for _, item := range v {
+			encodeMMDBValue(out, item)
+		}
+	default:
+		panic("unsupported type")
+	}
+}
+
+func writeMMDBUint(out *bytes.Buffer, dataType int, v uint64) {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	b = bytes.TrimLeft(b, "\x00")
+	writeMMDBControl(out, dataType, len(b))
+	out.Write(b)
+}
+
+func writeMMDBControl(out *bytes.Buffer, dataType, size int) {
+	var control byte
+	var extended []byte
+
+	if dataType > 7 {
+		extended = []byte{byte(dataType - 7)}
+	} else {
+		control = byte(dataType << 5)
+	}
+
+	var sizeBytes []byte
+
+	switch {
+	case size < 29:
+		control |= byte(size)
+	case size < 285:
+		control |= 29
+		sizeBytes = []byte{byte(size - 29)}
+	default:
+		control |= 30
+		sizeBytes = []byte{byte((size - 285) >> 8), byte(size - 285)}
+	}
+
+	out.WriteByte(control)
+	out.Write(extended)
+	out.Write(sizeBytes)
+}
@@ -0,0 +1,44 @@
+package geodb
+
+import (
+	"net/http"
+)
+
+// GeoProvider looks up the Location of a visitor.
+type GeoProvider interface {
+	// Locate returns the Location for given request and client IP address.
+	// An empty Location is returned if it is unknown.
+	Locate(r *http.Request, ip string) Location
+}
+
+// GeoProviders is a chain of GeoProviders, which are asked in order.
+// The location is taken from the first provider returning a country and the network from the first provider returning an ASN,
+// so that a GeoDB can be used as a fallback for a HeaderProvider, or to add the network to the location set by a proxy.
+type GeoProviders []GeoProvider
+
+// Locate implements the GeoProvider interface.
+func (providers GeoProviders) Locate(r *http.Request, ip string) Location {
+	var location Location
+
+	for _, provider := range providers {
+		l := provider.Locate(r, ip)
+
+		if location.CountryCode == "" && l.CountryCode != "" {
+			location.CountryCode = l.CountryCode
+			location.Region = l.Region
+			location.City = l.City
+			location.TimeZone = l.TimeZone
+		}
+
+		if location.ASN == 0 && l.ASN != 0 {
+			location.ASN = l.ASN
+			location.ASOrganization = l.ASOrganization
+		}
+
+		if location.CountryCode != "" && location.ASN != 0 {
+			break
+		}
+	}
+
+	return location
+}
@@ -0,0 +1,39 @@
+package geodb
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGeoProviders_Locate(t *testing.T) {
+	geoDB, _ := NewGeoDB("", "")
+	assert.NoError(t, geoDB.UpdateFromFile("../../../test/GeoIP2-City-Test.mmdb"))
+	assert.NoError(t, geoDB.UpdateASNFromFile(asnTestDB))
+	_, subnet, _ := net.ParseCIDR("10.0.0.0/8")
+	providers := GeoProviders{
+		NewHeaderProvider(CloudflareHeaders, []net.IPNet{*subnet}),
+		geoDB,
+	}
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "10.1.2.3:12345"
+	assert.Equal(t, Location{
+		CountryCode:    "gb",
+		Region:         "gb-eng",
+		City:           "London",
+		TimeZone:       "Europe/London",
+		ASN:            20712,
+		ASOrganization: "Andrews & Arnold Ltd",
+	}, providers.Locate(r, "81.2.69.142"))
+	r.Header.Set("CF-IPCountry", "DE")
+	r.Header.Set("CF-IPCity", "Berlin")
+	assert.Equal(t, Location{
+		CountryCode:    "de",
+		City:           "Berlin",
+		ASN:            20712,
+		ASOrganization: "Andrews & Arnold Ltd",
+	}, providers.Locate(r, "81.2.69.142"))
+	assert.Equal(t, Location{CountryCode: "de", City: "Berlin"}, providers.Locate(r, "invalid"))
+	assert.Equal(t, Location{}, GeoProviders{}.Locate(r, "81.2.69.142"))
+}
@@ -0,0 +1,482 @@
+package geodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/oschwald/maxminddb-golang"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/util"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultUpdaterWatchInterval = time.Minute
+	defaultUpdaterTimeout       = time.Minute * 5
+	previousSuffix              = ".previous"
+)
+
+var errOutdatedDatabase = errors.New("older than the loaded database")
+
+// UpdaterConfig is the configuration for the Updater.
+type UpdaterConfig struct {
+	// GeoDB is the database kept up to date. A new GeoDB is created if it's not set.
+	GeoDB *GeoDB
+
+	// LicenseKey is the MaxMind license key. Databases are only downloaded if it's set.
+	LicenseKey string
+
+	// Path is the directory the active and previous databases are stored in.
+	Path string
+
+	// Edition is the location database edition. Defaults to GeoLite2-City.
+	Edition string
+
+	// ASNEdition is the ASN database edition. The ASN database is only updated if it's set (like GeoLite2-ASN).
+	ASNEdition string
+
+	// DownloadURL is the URL the tar.gz archives are downloaded from. Defaults to the MaxMind permalink.
+	// EDITION and LICENSE_KEY are replaced by the edition and license key.
+	DownloadURL string
+
+	// Interval is the interval in which the databases are downloaded. Defaults to daily at midnight (UTC).
+	Interval time.Duration
+
+	// Timeout is the timeout for a single download. Defaults to 5 minutes.
+	Timeout time.Duration
+
+	// WatchPath is an optional directory watched for .mmdb files dropped by an external tool, like geoipupdate.
+	// New files are verified and installed the same way as downloaded databases. It must not be the same as Path.
+	WatchPath string
+
+	// WatchInterval is the interval in which the WatchPath is checked for new files. Defaults to one minute.
+	WatchInterval time.Duration
+
+	// Logger is the log/slog.Logger used to log errors. Defaults to slog.Default.
+	Logger *slog.Logger
+}
+
+func (config *UpdaterConfig) validate() error {
+	if config.Path == "" {
+		return errors.New("path must be set")
+	}
+
+	if config.WatchPath != "" && filepath.Clean(config.WatchPath) == filepath.Clean(config.Path) {
+		return errors.New("watch path must not be the same as path")
+	}
+
+	if config.GeoDB == nil {
+		config.GeoDB, _ = NewGeoDB("", "")
+	}
+
+	if config.Edition == "" {
+		config.Edition = geoLite2CityEdition
+	}
+
+	if config.DownloadURL == "" {
+		config.DownloadURL = geoLite2Permalink
+	}
+
+	if config.Timeout <= 0 {
+		config.Timeout = defaultUpdaterTimeout
+	}
+
+	if config.WatchInterval <= 0 {
+		config.WatchInterval = defaultUpdaterWatchInterval
+	}
+
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+
+	return nil
+}
+
+// Updater keeps a GeoDB up to date by downloading new databases on a schedule and installing files dropped into a watched directory.
+// New databases are verified before they are swapped in without blocking lookups.
+// The previously active database is kept on disk, so that it can be restored using Rollback.
+// After a rollback, a database is only installed again if it has been built after the database that has been rolled back.
+type Updater struct {
+	config     UpdaterConfig
+	client     *http.Client
+	watched    map[string]time.Time
+	rolledBack map[string]uint
+	m          sync.Mutex
+	cancel     context.CancelFunc
+	midnight   context.CancelFunc
+	done       sync.WaitGroup
+}
+
+// NewUpdater creates a new Updater and starts updating the GeoDB in the background.
+// Databases installed in the path by a previous run are loaded first. Missing databases are downloaded right away.
+// Call Stop to stop the Updater.
+func NewUpdater(config UpdaterConfig) (*Updater, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(config.Path, 0755); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	updater := &Updater{
+		config:     config,
+		client:     &http.Client{Timeout: config.Timeout},
+		watched:    make(map[string]time.Time),
+		rolledBack: make(map[string]uint),
+		cancel:     cancel,
+	}
+
+	for _, db := range updater.databases() {
+		if err := updater.load(db); err != nil && !errors.Is(err, os.ErrNotExist) {
+			config.Logger.Error("error loading installed geo database", "edition", db.edition, "err", err)
+		}
+	}
+
+	if config.LicenseKey != "" {
+		for _, db := range updater.databases() {
+			if updater.current(db.asn) == nil {
+				if err := updater.download(db); err != nil {
+					cancel()
+					return nil, err
+				}
+			}
+		}
+
+		if config.Interval > 0 {
+			updater.done.Add(1)
+			go updater.run(ctx, config.Interval, updater.updateAndLog)
+		} else {
+			updater.midnight = util.RunAtMidnight(updater.updateAndLog)
+		}
+	}
+
+	if config.WatchPath != "" {
+		updater.watch()
+		updater.done.Add(1)
+		go updater.run(ctx, config.WatchInterval, updater.watch)
+	}
+
+	return updater, nil
+}
+
+// GeoDB returns the GeoDB kept up to date.
+func (updater *Updater) GeoDB() *GeoDB {
+	return updater.config.GeoDB
+}
+
+// Update downloads and installs the databases, if they are newer than the loaded ones.
+func (updater *Updater) Update() error {
+	var errs []error
+
+	for _, db := range updater.databases() {
+		errs = append(errs, updater.download(db))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Rollback restores the previous location database.
+func (updater *Updater) Rollback() error {
+	return updater.rollback(updaterDB{updater.config.Edition, false})
+}
+
+// RollbackASN restores the previous ASN database.
+func (updater *Updater) RollbackASN() error {
+	if updater.config.ASNEdition == "" {
+		return errors.New("no ASN edition configured")
+	}
+
+	return updater.rollback(updaterDB{updater.config.ASNEdition, true})
+}
+
+// Stop stops updating the GeoDB.
+func (updater *Updater) Stop() {
+	updater.cancel()
+
+	if updater.midnight != nil {
+		updater.midnight()
+	}
+
+	updater.done.Wait()
+}
+
+type updaterDB struct {
+	edition string
+	asn     bool
+}
+
+func (updater *Updater) databases() []updaterDB {
+	dbs := []updaterDB{{updater.config.Edition, false}}
+
+	if updater.config.ASNEdition != "" {
+		dbs = append(dbs, updaterDB{updater.config.ASNEdition, true})
+	}
+
+	return dbs
+}
+
+func (updater *Updater) run(ctx context.Context, interval time.Duration, f func()) {
+	defer updater.done.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			f()
+		}
+	}
+}
+
+func (updater *Updater) updateAndLog() {
+	if err := updater.Update(); err != nil {
+		updater.config.Logger.Error("error updating geo database", "err", err)
+	}
+}
+
+func (updater *Updater) current(asn bool) *maxminddb.Reader {
+	updater.config.GeoDB.m.RLock()
+	defer updater.config.GeoDB.m.RUnlock()
+
+	if asn {
+		return updater.config.GeoDB.asnDB
+	}
+
+	return updater.config.GeoDB.db
+}
+
+func (updater *Updater) path(db updaterDB) string {
+	return filepath.Join(updater.config.Path, db.edition+".mmdb")
+}
+
+func (updater *Updater) load(db updaterDB) error {
+	data, err := os.ReadFile(updater.path(db))
+
+	if err != nil {
+		return err
+	}
+
+	reader, err := verify(data, db.asn)
+
+	if err != nil {
+		return err
+	}
+
+	updater.config.GeoDB.swap(db.asn, reader)
+
+	// a previous database newer than the active one has been rolled back by a previous run
+	if previous, err := maxminddb.Open(updater.path(db) + previousSuffix); err == nil {
+		if previous.Metadata.BuildEpoch > reader.Metadata.BuildEpoch {
+			updater.rolledBack[db.edition] = previous.Metadata.BuildEpoch
+		}
+
+		_ = previous.Close()
+	}
+
+	return nil
+}
+
+func (updater *Updater) download(db updaterDB) error {
+	u := strings.Replace(updater.config.DownloadURL, geoLite2Edition, db.edition, 1)
+	u = strings.Replace(u, geoLite2LicenseKey, updater.config.LicenseKey, 1)
+	resp, err := updater.client.Get(u)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error downloading %s: %s", db.edition, resp.Status)
+	}
+
+	data, err := unpackMMDB(resp.Body, db.edition)
+
+	if err != nil {
+		return fmt.Errorf("error unpacking %s: %w", db.edition, err)
+	}
+
+	return updater.install(db, data)
+}
+
+// install verifies the database and swaps it in if it is newer than the loaded one and the database rolled back from, if any.
+// The active file is kept as the previous database.
+func (updater *Updater) install(db updaterDB, data []byte) error {
+	updater.m.Lock()
+	defer updater.m.Unlock()
+	reader, err := verify(data, db.asn)
+
+	if err != nil {
+		return fmt.Errorf("error verifying %s: %w", db.edition, err)
+	}
+
+	if current := updater.current(db.asn); current != nil {
+		if reader.Metadata.BuildEpoch == current.Metadata.BuildEpoch {
+			return nil
+		} else if reader.Metadata.BuildEpoch < current.Metadata.BuildEpoch {
+			return fmt.Errorf("%s built on %s is %w", db.edition, buildDate(reader).Format(time.DateOnly), errOutdatedDatabase)
+		}
+	}
+
+	if reader.Metadata.BuildEpoch <= updater.rolledBack[db.edition] {
+		return nil
+	}
+
+	path := updater.path(db)
+	tmp, err := os.CreateTemp(updater.config.Path, db.edition+".*.tmp")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		if err := os.Rename(path, path+previousSuffix); err != nil {
+			return err
+		}
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+
+	delete(updater.rolledBack, db.edition)
+	updater.config.GeoDB.swap(db.asn, reader)
+	updater.config.Logger.Info("installed geo database", "edition", db.edition, "build_date", buildDate(reader))
+	return nil
+}
+
+func (updater *Updater) rollback(db updaterDB) error {
+	updater.m.Lock()
+	defer updater.m.Unlock()
+	path := updater.path(db)
+	data, err := os.ReadFile(path + previousSuffix)
+
+	if err != nil {
+		return err
+	}
+
+	reader, err := verify(data, db.asn)
+
+	if err != nil {
+		return fmt.Errorf("error verifying previous %s: %w", db.edition, err)
+	}
+
+	// swap the files, so that the rollback can be undone
+	tmp := path + ".rollback"
+
+	if err := os.Rename(path, tmp); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if err := os.Rename(path+previousSuffix, path); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp, path+previousSuffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	// keep the rolled back database from being installed again, unless the rollback is undone
+	if current := updater.current(db.asn); current != nil && current.Metadata.BuildEpoch > reader.Metadata.BuildEpoch {
+		updater.rolledBack[db.edition] = current.Metadata.BuildEpoch
+	} else {
+		delete(updater.rolledBack, db.edition)
+	}
+
+	updater.config.GeoDB.swap(db.asn, reader)
+	updater.config.Logger.Info("rolled back geo database", "edition", db.edition, "build_date", buildDate(reader))
+	return nil
+}
+
+// watch installs new or changed .mmdb files from the watch path.
+// The database type is detected from the file metadata.
+// Files that cannot be installed are tried again on the next call, unless they are older than the loaded database.
+func (updater *Updater) watch() {
+	files, err := filepath.Glob(filepath.Join(updater.config.WatchPath, "*.mmdb"))
+
+	if err != nil {
+		updater.config.Logger.Error("error reading geo database watch path", "err", err)
+		return
+	}
+
+	for _, file := range files {
+		info, err := os.Stat(file)
+
+		if err != nil || info.IsDir() || updater.watched[file].Equal(info.ModTime()) {
+			continue
+		}
+
+		data, err := os.ReadFile(file)
+
+		if err != nil {
+			updater.config.Logger.Error("error reading geo database", "file", file, "err", err)
+			continue
+		}
+
+		db := updaterDB{updater.config.Edition, false}
+		reader, err := maxminddb.FromBytes(data)
+
+		if err == nil && isASN(reader.Metadata.DatabaseType) {
+			if updater.config.ASNEdition == "" {
+				updater.watched[file] = info.ModTime()
+				continue
+			}
+
+			db = updaterDB{updater.config.ASNEdition, true}
+		}
+
+		if err := updater.install(db, data); err != nil {
+			updater.config.Logger.Error("error installing geo database", "file", file, "err", err)
+
+			if !errors.Is(err, errOutdatedDatabase) {
+				continue
+			}
+		}
+
+		updater.watched[file] = info.ModTime()
+	}
+}
+
+// verify opens and verifies given database and checks whether it is a location or ASN database.
+func verify(data []byte, asn bool) (*maxminddb.Reader, error) {
+	reader, err := maxminddb.FromBytes(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := reader.Verify(); err != nil {
+		return nil, err
+	}
+
+	if isASN(reader.Metadata.DatabaseType) != asn {
+		return nil, fmt.Errorf("unexpected database type %s", reader.Metadata.DatabaseType)
+	}
+
+	return reader, nil
+}
+
+func isASN(databaseType string) bool {
+	return strings.Contains(databaseType, "ASN")
+}
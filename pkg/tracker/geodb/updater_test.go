@@ -0,0 +1,198 @@
+package geodb
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestUpdater(t *testing.T) {
+	cityDB, err := os.ReadFile("../../../test/GeoIP2-City-Test.mmdb")
+	assert.NoError(t, err)
+	asnV1 := writeMMDBBuild("GeoLite2-ASN", asnTestNetworks, 1704067200)
+	asnV2 := writeMMDBBuild("GeoLite2-ASN", []mmdbNetwork{
+		{"5.9.0.0/16", map[string]any{"autonomous_system_number": uint32(24940), "autonomous_system_organization": "Hetzner"}},
+	}, 1704153600)
+	var m sync.Mutex
+	files := map[string][]byte{
+		geoLite2CityEdition: cityDB,
+		geoLite2ASNEdition:  asnV1,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("key") != "key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		m.Lock()
+		defer m.Unlock()
+		edition := r.URL.Query().Get("edition")
+		_, _ = w.Write(tarGzMMDB(t, edition, files[edition]))
+	}))
+	defer server.Close()
+	setFile := func(edition string, data []byte) {
+		m.Lock()
+		defer m.Unlock()
+		files[edition] = data
+	}
+	dir := t.TempDir()
+	_, err = NewUpdater(UpdaterConfig{
+		LicenseKey:  "invalid",
+		Path:        dir,
+		DownloadURL: server.URL + "/?edition=EDITION&key=LICENSE_KEY",
+	})
+	assert.ErrorContains(t, err, "401")
+	updater, err := NewUpdater(UpdaterConfig{
+		LicenseKey:  "key",
+		Path:        dir,
+		ASNEdition:  geoLite2ASNEdition,
+		DownloadURL: server.URL + "/?edition=EDITION&key=LICENSE_KEY",
+	})
+	assert.NoError(t, err)
+	defer updater.Stop()
+	geoDB := updater.GeoDB()
+	assert.Equal(t, "London", geoDB.Lookup("81.2.69.142").City)
+	assert.Equal(t, "Hetzner Online GmbH", geoDB.Lookup("5.9.10.20").ASOrganization)
+	assert.Equal(t, time.Unix(1578435657, 0).UTC(), geoDB.BuildDate())
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), geoDB.ASNBuildDate())
+	assert.FileExists(t, filepath.Join(dir, "GeoLite2-City.mmdb"))
+	assert.FileExists(t, filepath.Join(dir, "GeoLite2-ASN.mmdb"))
+
+	// the same build is not installed again
+	assert.NoError(t, updater.Update())
+	assert.NoFileExists(t, filepath.Join(dir, "GeoLite2-ASN.mmdb.previous"))
+
+	setFile(geoLite2ASNEdition, asnV2)
+	assert.NoError(t, updater.Update())
+	assert.Equal(t, "Hetzner", geoDB.Lookup("5.9.10.20").ASOrganization)
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), geoDB.ASNBuildDate())
+	assert.FileExists(t, filepath.Join(dir, "GeoLite2-ASN.mmdb.previous"))
+	assert.NoError(t, updater.RollbackASN())
+	assert.Equal(t, "Hetzner Online GmbH", geoDB.Lookup("5.9.10.20").ASOrganization)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), geoDB.ASNBuildDate())
+
+	// the rolled back database is not installed again
+	assert.NoError(t, updater.Update())
+	assert.Equal(t, "Hetzner Online GmbH", geoDB.Lookup("5.9.10.20").ASOrganization)
+	assert.NoError(t, updater.RollbackASN())
+	assert.Equal(t, "Hetzner", geoDB.Lookup("5.9.10.20").ASOrganization)
+	assert.Error(t, updater.Rollback())
+
+	// invalid, older, and wrong databases are rejected
+	setFile(geoLite2ASNEdition, []byte("invalid"))
+	assert.Error(t, updater.Update())
+	setFile(geoLite2ASNEdition, asnV1)
+	assert.ErrorContains(t, updater.Update(), "older")
+	setFile(geoLite2ASNEdition, cityDB)
+	assert.ErrorContains(t, updater.Update(), "unexpected database type")
+	assert.Equal(t, "Hetzner", geoDB.Lookup("5.9.10.20").ASOrganization)
+	updater.Stop()
+
+	// installed databases are loaded on start
+	updater, err = NewUpdater(UpdaterConfig{
+		Path:       dir,
+		ASNEdition: geoLite2ASNEdition,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "London", updater.GeoDB().Lookup("81.2.69.142").City)
+	assert.Equal(t, "Hetzner", updater.GeoDB().Lookup("5.9.10.20").ASOrganization)
+	assert.NoError(t, updater.RollbackASN())
+	updater.Stop()
+
+	// the rollback is kept after a restart until a newer database has been built
+	setFile(geoLite2ASNEdition, asnV2)
+	updater, err = NewUpdater(UpdaterConfig{
+		LicenseKey:  "key",
+		Path:        dir,
+		ASNEdition:  geoLite2ASNEdition,
+		DownloadURL: server.URL + "/?edition=EDITION&key=LICENSE_KEY",
+	})
+	assert.NoError(t, err)
+	defer updater.Stop()
+	assert.Equal(t, "Hetzner Online GmbH", updater.GeoDB().Lookup("5.9.10.20").ASOrganization)
+	setFile(geoLite2ASNEdition, writeMMDBBuild("GeoLite2-ASN", []mmdbNetwork{
+		{"5.9.0.0/16", map[string]any{"autonomous_system_number": uint32(24940), "autonomous_system_organization": "Hetzner Online"}},
+	}, 1704240000))
+	assert.NoError(t, updater.Update())
+	assert.Equal(t, "Hetzner Online", updater.GeoDB().Lookup("5.9.10.20").ASOrganization)
+	assert.Equal(t, time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), updater.GeoDB().ASNBuildDate())
+}
+
+func TestUpdater_Watch(t *testing.T) {
+	dir := t.TempDir()
+	watchDir := t.TempDir()
+	asnFile := filepath.Join(watchDir, "GeoLite2-ASN.mmdb")
+	assert.NoError(t, os.WriteFile(asnFile, writeMMDBBuild("GeoLite2-ASN", asnTestNetworks, 1704067200), 0644))
+	updater, err := NewUpdater(UpdaterConfig{
+		Path:          dir,
+		ASNEdition:    geoLite2ASNEdition,
+		WatchPath:     watchDir,
+		WatchInterval: time.Millisecond * 10,
+	})
+	assert.NoError(t, err)
+	defer updater.Stop()
+	assert.Equal(t, "Hetzner Online GmbH", updater.GeoDB().Lookup("5.9.10.20").ASOrganization)
+	assert.Empty(t, updater.GeoDB().Lookup("81.2.69.142").City)
+	assert.NoError(t, os.WriteFile(asnFile, writeMMDBBuild("GeoLite2-ASN", []mmdbNetwork{
+		{"5.9.0.0/16", map[string]any{"autonomous_system_number": uint32(24940), "autonomous_system_organization": "Hetzner"}},
+	}, 1704153600), 0644))
+	assert.NoError(t, os.Chtimes(asnFile, time.Now(), time.Now().Add(time.Minute)))
+
+	// files failing to install are tried again, even if they have not been modified since
+	cityFile := filepath.Join(watchDir, "GeoIP2-City.mmdb")
+	modTime := time.Now().Add(time.Minute)
+	assert.NoError(t, os.WriteFile(cityFile, []byte("invalid"), 0644))
+	assert.NoError(t, os.Chtimes(cityFile, modTime, modTime))
+	time.Sleep(time.Millisecond * 50)
+	assert.Empty(t, updater.GeoDB().Lookup("81.2.69.142").City)
+	assert.NoError(t, copyFile("../../../test/GeoIP2-City-Test.mmdb", cityFile))
+	assert.NoError(t, os.Chtimes(cityFile, modTime, modTime))
+	assert.Eventually(t, func() bool {
+		location := updater.GeoDB().Lookup("5.9.10.20")
+		return location.ASOrganization == "Hetzner" && updater.GeoDB().Lookup("81.2.69.142").City == "London"
+	}, time.Second*5, time.Millisecond*10)
+	assert.FileExists(t, filepath.Join(dir, "GeoLite2-ASN.mmdb.previous"))
+	assert.FileExists(t, filepath.Join(dir, "GeoLite2-City.mmdb"))
+}
+
+func TestUpdaterConfig_validate(t *testing.T) {
+	_, err := NewUpdater(UpdaterConfig{})
+	assert.Error(t, err)
+	dir := t.TempDir()
+	_, err = NewUpdater(UpdaterConfig{Path: dir, WatchPath: dir + "/"})
+	assert.Error(t, err)
+}
+
+func tarGzMMDB(t *testing.T, edition string, data []byte) []byte {
+	var buffer bytes.Buffer
+	gz := gzip.NewWriter(&buffer)
+	w := tar.NewWriter(gz)
+	assert.NoError(t, w.WriteHeader(&tar.Header{
+		Name: edition + "_20240101/" + edition + ".mmdb",
+		Mode: 0644,
+		Size: int64(len(data)),
+	}))
+	_, err := w.Write(data)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+	assert.NoError(t, gz.Close())
+	return buffer.Bytes()
+}
+
+func copyFile(from, to string) error {
+	data, err := os.ReadFile(from)
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(to, data, 0644)
+}
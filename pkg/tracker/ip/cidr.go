@@ -0,0 +1,176 @@
+package ip
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// CIDRFilter implements the Filter interface for IP addresses, CIDR blocks, and IP address ranges.
+// They can be loaded from plain text files, like lists of datacenter ranges, office networks, or custom blocklists.
+// Every line contains a single IP address (10.0.0.1), a CIDR block (10.0.0.0/8), or a range (10.0.0.1-10.0.0.255),
+// for IPv4 or IPv6. Empty lines and everything following a # are ignored.
+// Lookups take logarithmic time and the list can be reloaded at any time without blocking lookups.
+type CIDRFilter struct {
+	ranges atomic.Pointer[ipSet]
+	files  []string
+	m      sync.Mutex
+}
+
+// NewCIDRFilter creates a new empty CIDRFilter.
+func NewCIDRFilter() *CIDRFilter {
+	filter := new(CIDRFilter)
+	filter.ranges.Store(newIPSet(nil))
+	return filter
+}
+
+// NewCIDRFilterFromFiles creates a new CIDRFilter and loads given files.
+func NewCIDRFilterFromFiles(paths ...string) (*CIDRFilter, error) {
+	filter := NewCIDRFilter()
+
+	if err := filter.LoadFiles(paths...); err != nil {
+		return nil, err
+	}
+
+	return filter, nil
+}
+
+// Update implements the Filter interface.
+// Invalid IP addresses and ranges are skipped.
+func (filter *CIDRFilter) Update(ipsV4, ipsV6 []string, rangesV4, rangesV6 []Range) {
+	filter.ranges.Store(newIPSet(toRanges(ipsV4, ipsV6, rangesV4, rangesV6)))
+}
+
+// Ignore implements the Filter interface.
+func (filter *CIDRFilter) Ignore(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+
+	if err != nil {
+		return true
+	}
+
+	return filter.ranges.Load().contains(addr)
+}
+
+// Len returns the number of distinct ranges in the filter, after overlapping and adjacent ranges have been merged.
+func (filter *CIDRFilter) Len() int {
+	return filter.ranges.Load().len()
+}
+
+// Load replaces the filter list with the entries read from given reader.
+// The filter is left unchanged if the input is invalid.
+func (filter *CIDRFilter) Load(r io.Reader) error {
+	ranges, err := parseRanges(r)
+
+	if err != nil {
+		return err
+	}
+
+	filter.ranges.Store(newIPSet(ranges))
+	return nil
+}
+
+// LoadFiles replaces the filter list with the entries of given files.
+// The filter is left unchanged if any file cannot be read or is invalid.
+// The paths are remembered for Reload.
+func (filter *CIDRFilter) LoadFiles(paths ...string) error {
+	filter.m.Lock()
+	defer filter.m.Unlock()
+	var ranges []ipRange
+
+	for _, path := range paths {
+		r, err := parseRangesFile(path)
+
+		if err != nil {
+			return err
+		}
+
+		ranges = append(ranges, r...)
+	}
+
+	filter.ranges.Store(newIPSet(ranges))
+	filter.files = paths
+	return nil
+}
+
+// Reload loads the files passed to LoadFiles again.
+func (filter *CIDRFilter) Reload() error {
+	filter.m.Lock()
+	files := filter.files
+	filter.m.Unlock()
+	return filter.LoadFiles(files...)
+}
+
+func parseRangesFile(path string) ([]ipRange, error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+	ranges, err := parseRanges(f)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return ranges, nil
+}
+
+func parseRanges(r io.Reader) ([]ipRange, error) {
+	var ranges []ipRange
+	scanner := bufio.NewScanner(r)
+	line := 0
+
+	for scanner.Scan() {
+		line++
+		entry, _, _ := strings.Cut(scanner.Text(), "#")
+		entry = strings.TrimSpace(entry)
+
+		if entry == "" {
+			continue
+		}
+
+		r, err := parseRange(entry)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid entry in line %d: %w", line, err)
+		}
+
+		ranges = append(ranges, r)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return ranges, nil
+}
+
+func toRanges(ipsV4, ipsV6 []string, rangesV4, rangesV6 []Range) []ipRange {
+	ranges := make([]ipRange, 0, len(ipsV4)+len(ipsV6)+len(rangesV4)+len(rangesV6))
+
+	for _, ips := range [][]string{ipsV4, ipsV6} {
+		for _, ip := range ips {
+			if r, err := parseRange(ip); err == nil {
+				ranges = append(ranges, r)
+			}
+		}
+	}
+
+	for _, rs := range [][]Range{rangesV4, rangesV6} {
+		for _, rng := range rs {
+			if r, err := newRange(rng.From, rng.To); err == nil {
+				ranges = append(ranges, r)
+			}
+		}
+	}
+
+	return ranges
+}
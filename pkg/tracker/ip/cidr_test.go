@@ -0,0 +1,178 @@
+package ip
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testCIDRList = `# office
+10.0.0.0/8
+192.168.1.1 # single address
+172.16.0.10 - 172.16.0.20
+::ffff:100.64.0.0/106
+
+2001:db8::/32
+2a00:1450:4001::1-2a00:1450:4001::ff
+`
+
+func TestCIDRFilter(t *testing.T) {
+	filter := NewCIDRFilter()
+	assert.False(t, filter.Ignore("10.0.0.1"))
+	assert.True(t, filter.Ignore("invalid"))
+	assert.NoError(t, filter.Load(strings.NewReader(testCIDRList)))
+	assert.Equal(t, 6, filter.Len())
+	input := []string{
+		"10.0.0.0",
+		"10.255.255.255",
+		"11.0.0.0",
+		"9.255.255.255",
+		"192.168.1.1",
+		"192.168.1.2",
+		"172.16.0.9",
+		"172.16.0.10",
+		"172.16.0.15",
+		"172.16.0.20",
+		"172.16.0.21",
+		"100.64.0.1",
+		"100.127.255.255",
+		"100.128.0.0",
+		"::ffff:10.1.2.3",
+		"2001:db8::1",
+		"2001:db8:ffff:ffff:ffff:ffff:ffff:ffff",
+		"2001:db9::",
+		"2a00:1450:4001::",
+		"2a00:1450:4001::1",
+		"2a00:1450:4001::ff",
+		"2a00:1450:4001::100",
+		"fe80::1%eth0",
+	}
+	expected := []bool{
+		true,
+		true,
+		false,
+		false,
+		true,
+		false,
+		false,
+		true,
+		true,
+		true,
+		false,
+		true,
+		true,
+		false,
+		true,
+		true,
+		true,
+		false,
+		false,
+		true,
+		true,
+		false,
+		false,
+	}
+
+	for i, in := range input {
+		assert.Equal(t, expected[i], filter.Ignore(in), in)
+	}
+}
+
+func TestCIDRFilter_Load(t *testing.T) {
+	filter := NewCIDRFilter()
+	assert.NoError(t, filter.Load(strings.NewReader("10.0.0.0/8")))
+	assert.EqualError(t, filter.Load(strings.NewReader("1.1.1.1\n\ninvalid")), `invalid entry in line 3: ParseAddr("invalid"): unable to parse IP`)
+	assert.Error(t, filter.Load(strings.NewReader("1.1.1.1/33")))
+	assert.EqualError(t, filter.Load(strings.NewReader("1.1.1.2-1.1.1.1")), "invalid entry in line 1: range start 1.1.1.2 is greater than end 1.1.1.1")
+	assert.EqualError(t, filter.Load(strings.NewReader("1.1.1.1-::1")), "invalid entry in line 1: range mixes IPv4 and IPv6 addresses")
+	assert.True(t, filter.Ignore("10.1.1.1"))
+	assert.False(t, filter.Ignore("1.1.1.1"))
+}
+
+func TestCIDRFilter_LoadFiles(t *testing.T) {
+	dir := t.TempDir()
+	office := filepath.Join(dir, "office.txt")
+	datacenter := filepath.Join(dir, "datacenter.txt")
+	assert.NoError(t, os.WriteFile(office, []byte("192.168.0.0/16"), 0644))
+	assert.NoError(t, os.WriteFile(datacenter, []byte("5.9.0.0/16\n2a01:4f8::/32"), 0644))
+	filter, err := NewCIDRFilterFromFiles(office, datacenter)
+	assert.NoError(t, err)
+	assert.True(t, filter.Ignore("192.168.178.1"))
+	assert.True(t, filter.Ignore("5.9.1.1"))
+	assert.True(t, filter.Ignore("2a01:4f8:1::1"))
+	assert.False(t, filter.Ignore("5.10.1.1"))
+	assert.NoError(t, os.WriteFile(datacenter, []byte("5.10.0.0/16"), 0644))
+	assert.NoError(t, filter.Reload())
+	assert.False(t, filter.Ignore("5.9.1.1"))
+	assert.True(t, filter.Ignore("5.10.1.1"))
+	assert.NoError(t, os.WriteFile(datacenter, []byte("invalid"), 0644))
+	assert.Error(t, filter.Reload())
+	assert.True(t, filter.Ignore("5.10.1.1"))
+	assert.True(t, filter.Ignore("192.168.178.1"))
+	_, err = NewCIDRFilterFromFiles(filepath.Join(dir, "missing.txt"))
+	assert.Error(t, err)
+}
+
+func TestCIDRFilter_Update(t *testing.T) {
+	filter := NewCIDRFilter()
+	filter.Update([]string{
+		"90.154.29.38",
+		"invalid",
+	}, []string{
+		"2003:e1:7f03:a7b7:6328:b96a:4061:9999",
+	}, []Range{
+		{"123.0.0.0", "123.10.0.5"},
+		{"invalid", "123.10.0.5"},
+	}, []Range{
+		{"2001:1ab0:f001::", "2001:1ab0:f001:ffff:ffff:ffff:ffff:ffff"},
+	})
+	assert.Equal(t, 4, filter.Len())
+	assert.True(t, filter.Ignore("90.154.29.38"))
+	assert.True(t, filter.Ignore("123.5.123.69"))
+	assert.False(t, filter.Ignore("123.10.0.6"))
+	assert.True(t, filter.Ignore("2003:e1:7f03:a7b7:6328:b96a:4061:9999"))
+	assert.True(t, filter.Ignore("2001:1ab0:f001:1000::ff"))
+}
+
+func TestNewRangeSet(t *testing.T) {
+	set := newRangeSet([]ipRange{
+		{netip.MustParseAddr("10.0.0.10"), netip.MustParseAddr("10.0.0.20")},
+		{netip.MustParseAddr("10.0.0.0"), netip.MustParseAddr("10.0.0.5")},
+		{netip.MustParseAddr("10.0.0.6"), netip.MustParseAddr("10.0.0.8")},
+		{netip.MustParseAddr("10.0.0.15"), netip.MustParseAddr("10.0.0.30")},
+		{netip.MustParseAddr("10.0.0.12"), netip.MustParseAddr("10.0.0.13")},
+		{netip.MustParseAddr("10.0.1.0"), netip.MustParseAddr("255.255.255.255")},
+		{netip.MustParseAddr("11.0.0.0"), netip.MustParseAddr("12.0.0.0")},
+	})
+	assert.Equal(t, rangeSet{
+		{netip.MustParseAddr("10.0.0.0"), netip.MustParseAddr("10.0.0.8")},
+		{netip.MustParseAddr("10.0.0.10"), netip.MustParseAddr("10.0.0.30")},
+		{netip.MustParseAddr("10.0.1.0"), netip.MustParseAddr("255.255.255.255")},
+	}, set)
+	assert.False(t, set.contains(netip.MustParseAddr("10.0.0.9")))
+	assert.True(t, set.contains(netip.MustParseAddr("10.0.0.25")))
+	assert.True(t, set.contains(netip.MustParseAddr("255.255.255.255")))
+	assert.False(t, rangeSet(nil).contains(netip.MustParseAddr("10.0.0.9")))
+}
+
+func BenchmarkCIDRFilter(b *testing.B) {
+	var list strings.Builder
+
+	for i := 0; i < 100_000; i++ {
+		addr := netip.AddrFrom4([4]byte{byte(rand.Intn(256)), byte(rand.Intn(256)), byte(rand.Intn(256)), 0})
+		list.WriteString(netip.PrefixFrom(addr, 24).String())
+		list.WriteByte('\n')
+	}
+
+	filter := NewCIDRFilter()
+	assert.NoError(b, filter.Load(strings.NewReader(list.String())))
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		filter.Ignore("91.36.189.125")
+	}
+}
@@ -33,12 +33,25 @@ var (
 		Forwarded,
 		XRealIP,
 	}
+
+	// DefaultListParser maps the headers of the default HeaderParser to the functions returning all addresses for GetUntrusted.
+	DefaultListParser = map[string]ListHeaderFunc{
+		CFConnectingIP.Header: listXForwardedForHeader,
+		TrueClientIP.Header:   listXForwardedForHeader,
+		XForwardedFor.Header:  listXForwardedForHeader,
+		Forwarded.Header:      listForwardedHeader,
+		XRealIP.Header:        listXRealIPHeader,
+	}
 )
 
 // ParseHeaderFunc parses and validates an IP address from a header.
 // It must return an empty string if the header or contained IP address is invalid.
 type ParseHeaderFunc func(string) string
 
+// ListHeaderFunc returns all addresses from a header, ordered from the client to the last proxy.
+// The addresses are not validated.
+type ListHeaderFunc func(string) []string
+
 // HeaderParser parses a header to extract the real client IP address.
 type HeaderParser struct {
 	Header string
@@ -50,7 +63,7 @@ type HeaderParser struct {
 func Get(r *http.Request, parser []HeaderParser, allowed []net.IPNet) string {
 	ip := cleanIP(r.RemoteAddr)
 
-	if len(allowed) > 0 && !validProxySource(ip, allowed) {
+	if !TrustedProxy(r, allowed) {
 		return ip
 	}
 
@@ -69,6 +82,12 @@ func Get(r *http.Request, parser []HeaderParser, allowed []net.IPNet) string {
 	return ip
 }
 
+// TrustedProxy returns whether headers set by a proxy can be trusted for given request.
+// This is the case if no allowed proxy subnets are configured, or the remote address is within one of them.
+func TrustedProxy(r *http.Request, allowed []net.IPNet) bool {
+	return len(allowed) == 0 || validProxySource(cleanIP(r.RemoteAddr), allowed)
+}
+
 // cleanIP returns the ip without port, if any.
 func cleanIP(ip string) string {
 	if strings.Contains(ip, ":") {
@@ -162,6 +162,18 @@ func TestGetWithProxy(t *testing.T) {
 	assert.Equal(t, "1.1.1.1", Get(r, DefaultHeaderParser, allowedProxySubnets))
 }
 
+func TestTrustedProxy(t *testing.T) {
+	_, subnet, _ := net.ParseCIDR("10.0.0.0/8")
+	allowed := []net.IPNet{*subnet}
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "10.0.0.8:29302"
+	assert.True(t, TrustedProxy(r, nil))
+	assert.True(t, TrustedProxy(r, allowed))
+	r.RemoteAddr = "65.182.89.102:29302"
+	assert.True(t, TrustedProxy(r, nil))
+	assert.False(t, TrustedProxy(r, allowed))
+}
+
 func TestIsValidIP(t *testing.T) {
 	assert.False(t, isValidIP("invalid"))
 	assert.False(t, isValidIP(""))
@@ -0,0 +1,169 @@
+package ip
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+)
+
+// ipRange is an inclusive range of IP addresses of the same family.
+type ipRange struct {
+	from netip.Addr
+	to   netip.Addr
+}
+
+// rangeSet is a sorted list of non-overlapping IP address ranges, which can be searched in logarithmic time.
+type rangeSet []ipRange
+
+// ipSet holds the IPv4 and IPv6 ranges of a filter.
+type ipSet struct {
+	v4 rangeSet
+	v6 rangeSet
+}
+
+// newIPSet creates a new ipSet from given ranges.
+// Overlapping and adjacent ranges are merged.
+func newIPSet(ranges []ipRange) *ipSet {
+	var v4, v6 []ipRange
+
+	for _, r := range ranges {
+		if r.from.Is4() {
+			v4 = append(v4, r)
+		} else {
+			v6 = append(v6, r)
+		}
+	}
+
+	return &ipSet{
+		v4: newRangeSet(v4),
+		v6: newRangeSet(v6),
+	}
+}
+
+func (set *ipSet) contains(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	if addr.Is4() {
+		return set.v4.contains(addr)
+	}
+
+	return set.v6.contains(addr.WithZone(""))
+}
+
+func (set *ipSet) len() int {
+	return len(set.v4) + len(set.v6)
+}
+
+func newRangeSet(ranges []ipRange) rangeSet {
+	if len(ranges) == 0 {
+		return nil
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].from.Less(ranges[j].from)
+	})
+	set := make(rangeSet, 0, len(ranges))
+	set = append(set, ranges[0])
+
+	for _, r := range ranges[1:] {
+		last := &set[len(set)-1]
+		next := last.to.Next()
+
+		if !next.IsValid() || r.from.Compare(next) <= 0 {
+			if r.to.Compare(last.to) > 0 {
+				last.to = r.to
+			}
+		} else {
+			set = append(set, r)
+		}
+	}
+
+	return set
+}
+
+func (set rangeSet) contains(addr netip.Addr) bool {
+	i := sort.Search(len(set), func(i int) bool {
+		return set[i].to.Compare(addr) >= 0
+	})
+	return i < len(set) && set[i].from.Compare(addr) <= 0
+}
+
+// parseRange parses a single IP address, a CIDR block (like 10.0.0.0/8), or a range of IP addresses (like 10.0.0.1-10.0.0.255).
+func parseRange(entry string) (ipRange, error) {
+	if from, to, found := strings.Cut(entry, "-"); found {
+		return newRange(strings.TrimSpace(from), strings.TrimSpace(to))
+	}
+
+	if strings.Contains(entry, "/") {
+		prefix, err := netip.ParsePrefix(entry)
+
+		if err != nil {
+			return ipRange{}, err
+		}
+
+		if prefix.Addr().Is4In6() && prefix.Bits() >= 96 {
+			prefix = netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()-96)
+		}
+
+		prefix = prefix.Masked()
+		return ipRange{prefix.Addr(), lastAddr(prefix)}, nil
+	}
+
+	addr, err := netip.ParseAddr(entry)
+
+	if err != nil {
+		return ipRange{}, err
+	}
+
+	addr = addr.Unmap().WithZone("")
+	return ipRange{addr, addr}, nil
+}
+
+func newRange(from, to string) (ipRange, error) {
+	fromAddr, err := netip.ParseAddr(from)
+
+	if err != nil {
+		return ipRange{}, err
+	}
+
+	toAddr, err := netip.ParseAddr(to)
+
+	if err != nil {
+		return ipRange{}, err
+	}
+
+	fromAddr = fromAddr.Unmap().WithZone("")
+	toAddr = toAddr.Unmap().WithZone("")
+
+	if fromAddr.Is4() != toAddr.Is4() {
+		return ipRange{}, errors.New("range mixes IPv4 and IPv6 addresses")
+	}
+
+	if fromAddr.Compare(toAddr) > 0 {
+		return ipRange{}, fmt.Errorf("range start %s is greater than end %s", fromAddr, toAddr)
+	}
+
+	return ipRange{fromAddr, toAddr}, nil
+}
+
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	addr := prefix.Addr()
+	b := addr.As16()
+	offset := 0
+
+	if addr.Is4() {
+		offset = 96
+	}
+
+	for i := prefix.Bits() + offset; i < 128; i++ {
+		b[i/8] |= 1 << (7 - i%8)
+	}
+
+	if addr.Is4() {
+		return netip.AddrFrom16(b).Unmap()
+	}
+
+	return netip.AddrFrom16(b)
+}
@@ -0,0 +1,151 @@
+package ip
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// TrustedProxies configures the proxies in front of the server for GetUntrusted.
+type TrustedProxies struct {
+	// Subnets are the CIDRs of trusted proxies.
+	Subnets []net.IPNet
+
+	// Hops is the number of proxies in front of the server that are trusted regardless of their address,
+	// like a load balancer without a fixed IP. The proxy connecting to the server counts as the first hop.
+	Hops int
+
+	// ListParser maps header names to the functions returning all addresses of a header.
+	// Headers without a ListHeaderFunc use the address returned by the HeaderParser as the only entry.
+	// Defaults to DefaultListParser.
+	ListParser map[string]ListHeaderFunc
+}
+
+// GetUntrusted returns the IP from given request using the right-most untrusted address.
+// The remote address and the addresses in the headers are walked from the right (the server) to the left (the client),
+// skipping trusted proxies. The first untrusted address is returned, as it's the one that connected to a trusted proxy
+// and all addresses further to the left can be set by the client.
+// If all addresses are trusted, the left-most address is returned, as there is no proxy left to pass it on.
+// The headers are ignored if the remote address is not a trusted proxy, and the remote address is returned if no header contains a valid address.
+func GetUntrusted(r *http.Request, parser []HeaderParser, trusted TrustedProxies) string {
+	ip := cleanIP(r.RemoteAddr)
+
+	if !trusted.TrustsRemoteAddr(r) {
+		return ip
+	}
+
+	for _, header := range parser {
+		value := strings.Join(r.Header.Values(header.Header), ",")
+
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+
+		if client := trusted.rightMostUntrusted(trusted.list(header, value)); client != "" {
+			return client
+		}
+	}
+
+	return ip
+}
+
+// TrustsRemoteAddr returns whether the remote address of given request is a trusted proxy,
+// so that the headers set by it can be trusted.
+func (trusted TrustedProxies) TrustsRemoteAddr(r *http.Request) bool {
+	return trusted.trusts(cleanIP(r.RemoteAddr), 0)
+}
+
+// rightMostUntrusted returns the right-most address not trusted from given list, or the left-most address if all are trusted.
+// The walk stops at the first invalid address, as the proxy that added it cannot be trusted to have set all addresses correctly.
+func (trusted TrustedProxies) rightMostUntrusted(addresses []string) string {
+	for i := len(addresses) - 1; i >= 0; i-- {
+		address := cleanHop(addresses[i])
+
+		if net.ParseIP(address) == nil {
+			return ""
+		}
+
+		if !trusted.trusts(address, len(addresses)-i) {
+			if isValidIP(address) {
+				return address
+			}
+
+			return ""
+		}
+	}
+
+	if len(addresses) == 0 {
+		return ""
+	}
+
+	return cleanHop(addresses[0])
+}
+
+// trusts returns whether the address at given hop is a trusted proxy.
+// The remote address is hop zero.
+func (trusted TrustedProxies) trusts(address string, hop int) bool {
+	return hop < trusted.Hops || validProxySource(address, trusted.Subnets)
+}
+
+func (trusted TrustedProxies) list(header HeaderParser, value string) []string {
+	listParser := trusted.ListParser
+
+	if listParser == nil {
+		listParser = DefaultListParser
+	}
+
+	for name, list := range listParser {
+		if strings.EqualFold(name, header.Header) {
+			return list(value)
+		}
+	}
+
+	if header.Parser != nil {
+		if ip := header.Parser(value); ip != "" {
+			return []string{ip}
+		}
+	}
+
+	return nil
+}
+
+// cleanHop returns the address of a header entry without quotes, brackets, and port.
+func cleanHop(address string) string {
+	address = strings.Trim(strings.TrimSpace(address), `"`)
+	address = cleanIP(address)
+	return strings.TrimSuffix(strings.TrimPrefix(address, "["), "]")
+}
+
+// listForwardedHeader returns the for= addresses of the Forwarded header.
+// Elements without a for= parameter are returned as empty strings, so that they are treated as invalid.
+func listForwardedHeader(value string) []string {
+	elements := strings.Split(value, ",")
+	addresses := make([]string, 0, len(elements))
+
+	for _, element := range elements {
+		address := ""
+
+		for _, part := range strings.Split(element, ";") {
+			k, v, found := strings.Cut(part, "=")
+
+			if found && strings.EqualFold(strings.TrimSpace(k), "for") {
+				address = v
+				break
+			}
+		}
+
+		addresses = append(addresses, address)
+	}
+
+	return addresses
+}
+
+// listXForwardedForHeader returns the addresses of the X-Forwarded-For header.
+func listXForwardedForHeader(value string) []string {
+	return strings.Split(value, ",")
+}
+
+// listXRealIPHeader returns the address of the X-Real-IP header.
+func listXRealIPHeader(value string) []string {
+	return []string{value}
+}
@@ -0,0 +1,112 @@
+package ip
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetUntrusted(t *testing.T) {
+	_, subnet, _ := net.ParseCIDR("10.0.0.0/8")
+	trusted := TrustedProxies{Subnets: []net.IPNet{*subnet}}
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "10.0.0.1:29302"
+
+	// no header, default
+	assert.Equal(t, "10.0.0.1", GetUntrusted(r, DefaultHeaderParser, trusted))
+
+	// headers are ignored for untrusted remote addresses
+	r.Header.Set("X-Forwarded-For", "65.182.89.102")
+	assert.Equal(t, "10.0.0.1", GetUntrusted(r, DefaultHeaderParser, TrustedProxies{}))
+	r.RemoteAddr = "23.21.45.67:29302"
+	assert.Equal(t, "23.21.45.67", GetUntrusted(r, DefaultHeaderParser, trusted))
+	r.RemoteAddr = "10.0.0.1:29302"
+	assert.Equal(t, "65.182.89.102", GetUntrusted(r, DefaultHeaderParser, trusted))
+
+	// no parser
+	assert.Equal(t, "10.0.0.1", GetUntrusted(r, nil, trusted))
+
+	// the headers are checked in order
+	r.Header.Set("CF-Connecting-IP", "103.0.53.43")
+	assert.Equal(t, "103.0.53.43", GetUntrusted(r, DefaultHeaderParser, trusted))
+
+	// multiple header lines are joined
+	r = httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "10.0.0.1:29302"
+	r.Header.Add("X-Forwarded-For", "65.182.89.102, 23.21.45.67")
+	r.Header.Add("X-Forwarded-For", "10.0.0.3, 10.0.0.2")
+	assert.Equal(t, "23.21.45.67", GetUntrusted(r, DefaultHeaderParser, trusted))
+}
+
+func TestGetUntrustedSpoofing(t *testing.T) {
+	_, subnet, _ := net.ParseCIDR("10.0.0.0/8")
+	subnets := []net.IPNet{*subnet}
+	parser := []HeaderParser{CFConnectingIP, TrueClientIP, XForwardedFor, Forwarded, XRealIP}
+	input := []struct {
+		remoteAddr string
+		header     []string
+		trusted    TrustedProxies
+	}{
+		// spoofed header from a client connecting directly
+		{"23.21.45.67:1234", []string{"65.182.89.102", "65.182.89.102", "65.182.89.102", "for=65.182.89.102", "65.182.89.102"}, TrustedProxies{Subnets: subnets}},
+		{"23.21.45.67:1234", []string{"65.182.89.102", "65.182.89.102", "65.182.89.102", "for=65.182.89.102", "65.182.89.102"}, TrustedProxies{}},
+
+		// single trusted proxy
+		{"10.0.0.1:1234", []string{"65.182.89.102", "65.182.89.102", "65.182.89.102", "for=65.182.89.102", "65.182.89.102"}, TrustedProxies{Subnets: subnets}},
+		{"10.0.0.1:1234", []string{"65.182.89.102", "65.182.89.102", "65.182.89.102", "for=65.182.89.102", "65.182.89.102"}, TrustedProxies{Hops: 1}},
+
+		// client sets an earlier hop, which is appended to by the trusted proxy (X-Real-IP only contains a single address)
+		{"10.0.0.1:1234", []string{"1.1.1.1, 65.182.89.102", "1.1.1.1, 65.182.89.102", "1.1.1.1, 65.182.89.102", "for=1.1.1.1, for=65.182.89.102", "1.1.1.1, 65.182.89.102"}, TrustedProxies{Subnets: subnets}},
+
+		// client sets a trusted proxy address as the earlier hop
+		{"10.0.0.1:1234", []string{"65.182.89.102, 10.0.0.2", "65.182.89.102, 10.0.0.2", "1.1.1.1, 65.182.89.102, 10.0.0.2", "for=1.1.1.1, for=65.182.89.102, for=10.0.0.2", "10.0.0.2"}, TrustedProxies{Subnets: subnets}},
+
+		// multiple trusted hops without fixed addresses
+		{"172.16.0.1:1234", []string{"1.1.1.1, 65.182.89.102, 23.21.45.67", "1.1.1.1, 65.182.89.102, 23.21.45.67", "1.1.1.1, 65.182.89.102, 23.21.45.67", `for="1.1.1.1", for="[2001:db8::1]:4711", for=23.21.45.67`, "23.21.45.67"}, TrustedProxies{Hops: 2}},
+
+		// invalid and private addresses stop the walk
+		{"10.0.0.1:1234", []string{"65.182.89.102, invalid", "65.182.89.102, 192.168.1.1", "65.182.89.102, unknown, 10.0.0.2", "for=65.182.89.102, by=10.0.0.2", "invalid"}, TrustedProxies{Subnets: subnets}},
+	}
+	expected := [][]string{
+		{"23.21.45.67", "23.21.45.67", "23.21.45.67", "23.21.45.67", "23.21.45.67"},
+		{"23.21.45.67", "23.21.45.67", "23.21.45.67", "23.21.45.67", "23.21.45.67"},
+		{"65.182.89.102", "65.182.89.102", "65.182.89.102", "65.182.89.102", "65.182.89.102"},
+		{"65.182.89.102", "65.182.89.102", "65.182.89.102", "65.182.89.102", "65.182.89.102"},
+		{"65.182.89.102", "65.182.89.102", "65.182.89.102", "65.182.89.102", "10.0.0.1"},
+		{"65.182.89.102", "65.182.89.102", "65.182.89.102", "65.182.89.102", "10.0.0.2"},
+		{"65.182.89.102", "65.182.89.102", "65.182.89.102", "2001:db8::1", "23.21.45.67"},
+		{"10.0.0.1", "10.0.0.1", "10.0.0.1", "10.0.0.1", "10.0.0.1"},
+	}
+
+	for i, in := range input {
+		for j, p := range parser {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.RemoteAddr = in.remoteAddr
+			r.Header.Set(p.Header, in.header[j])
+			assert.Equal(t, expected[i][j], GetUntrusted(r, []HeaderParser{p}, in.trusted), "case %d, header %s", i, p.Header)
+		}
+	}
+}
+
+func TestGetUntrustedCustomParser(t *testing.T) {
+	parser := HeaderParser{"X-Client-IP", parseXRealIPHeader}
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "10.0.0.1:1234"
+	r.Header.Set("X-Client-IP", "65.182.89.102")
+	assert.Equal(t, "65.182.89.102", GetUntrusted(r, []HeaderParser{parser}, TrustedProxies{Hops: 1}))
+	r.Header.Set("X-Client-IP", "10.0.0.2")
+	assert.Equal(t, "10.0.0.1", GetUntrusted(r, []HeaderParser{parser}, TrustedProxies{Hops: 1}))
+	r.Header.Set("X-Client-IP", "1.1.1.1, 65.182.89.102")
+	assert.Equal(t, "10.0.0.1", GetUntrusted(r, []HeaderParser{parser}, TrustedProxies{Hops: 1}))
+	listParser := map[string]ListHeaderFunc{"x-client-ip": listXForwardedForHeader}
+	assert.Equal(t, "65.182.89.102", GetUntrusted(r, []HeaderParser{parser}, TrustedProxies{Hops: 1, ListParser: listParser}))
+}
+
+func TestListForwardedHeader(t *testing.T) {
+	assert.Equal(t, []string{"12.34.56.78", "23.45.67.89"}, listForwardedHeader("for=12.34.56.78;host=example.com;proto=https, for=23.45.67.89"))
+	assert.Equal(t, []string{`"[2001:db8:cafe::17]:4711"`, ""}, listForwardedHeader(`For="[2001:db8:cafe::17]:4711", proto=http`))
+	assert.Equal(t, "2001:db8:cafe::17", cleanHop(`"[2001:db8:cafe::17]:4711"`))
+	assert.Equal(t, "2001:db8:cafe::17", cleanHop(" [2001:db8:cafe::17] "))
+	assert.Equal(t, "12.34.56.78", cleanHop(" 12.34.56.78:1234"))
+}
@@ -1,16 +1,15 @@
 package ip
 
 import (
-	"bytes"
 	"database/sql"
 	"fmt"
 	_ "github.com/mattn/go-sqlite3"
 	"io"
-	"net"
 	"net/http"
+	"net/netip"
 	"os"
 	"path/filepath"
-	"sync"
+	"sync/atomic"
 )
 
 const (
@@ -18,94 +17,37 @@ const (
 	udgerFilename = "udgerdb_v4.dat"
 )
 
-type ipRange struct {
-	from net.IP
-	to   net.IP
-}
-
 // Udger implements the Filter interface.
 type Udger struct {
-	accessKey          string
-	downloadPath       string
-	ipsV4, ipsV6       map[string]struct{}
-	rangesV4, rangesV6 []ipRange
-	m                  sync.RWMutex
+	accessKey    string
+	downloadPath string
+	ranges       atomic.Pointer[ipSet]
 }
 
 // NewUdger creates a new Filter using the IP lists provided by udger.com.
 func NewUdger(accessKey, downloadPath string) *Udger {
-	return &Udger{
+	udger := &Udger{
 		accessKey:    accessKey,
 		downloadPath: downloadPath,
 	}
+	udger.ranges.Store(newIPSet(nil))
+	return udger
 }
 
 // Update implements the Filter interface.
 func (udger *Udger) Update(ipsV4, ipsV6 []string, rangesV4, rangesV6 []Range) {
-	ipMapV4 := make(map[string]struct{}, len(ipsV4))
-
-	for _, ip := range ipsV4 {
-		ipMapV4[ip] = struct{}{}
-	}
-
-	ipMapV6 := make(map[string]struct{}, len(ipsV6))
-
-	for _, ip := range ipsV6 {
-		ipMapV6[ip] = struct{}{}
-	}
-
-	ipRangesV4 := make([]ipRange, 0, len(rangesV4))
-
-	for _, r := range rangesV4 {
-		fromIP := net.ParseIP(r.From)
-		toIP := net.ParseIP(r.To)
-
-		if fromIP != nil && toIP != nil {
-			ipRangesV4 = append(ipRangesV4, ipRange{
-				from: net.ParseIP(r.From),
-				to:   net.ParseIP(r.To),
-			})
-		}
-	}
-
-	ipRangesV6 := make([]ipRange, 0, len(rangesV6))
-
-	for _, r := range rangesV6 {
-		fromIP := net.ParseIP(r.From)
-		toIP := net.ParseIP(r.To)
-
-		if fromIP != nil && toIP != nil {
-			ipRangesV6 = append(ipRangesV6, ipRange{
-				from: net.ParseIP(r.From),
-				to:   net.ParseIP(r.To),
-			})
-		}
-	}
-
-	udger.m.Lock()
-	defer udger.m.Unlock()
-	udger.ipsV4 = ipMapV4
-	udger.ipsV6 = ipMapV6
-	udger.rangesV4 = ipRangesV4
-	udger.rangesV6 = ipRangesV6
+	udger.ranges.Store(newIPSet(toRanges(ipsV4, ipsV6, rangesV4, rangesV6)))
 }
 
 // Ignore implements the Filter interface.
 func (udger *Udger) Ignore(ip string) bool {
-	parsedIP := net.ParseIP(ip)
+	addr, err := netip.ParseAddr(ip)
 
-	if parsedIP == nil {
+	if err != nil {
 		return true
 	}
 
-	udger.m.RLock()
-	defer udger.m.RUnlock()
-
-	if parsedIP.To4() != nil {
-		return udger.findIP(ip, parsedIP, udger.ipsV4, udger.rangesV4)
-	}
-
-	return udger.findIP(ip, parsedIP, udger.ipsV6, udger.rangesV6)
+	return udger.ranges.Load().contains(addr)
 }
 
 // DownloadAndUpdate downloads and updates the IP list from udger.com.
@@ -114,7 +56,7 @@ func (udger *Udger) DownloadAndUpdate() error {
 		return err
 	}
 
-	if err := udger.updateFromDB(); err != nil {
+	if err := udger.UpdateFromFile(filepath.Join(udger.downloadPath, udgerFilename)); err != nil {
 		return err
 	}
 
@@ -149,8 +91,14 @@ func (udger *Udger) download() error {
 	return nil
 }
 
-func (udger *Udger) updateFromDB() error {
-	db, err := sql.Open("sqlite3", filepath.Join(udger.downloadPath, udgerFilename))
+// UpdateFromFile updates the IP list from a udger.com database file that has been downloaded before.
+// This can be used in environments without internet access.
+func (udger *Udger) UpdateFromFile(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+
+	db, err := sql.Open("sqlite3", path)
 
 	if err != nil {
 		return err
@@ -228,17 +176,3 @@ func (udger *Udger) updateFromDB() error {
 	udger.Update(ipV4, ipV6, rangesV4, rangesV6)
 	return nil
 }
-
-func (udger *Udger) findIP(ip string, parsedIP net.IP, ips map[string]struct{}, ranges []ipRange) bool {
-	if _, ok := ips[ip]; ok {
-		return true
-	}
-
-	for _, r := range ranges {
-		if bytes.Compare(parsedIP, r.from) >= 0 && bytes.Compare(parsedIP, r.to) <= 0 {
-			return true
-		}
-	}
-
-	return false
-}
@@ -1,8 +1,10 @@
 package ip
 
 import (
+	"database/sql"
 	"github.com/stretchr/testify/assert"
 	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -51,3 +53,27 @@ func BenchmarkUdger(b *testing.B) {
 		})
 	}
 }
+
+func TestUdger_UpdateFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), udgerFilename)
+	db, err := sql.Open("sqlite3", path)
+	assert.NoError(t, err)
+	_, err = db.Exec(`CREATE TABLE udger_ip_list (ip TEXT, class_id INTEGER);
+		CREATE TABLE udger_datacenter_range (ip_from TEXT, ip_to TEXT);
+		CREATE TABLE udger_datacenter_range6 (ip_from TEXT, ip_to TEXT);
+		INSERT INTO udger_ip_list VALUES ('90.154.29.38', 2), ('90.154.29.39', 1), ('2003:e1:7f03:a7b7:6328:b96a:4061:9999', 2);
+		INSERT INTO udger_datacenter_range VALUES ('123.0.0.0', '123.10.0.5');
+		INSERT INTO udger_datacenter_range6 VALUES ('2001:1ab0:f001::', '2001:1ab0:f001:ffff:ffff:ffff:ffff:ffff');`)
+	assert.NoError(t, err)
+	assert.NoError(t, db.Close())
+	udger := NewUdger("", "")
+	assert.NoError(t, udger.UpdateFromFile(path))
+	assert.True(t, udger.Ignore("90.154.29.38"))
+	assert.False(t, udger.Ignore("90.154.29.39"))
+	assert.True(t, udger.Ignore("2003:e1:7f03:a7b7:6328:b96a:4061:9999"))
+	assert.True(t, udger.Ignore("123.5.123.69"))
+	assert.True(t, udger.Ignore("2001:1ab0:f001:1000::ff"))
+	assert.False(t, udger.Ignore("91.154.29.38"))
+	assert.Error(t, udger.UpdateFromFile(filepath.Join(t.TempDir(), udgerFilename)))
+	assert.True(t, udger.Ignore("90.154.29.38"))
+}
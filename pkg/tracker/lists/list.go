@@ -0,0 +1,295 @@
+package lists
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+const versionPrefix = "# version:"
+
+// Entries maps the keys of a list to an optional value.
+// Keys are always lowercase.
+type Entries map[string]string
+
+// Keys returns the sorted keys.
+func (entries Entries) Keys() []string {
+	keys := make([]string, 0, len(entries))
+
+	for key := range entries {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+// Data is a version of a list.
+// It must not be modified after it has been passed to a List.
+type Data struct {
+	Version string
+	Entries Entries
+}
+
+// Overrides are additions and exclusions for a single client.
+// It must not be modified after it has been passed to a List.
+type Overrides struct {
+	Add     Entries
+	Exclude map[string]struct{}
+}
+
+// Loader returns a reader for a list file.
+type Loader func(ctx context.Context) (io.ReadCloser, error)
+
+// List is a list of entries that can be replaced at runtime without locking readers.
+// Clients can add and exclude entries on top of the list.
+type List struct {
+	defaults  *Data
+	data      atomic.Pointer[Data]
+	overrides map[uint64]*Overrides
+	m         sync.RWMutex
+}
+
+// New creates a new List using given data as the default.
+func New(defaults *Data) *List {
+	list := &List{
+		defaults:  defaults,
+		overrides: make(map[uint64]*Overrides),
+	}
+	list.data.Store(defaults)
+	return list
+}
+
+// Parse reads a list file.
+// Every line contains a single entry, optionally followed by a tab and a value.
+// Empty lines and lines starting with # are ignored, except for "# version: <version>", which sets the version.
+func Parse(r io.Reader) (*Data, error) {
+	data := &Data{Entries: make(Entries)}
+	scanner := bufio.NewScanner(r)
+	line := 0
+
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		trimmed := strings.TrimSpace(text)
+
+		if strings.HasPrefix(trimmed, versionPrefix) {
+			if data.Version == "" {
+				data.Version = strings.TrimSpace(trimmed[len(versionPrefix):])
+			}
+
+			continue
+		}
+
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		key, value, _ := strings.Cut(text, "\t")
+		key = strings.ToLower(strings.TrimSpace(key))
+
+		if key == "" {
+			return nil, fmt.Errorf("empty entry in line %d", line)
+		}
+
+		data.Entries[key] = strings.TrimSpace(value)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// MustParse parses a list file and panics in case it is invalid.
+// This is used for the embedded default lists.
+func MustParse(data []byte) *Data {
+	d, err := Parse(bytes.NewReader(data))
+
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+// ParseFile reads a list file from disk.
+func ParseFile(path string) (*Data, error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+	return Parse(f)
+}
+
+// File returns a Loader reading a list from a file.
+func File(path string) Loader {
+	return func(context.Context) (io.ReadCloser, error) {
+		return os.Open(path)
+	}
+}
+
+// URL returns a Loader downloading a list.
+func URL(url string) Loader {
+	return func(ctx context.Context) (io.ReadCloser, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("error downloading list %s: %s", url, resp.Status)
+		}
+
+		return resp.Body, nil
+	}
+}
+
+// Data returns the active version of the list.
+func (list *List) Data() *Data {
+	return list.data.Load()
+}
+
+// Version returns the version of the active list.
+func (list *List) Version() string {
+	return list.data.Load().Version
+}
+
+// Set replaces the list.
+func (list *List) Set(data *Data) {
+	list.data.Store(data)
+}
+
+// Reset restores the default list.
+func (list *List) Reset() {
+	list.data.Store(list.defaults)
+}
+
+// Load replaces the list with the one read from given reader.
+// The list is left unchanged in case the input is invalid or empty.
+func (list *List) Load(r io.Reader) error {
+	data, err := Parse(r)
+
+	if err != nil {
+		return err
+	}
+
+	if len(data.Entries) == 0 {
+		return fmt.Errorf("list is empty")
+	}
+
+	list.Set(data)
+	return nil
+}
+
+// Update replaces the list with the one returned by given Loader.
+func (list *List) Update(ctx context.Context, loader Loader) error {
+	r, err := loader(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer r.Close()
+	return list.Load(r)
+}
+
+// SetClient sets the entries added and excluded for given client, replacing previous overrides.
+func (list *List) SetClient(clientID uint64, add Entries, exclude []string) {
+	overrides := &Overrides{
+		Add:     make(Entries, len(add)),
+		Exclude: make(map[string]struct{}, len(exclude)),
+	}
+
+	for key, value := range add {
+		overrides.Add[strings.ToLower(key)] = value
+	}
+
+	for _, key := range exclude {
+		overrides.Exclude[strings.ToLower(key)] = struct{}{}
+	}
+
+	list.m.Lock()
+	defer list.m.Unlock()
+	list.overrides[clientID] = overrides
+}
+
+// RemoveClient removes the overrides for given client.
+func (list *List) RemoveClient(clientID uint64) {
+	list.m.Lock()
+	defer list.m.Unlock()
+	delete(list.overrides, clientID)
+}
+
+// Client returns the overrides for given client or nil.
+func (list *List) Client(clientID uint64) *Overrides {
+	list.m.RLock()
+	defer list.m.RUnlock()
+	return list.overrides[clientID]
+}
+
+// Get returns the value for given key and whether the list contains it for given client.
+// The key must be lowercase.
+func (list *List) Get(clientID uint64, key string) (string, bool) {
+	if overrides := list.Client(clientID); overrides != nil {
+		if _, excluded := overrides.Exclude[key]; excluded {
+			return "", false
+		}
+
+		if value, found := overrides.Add[key]; found {
+			return value, true
+		}
+	}
+
+	value, found := list.data.Load().Entries[key]
+	return value, found
+}
+
+// Entries returns all entries of the active list for given client, including the client's overrides.
+func (list *List) Entries(clientID uint64) Entries {
+	return Merge(list.Data(), list.Client(clientID))
+}
+
+// Merge applies the overrides to the data and returns the resulting entries.
+// The overrides can be nil.
+func Merge(data *Data, overrides *Overrides) Entries {
+	if overrides == nil {
+		return data.Entries
+	}
+
+	entries := make(Entries, len(data.Entries)+len(overrides.Add))
+
+	for key, value := range data.Entries {
+		if _, excluded := overrides.Exclude[key]; !excluded {
+			entries[key] = value
+		}
+	}
+
+	for key, value := range overrides.Add {
+		if _, excluded := overrides.Exclude[key]; !excluded {
+			entries[key] = value
+		}
+	}
+
+	return entries
+}
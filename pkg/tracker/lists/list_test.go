@@ -0,0 +1,99 @@
+package lists
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testList = `# version: 2024-01-01
+# comment
+
+Foo
+bar	Bar
+  baz.com/path	Baz
+`
+
+func TestParse(t *testing.T) {
+	data, err := Parse(strings.NewReader(testList))
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-01-01", data.Version)
+	assert.Len(t, data.Entries, 3)
+	assert.Equal(t, "", data.Entries["foo"])
+	assert.Equal(t, "Bar", data.Entries["bar"])
+	assert.Equal(t, "Baz", data.Entries["baz.com/path"])
+	assert.Equal(t, []string{"bar", "baz.com/path", "foo"}, data.Entries.Keys())
+	_, err = Parse(strings.NewReader("foo\n\tbar"))
+	assert.EqualError(t, err, "empty entry in line 2")
+	assert.Panics(t, func() {
+		MustParse([]byte("\tbar"))
+	})
+}
+
+func TestList_Load(t *testing.T) {
+	defaults := MustParse([]byte("# version: 1\nfoo"))
+	list := New(defaults)
+	assert.Equal(t, "1", list.Version())
+	_, found := list.Get(0, "foo")
+	assert.True(t, found)
+	assert.NoError(t, list.Load(strings.NewReader("# version: 2\nbar")))
+	assert.Equal(t, "2", list.Version())
+	_, found = list.Get(0, "foo")
+	assert.False(t, found)
+	_, found = list.Get(0, "bar")
+	assert.True(t, found)
+	assert.EqualError(t, list.Load(strings.NewReader("# version: 3\n")), "list is empty")
+	assert.Equal(t, "2", list.Version())
+	list.Reset()
+	assert.Equal(t, defaults, list.Data())
+	list.Set(&Data{Version: "4", Entries: Entries{"baz": ""}})
+	assert.Equal(t, "4", list.Version())
+}
+
+func TestList_Update(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "list.txt")
+	assert.NoError(t, os.WriteFile(path, []byte(testList), 0644))
+	list := New(MustParse([]byte("foo")))
+	assert.NoError(t, list.Update(context.Background(), File(path)))
+	assert.Equal(t, "2024-01-01", list.Version())
+	assert.Len(t, list.Data().Entries, 3)
+	assert.Error(t, list.Update(context.Background(), File(filepath.Join(t.TempDir(), "missing.txt"))))
+	assert.Equal(t, "2024-01-01", list.Version())
+}
+
+func TestList_SetClient(t *testing.T) {
+	list := New(MustParse([]byte(testList)))
+	list.SetClient(42, Entries{"New": "New"}, []string{"Bar"})
+	value, found := list.Get(42, "new")
+	assert.True(t, found)
+	assert.Equal(t, "New", value)
+	_, found = list.Get(42, "bar")
+	assert.False(t, found)
+	value, found = list.Get(42, "baz.com/path")
+	assert.True(t, found)
+	assert.Equal(t, "Baz", value)
+	_, found = list.Get(0, "new")
+	assert.False(t, found)
+	_, found = list.Get(0, "bar")
+	assert.True(t, found)
+	assert.Equal(t, []string{"baz.com/path", "foo", "new"}, list.Entries(42).Keys())
+	assert.Equal(t, []string{"bar", "baz.com/path", "foo"}, list.Entries(0).Keys())
+	list.RemoveClient(42)
+	assert.Nil(t, list.Client(42))
+	_, found = list.Get(42, "bar")
+	assert.True(t, found)
+}
+
+func TestMerge(t *testing.T) {
+	data := &Data{Entries: Entries{"foo": "", "bar": "Bar"}}
+	assert.Equal(t, data.Entries, Merge(data, nil))
+	entries := Merge(data, &Overrides{
+		Add:     Entries{"baz": "Baz", "excluded": ""},
+		Exclude: map[string]struct{}{"foo": {}, "excluded": {}},
+	})
+	assert.Equal(t, Entries{"bar": "Bar", "baz": "Baz"}, entries)
+	assert.Len(t, data.Entries, 2)
+}
@@ -0,0 +1,201 @@
+package referrer
+
+import (
+	"github.com/pirsch-analytics/pirsch/v6/pkg/tracker/lists"
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	androidAppPrefix = "android-app://"
+	iosAppPrefix     = "ios-app://"
+)
+
+// AppPlatform is the platform of an app referrer.
+type AppPlatform string
+
+const (
+	// AppAndroid is an Android app referrer (android-app://<package name>).
+	AppAndroid = AppPlatform("android")
+
+	// AppIOS is an iOS app referrer (ios-app://<App Store ID>).
+	AppIOS = AppPlatform("ios")
+
+	// AppScheme is an app referrer using a custom URL scheme (<scheme>://).
+	// Schemes used by browsers and protocols other than apps, like ftp:// or file://, are not considered apps.
+	AppScheme = AppPlatform("scheme")
+)
+
+var (
+	isScheme    = regexp.MustCompile("^[a-z][a-z0-9+.-]*$")
+	appResolver atomic.Pointer[appResolverHolder]
+
+	// nonAppSchemes are URL schemes used by browsers and protocols other than apps.
+	nonAppSchemes = map[string]struct{}{
+		"http":                 {},
+		"https":                {},
+		"ftp":                  {},
+		"ftps":                 {},
+		"sftp":                 {},
+		"file":                 {},
+		"ws":                   {},
+		"wss":                  {},
+		"data":                 {},
+		"blob":                 {},
+		"about":                {},
+		"javascript":           {},
+		"view-source":          {},
+		"chrome":               {},
+		"chrome-extension":     {},
+		"moz-extension":        {},
+		"safari-extension":     {},
+		"safari-web-extension": {},
+		"edge":                 {},
+		"extension":            {},
+		"content":              {},
+	}
+)
+
+// App is the name and icon of an app.
+type App struct {
+	Name string
+	Icon string
+}
+
+// AppResolver looks up the App for an app referrer.
+// Implementations must not block, as Resolve is called for every request.
+type AppResolver interface {
+	// Resolve returns the App for given platform and ID (package name, App Store ID, or URL scheme)
+	// and whether it is known.
+	Resolve(AppPlatform, string) (App, bool)
+}
+
+type appResolverHolder struct {
+	resolver AppResolver
+}
+
+// AppResolvers tries multiple AppResolvers in order and returns the first result found.
+type AppResolvers []AppResolver
+
+// Resolve implements the AppResolver interface.
+func (resolvers AppResolvers) Resolve(platform AppPlatform, id string) (App, bool) {
+	for _, resolver := range resolvers {
+		if app, found := resolver.Resolve(platform, id); found {
+			return app, true
+		}
+	}
+
+	return App{}, false
+}
+
+// StaticAppResolver resolves apps from a list mapping app referrers to the name and icon.
+// Keys are the app referrer without a path (like android-app://com.slack, ios-app://618783545, or slack://),
+// values are the name, optionally followed by a tab and the icon URL.
+type StaticAppResolver struct {
+	list *lists.List
+}
+
+// NewStaticAppResolver creates a new StaticAppResolver for given list.
+func NewStaticAppResolver(list *lists.List) *StaticAppResolver {
+	return &StaticAppResolver{list}
+}
+
+// LoadStaticAppResolver creates a new StaticAppResolver for the list file at given path.
+func LoadStaticAppResolver(path string) (*StaticAppResolver, error) {
+	data, err := lists.ParseFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewStaticAppResolver(lists.New(data)), nil
+}
+
+// Resolve implements the AppResolver interface.
+func (resolver *StaticAppResolver) Resolve(platform AppPlatform, id string) (App, bool) {
+	value, found := resolver.list.Get(0, appKey(platform, id))
+
+	if !found || value == "" {
+		return App{}, false
+	}
+
+	name, icon, _ := strings.Cut(value, "\t")
+	return App{
+		Name: strings.TrimSpace(name),
+		Icon: strings.TrimSpace(icon),
+	}, true
+}
+
+// SetAppResolver sets the AppResolver used to look up app referrers.
+// Passing nil restores the default, which resolves apps from the Apps list.
+func SetAppResolver(resolver AppResolver) {
+	if resolver == nil {
+		resolver = NewStaticAppResolver(Apps)
+	}
+
+	appResolver.Store(&appResolverHolder{resolver})
+}
+
+func getAppResolver() AppResolver {
+	holder := appResolver.Load()
+
+	if holder == nil {
+		return NewStaticAppResolver(Apps)
+	}
+
+	return holder.resolver
+}
+
+// parseApp returns the platform and ID for app referrers.
+func parseApp(referrer string) (AppPlatform, string, bool) {
+	referrer = strings.ToLower(strings.TrimSpace(referrer))
+	var platform AppPlatform
+	var id string
+
+	if strings.HasPrefix(referrer, androidAppPrefix) {
+		platform = AppAndroid
+		id = appID(referrer[len(androidAppPrefix):])
+	} else if strings.HasPrefix(referrer, iosAppPrefix) {
+		platform = AppIOS
+		id = appID(referrer[len(iosAppPrefix):])
+	} else {
+		scheme, _, found := strings.Cut(referrer, "://")
+
+		if !found || !isScheme.MatchString(scheme) {
+			return "", "", false
+		}
+
+		if _, nonApp := nonAppSchemes[scheme]; nonApp {
+			return "", "", false
+		}
+
+		platform = AppScheme
+		id = scheme
+	}
+
+	if id == "" {
+		return "", "", false
+	}
+
+	return platform, id, true
+}
+
+func appID(path string) string {
+	if i := strings.IndexAny(path, "/?#"); i > -1 {
+		return path[:i]
+	}
+
+	return path
+}
+
+func appKey(platform AppPlatform, id string) string {
+	switch platform {
+	case AppAndroid:
+		return androidAppPrefix + id
+	case AppIOS:
+		return iosAppPrefix + id
+	default:
+		return id + "://"
+	}
+}
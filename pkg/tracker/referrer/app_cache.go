@@ -0,0 +1,174 @@
+package referrer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	defaultAppCacheMaxSize = 10_000
+	defaultAppCacheMaxAge  = time.Hour * 24 * 7
+)
+
+// AppCache is a persistent cache for resolved apps.
+// Apps that could not be found are cached as well, so that they aren't looked up again until they expire.
+type AppCache struct {
+	path    string
+	maxSize int
+	maxAge  time.Duration
+	apps    map[string]cachedApp
+	changed bool
+	m       sync.RWMutex
+}
+
+type cachedApp struct {
+	Name string    `json:"name,omitempty"`
+	Icon string    `json:"icon,omitempty"`
+	Time time.Time `json:"time"`
+}
+
+// NewAppCache creates a new AppCache for up to maxSize apps, which expire after maxAge.
+// The cache is restored from the file at given path if it exists and written to it on Save.
+// Pass an empty path to keep the cache in memory only.
+// The size defaults to 10,000 and the max age to 7 days.
+func NewAppCache(path string, maxSize int, maxAge time.Duration) (*AppCache, error) {
+	if maxSize < 1 {
+		maxSize = defaultAppCacheMaxSize
+	}
+
+	if maxAge <= 0 {
+		maxAge = defaultAppCacheMaxAge
+	}
+
+	cache := &AppCache{
+		path:    path,
+		maxSize: maxSize,
+		maxAge:  maxAge,
+		apps:    make(map[string]cachedApp),
+	}
+
+	if path != "" {
+		content, err := os.ReadFile(path)
+
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+
+		if len(content) > 0 {
+			if err := json.Unmarshal(content, &cache.apps); err != nil {
+				return nil, err
+			}
+
+			cache.removeExpired(time.Now().UTC())
+		}
+	}
+
+	return cache, nil
+}
+
+// Get returns the App for given platform and ID and whether it is cached.
+// Apps that could not be found are returned with an empty name.
+func (cache *AppCache) Get(platform AppPlatform, id string) (App, bool) {
+	cache.m.RLock()
+	app, found := cache.apps[appKey(platform, id)]
+	cache.m.RUnlock()
+
+	if !found || time.Since(app.Time) > cache.maxAge {
+		return App{}, false
+	}
+
+	return App{Name: app.Name, Icon: app.Icon}, true
+}
+
+// Set caches the App for given platform and ID.
+// Set an App with an empty name for apps that could not be found.
+func (cache *AppCache) Set(platform AppPlatform, id string, app App) {
+	cache.m.Lock()
+	defer cache.m.Unlock()
+	now := time.Now().UTC()
+
+	if len(cache.apps) >= cache.maxSize {
+		cache.removeExpired(now)
+
+		if len(cache.apps) >= cache.maxSize {
+			cache.apps = make(map[string]cachedApp)
+		}
+	}
+
+	cache.apps[appKey(platform, id)] = cachedApp{
+		Name: app.Name,
+		Icon: app.Icon,
+		Time: now,
+	}
+	cache.changed = true
+}
+
+// Resolve implements the AppResolver interface.
+func (cache *AppCache) Resolve(platform AppPlatform, id string) (App, bool) {
+	app, found := cache.Get(platform, id)
+	return app, found && app.Name != ""
+}
+
+// Len returns the number of cached apps.
+func (cache *AppCache) Len() int {
+	cache.m.RLock()
+	defer cache.m.RUnlock()
+	return len(cache.apps)
+}
+
+// Save writes the cache to disk if it has been changed since it was last saved.
+// The cache is written to a temporary file first and then moved to the path, so that the file is never incomplete.
+func (cache *AppCache) Save() error {
+	if cache.path == "" {
+		return nil
+	}
+
+	cache.m.Lock()
+	defer cache.m.Unlock()
+
+	if !cache.changed {
+		return nil
+	}
+
+	content, err := json.Marshal(cache.apps)
+
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(cache.path), filepath.Base(cache.path)+".tmp*")
+
+	if err != nil {
+		return err
+	}
+
+	tmp := f.Name()
+	defer os.Remove(tmp)
+
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp, cache.path); err != nil {
+		return err
+	}
+
+	cache.changed = false
+	return nil
+}
+
+func (cache *AppCache) removeExpired(now time.Time) {
+	for key, app := range cache.apps {
+		if now.Sub(app.Time) > cache.maxAge {
+			delete(cache.apps, key)
+		}
+	}
+}
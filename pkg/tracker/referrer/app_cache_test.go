@@ -0,0 +1,55 @@
+package referrer
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAppCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "apps.json")
+	cache, err := NewAppCache(path, 0, 0)
+	assert.NoError(t, err)
+	cache.Set(AppAndroid, "com.slack", App{Name: "Slack", Icon: "icon"})
+	cache.Set(AppIOS, "1", App{})
+	app, found := cache.Get(AppAndroid, "com.slack")
+	assert.True(t, found)
+	assert.Equal(t, App{Name: "Slack", Icon: "icon"}, app)
+	app, found = cache.Get(AppIOS, "1")
+	assert.True(t, found)
+	assert.Empty(t, app.Name)
+	_, found = cache.Resolve(AppIOS, "1")
+	assert.False(t, found)
+	_, found = cache.Get(AppAndroid, "com.pinterest")
+	assert.False(t, found)
+	assert.NoError(t, cache.Save())
+	cache, err = NewAppCache(path, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, cache.Len())
+	app, found = cache.Resolve(AppAndroid, "com.slack")
+	assert.True(t, found)
+	assert.Equal(t, "Slack", app.Name)
+	cache, err = NewAppCache(filepath.Join(t.TempDir(), "missing.json"), 0, 0)
+	assert.NoError(t, err)
+	assert.Zero(t, cache.Len())
+	assert.NoError(t, os.WriteFile(path, []byte("invalid"), 0644))
+	_, err = NewAppCache(path, 0, 0)
+	assert.Error(t, err)
+}
+
+func TestAppCacheMaxAge(t *testing.T) {
+	cache, err := NewAppCache("", 2, time.Millisecond*20)
+	assert.NoError(t, err)
+	cache.Set(AppScheme, "a", App{Name: "A"})
+	cache.Set(AppScheme, "b", App{Name: "B"})
+	time.Sleep(time.Millisecond * 30)
+	_, found := cache.Get(AppScheme, "a")
+	assert.False(t, found)
+	cache.Set(AppScheme, "c", App{Name: "C"})
+	assert.Equal(t, 1, cache.Len())
+	_, found = cache.Get(AppScheme, "c")
+	assert.True(t, found)
+	assert.NoError(t, cache.Save())
+}
@@ -0,0 +1,372 @@
+package referrer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"golang.org/x/net/html"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	googlePlayStoreURL            = "https://play.google.com/store/apps/details?id=%s"
+	appleAppStoreURL              = "https://itunes.apple.com/lookup?id=%s"
+	defaultAppScraperWorker       = 2
+	defaultAppScraperQueueSize    = 1000
+	defaultAppScraperTimeout      = time.Second * 10
+	defaultAppScraperSaveInterval = time.Minute * 5
+)
+
+// AppScraperConfig is the optional configuration for the AppScraper.
+type AppScraperConfig struct {
+	// Cache stores the scraped apps. Defaults to an in-memory AppCache.
+	Cache *AppCache
+
+	// Worker is the number of concurrent lookups. Defaults to 2.
+	Worker int
+
+	// QueueSize is the number of apps waiting to be looked up. Apps are dropped while the queue is full. Defaults to 1000.
+	QueueSize int
+
+	// Timeout is the timeout for a single lookup. Defaults to 10 seconds.
+	Timeout time.Duration
+
+	// SaveInterval is the interval in which the cache is written to disk. Defaults to 5 minutes.
+	SaveInterval time.Duration
+
+	// PlayStoreURL is the format string for Google Play Store app pages. The package name is inserted for %s.
+	PlayStoreURL string
+
+	// AppStoreURL is the format string for the Apple App Store lookup API. The App Store ID is inserted for %s.
+	AppStoreURL string
+
+	// Logger is the log/slog.Logger used to log errors. Defaults to slog.Default.
+	Logger *slog.Logger
+}
+
+func (config *AppScraperConfig) validate() {
+	if config.Worker < 1 {
+		config.Worker = defaultAppScraperWorker
+	}
+
+	if config.QueueSize < 1 {
+		config.QueueSize = defaultAppScraperQueueSize
+	}
+
+	if config.Timeout <= 0 {
+		config.Timeout = defaultAppScraperTimeout
+	}
+
+	if config.SaveInterval <= 0 {
+		config.SaveInterval = defaultAppScraperSaveInterval
+	}
+
+	if config.PlayStoreURL == "" {
+		config.PlayStoreURL = googlePlayStoreURL
+	}
+
+	if config.AppStoreURL == "" {
+		config.AppStoreURL = appleAppStoreURL
+	}
+
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+}
+
+// AppScraper resolves Android apps by scraping the Google Play Store and iOS apps using the Apple App Store lookup API.
+// Lookups run in the background, so Resolve never blocks and returns apps once they have been looked up.
+// Apps using a custom URL scheme cannot be scraped.
+type AppScraper struct {
+	config  AppScraperConfig
+	cache   *AppCache
+	client  *http.Client
+	queue   chan appRequest
+	pending map[appRequest]struct{}
+	m       sync.Mutex
+	cancel  context.CancelFunc
+	done    sync.WaitGroup
+}
+
+type appRequest struct {
+	platform AppPlatform
+	id       string
+}
+
+type appStoreLookup struct {
+	Results []struct {
+		TrackName     string `json:"trackName"`
+		ArtworkURL100 string `json:"artworkUrl100"`
+	} `json:"results"`
+}
+
+// NewAppScraper creates a new AppScraper and starts looking up apps in the background.
+// Call Stop to stop the scraper and save the cache.
+func NewAppScraper(config AppScraperConfig) *AppScraper {
+	config.validate()
+
+	if config.Cache == nil {
+		config.Cache, _ = NewAppCache("", 0, 0)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scraper := &AppScraper{
+		config:  config,
+		cache:   config.Cache,
+		client:  &http.Client{Timeout: config.Timeout},
+		queue:   make(chan appRequest, config.QueueSize),
+		pending: make(map[appRequest]struct{}),
+		cancel:  cancel,
+	}
+	scraper.done.Add(config.Worker + 1)
+
+	for i := 0; i < config.Worker; i++ {
+		go scraper.work(ctx)
+	}
+
+	go scraper.save(ctx)
+	return scraper
+}
+
+// Resolve implements the AppResolver interface.
+// Apps not found in the cache are queued to be looked up and reported as unknown.
+func (scraper *AppScraper) Resolve(platform AppPlatform, id string) (App, bool) {
+	if app, found := scraper.cache.Get(platform, id); found {
+		return app, app.Name != ""
+	}
+
+	if platform == AppAndroid || platform == AppIOS {
+		scraper.enqueue(appRequest{platform, id})
+	}
+
+	return App{}, false
+}
+
+// Stop stops the background lookups and writes the cache to disk.
+func (scraper *AppScraper) Stop() error {
+	scraper.cancel()
+	scraper.done.Wait()
+	return scraper.cache.Save()
+}
+
+func (scraper *AppScraper) enqueue(req appRequest) {
+	scraper.m.Lock()
+	defer scraper.m.Unlock()
+
+	if _, found := scraper.pending[req]; found {
+		return
+	}
+
+	select {
+	case scraper.queue <- req:
+		scraper.pending[req] = struct{}{}
+	default:
+		// drop the request, it will be queued again on the next lookup
+	}
+}
+
+func (scraper *AppScraper) work(ctx context.Context) {
+	defer scraper.done.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case req := <-scraper.queue:
+			var app App
+			var err error
+
+			if req.platform == AppAndroid {
+				app, err = scraper.scrapePlayStore(ctx, req.id)
+			} else {
+				app, err = scraper.lookupAppStore(ctx, req.id)
+			}
+
+			// apps that don't exist are cached with an empty name, so that they aren't looked up again,
+			// while failed lookups are retried on the next request
+			if err != nil {
+				scraper.config.Logger.Debug("error looking up app", "platform", req.platform, "id", req.id, "err", err)
+			} else {
+				scraper.cache.Set(req.platform, req.id, app)
+			}
+
+			scraper.m.Lock()
+			delete(scraper.pending, req)
+			scraper.m.Unlock()
+		}
+	}
+}
+
+func (scraper *AppScraper) save(ctx context.Context) {
+	defer scraper.done.Done()
+	ticker := time.NewTicker(scraper.config.SaveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := scraper.cache.Save(); err != nil {
+				scraper.config.Logger.Error("error saving app cache", "err", err)
+			}
+		}
+	}
+}
+
+// get requests given URL and returns the response for status 200, nil for status 404, or an error otherwise.
+func (scraper *AppScraper) get(ctx context.Context, u string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := scraper.client.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		_ = resp.Body.Close()
+		return nil, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	return resp, nil
+}
+
+func (scraper *AppScraper) scrapePlayStore(ctx context.Context, packageName string) (App, error) {
+	resp, err := scraper.get(ctx, fmt.Sprintf(scraper.config.PlayStoreURL, url.QueryEscape(packageName)))
+
+	if err != nil || resp == nil {
+		return App{}, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	doc, err := html.Parse(resp.Body)
+
+	if err != nil {
+		return App{}, err
+	}
+
+	titleNode := findName(doc)
+
+	if titleNode == nil {
+		return App{}, nil
+	}
+
+	appName := findTextNode(titleNode)
+
+	if appName == nil {
+		return App{}, nil
+	}
+
+	icon := ""
+	iconNode := findIcon(doc)
+
+	if iconNode != nil {
+		icon = getHTMLAttribute(iconNode, "src")
+	}
+
+	return App{Name: appName.Data, Icon: icon}, nil
+}
+
+func (scraper *AppScraper) lookupAppStore(ctx context.Context, id string) (App, error) {
+	resp, err := scraper.get(ctx, fmt.Sprintf(scraper.config.AppStoreURL, url.QueryEscape(id)))
+
+	if err != nil || resp == nil {
+		return App{}, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	var lookup appStoreLookup
+
+	if err := json.NewDecoder(resp.Body).Decode(&lookup); err != nil {
+		return App{}, err
+	}
+
+	if len(lookup.Results) == 0 || lookup.Results[0].TrackName == "" {
+		return App{}, nil
+	}
+
+	return App{
+		Name: lookup.Results[0].TrackName,
+		Icon: lookup.Results[0].ArtworkURL100,
+	}, nil
+}
+
+func findName(node *html.Node) *html.Node {
+	if node.Type == html.ElementNode && node.Data == "h1" {
+		return node
+	}
+
+	for c := node.FirstChild; c != nil; c = c.NextSibling {
+		if n := findName(c); n != nil {
+			return n
+		}
+	}
+
+	return nil
+}
+
+func findIcon(node *html.Node) *html.Node {
+	if node.Type == html.ElementNode && node.Data == "img" && hasHTMLAttribute(node, "itemprop", "image") {
+		return node
+	}
+
+	for c := node.FirstChild; c != nil; c = c.NextSibling {
+		if n := findIcon(c); n != nil {
+			return n
+		}
+	}
+
+	return nil
+}
+
+func findTextNode(node *html.Node) *html.Node {
+	if node.Type == html.TextNode {
+		return node
+	}
+
+	for c := node.FirstChild; c != nil; c = c.NextSibling {
+		if n := findTextNode(c); n != nil {
+			return n
+		}
+	}
+
+	return nil
+}
+
+func hasHTMLAttribute(node *html.Node, key, value string) bool {
+	for _, attr := range node.Attr {
+		if attr.Key == key && attr.Val == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getHTMLAttribute(node *html.Node, key string) string {
+	for _, attr := range node.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,93 @@
+package referrer
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAppScraper(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/play":
+			if r.URL.Query().Get("id") == "com.slack" {
+				_, _ = fmt.Fprint(w, `<html><body><img itemprop="image" src="https://example.com/slack.png"><h1><span>Slack</span></h1></body></html>`)
+				return
+			}
+		case "/lookup":
+			if r.URL.Query().Get("id") == "618783545" {
+				_, _ = fmt.Fprint(w, `{"resultCount":1,"results":[{"trackName":"Slack","artworkUrl100":"https://example.com/slack-ios.png"}]}`)
+			} else {
+				_, _ = fmt.Fprint(w, `{"resultCount":0,"results":[]}`)
+			}
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	scraper := NewAppScraper(AppScraperConfig{
+		PlayStoreURL: server.URL + "/play?id=%s",
+		AppStoreURL:  server.URL + "/lookup?id=%s",
+	})
+	var wg sync.WaitGroup
+	wg.Add(100)
+
+	for i := 0; i < 100; i++ {
+		go func() {
+			scraper.Resolve(AppAndroid, "com.slack")
+			scraper.Resolve(AppIOS, "618783545")
+			scraper.Resolve(AppAndroid, "does-not-exist")
+			scraper.Resolve(AppIOS, "1")
+			wg.Done()
+		}()
+	}
+
+	wg.Wait()
+	assert.Eventually(t, func() bool {
+		return scraper.cache.Len() == 4
+	}, time.Second*5, time.Millisecond*10)
+	app, found := scraper.Resolve(AppAndroid, "com.slack")
+	assert.True(t, found)
+	assert.Equal(t, "Slack", app.Name)
+	assert.Equal(t, "https://example.com/slack.png", app.Icon)
+	app, found = scraper.Resolve(AppIOS, "618783545")
+	assert.True(t, found)
+	assert.Equal(t, "Slack", app.Name)
+	assert.Equal(t, "https://example.com/slack-ios.png", app.Icon)
+	_, found = scraper.Resolve(AppAndroid, "does-not-exist")
+	assert.False(t, found)
+	_, found = scraper.Resolve(AppIOS, "1")
+	assert.False(t, found)
+	_, found = scraper.Resolve(AppScheme, "slack")
+	assert.False(t, found)
+	assert.NoError(t, scraper.Stop())
+	assert.Equal(t, 4, scraper.cache.Len())
+}
+
+func TestAppScraperOffline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	scraper := NewAppScraper(AppScraperConfig{
+		PlayStoreURL: server.URL + "/play?id=%s",
+		AppStoreURL:  server.URL + "/lookup?id=%s",
+	})
+	start := time.Now()
+	_, found := scraper.Resolve(AppAndroid, "com.slack")
+	assert.False(t, found)
+	assert.Less(t, time.Since(start), time.Millisecond*100)
+	assert.Eventually(t, func() bool {
+		scraper.m.Lock()
+		defer scraper.m.Unlock()
+		return len(scraper.pending) == 0
+	}, time.Second*5, time.Millisecond*10)
+	assert.Zero(t, scraper.cache.Len())
+	assert.NoError(t, scraper.Stop())
+}
@@ -0,0 +1,117 @@
+package referrer
+
+import (
+	"github.com/pirsch-analytics/pirsch/v6/pkg/tracker/lists"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseApp(t *testing.T) {
+	input := []string{
+		"android-app://com.Slack",
+		"android-app://com.google.android.gm/",
+		"android-app://com.google.android.googlequicksearchbox/https/www.google.com",
+		"ios-app://618783545/slack/open",
+		"slack://open?team=123",
+		"fb-messenger://",
+		"android-app://",
+		"https://example.com",
+		"HTTP://example.com",
+		"example.com",
+		"ReferrerName",
+		"1invalid://",
+		"ftp://example.com/file.txt",
+		"file:///home/user/index.html",
+		"chrome-extension://abcdef/popup.html",
+	}
+	expected := []struct {
+		platform AppPlatform
+		id       string
+		ok       bool
+	}{
+		{AppAndroid, "com.slack", true},
+		{AppAndroid, "com.google.android.gm", true},
+		{AppAndroid, "com.google.android.googlequicksearchbox", true},
+		{AppIOS, "618783545", true},
+		{AppScheme, "slack", true},
+		{AppScheme, "fb-messenger", true},
+		{"", "", false},
+		{"", "", false},
+		{"", "", false},
+		{"", "", false},
+		{"", "", false},
+		{"", "", false},
+		{"", "", false},
+		{"", "", false},
+		{"", "", false},
+	}
+
+	for i, in := range input {
+		platform, id, ok := parseApp(in)
+		assert.Equal(t, expected[i].platform, platform, in)
+		assert.Equal(t, expected[i].id, id, in)
+		assert.Equal(t, expected[i].ok, ok, in)
+	}
+}
+
+func TestStaticAppResolver(t *testing.T) {
+	resolver := NewStaticAppResolver(Apps)
+	app, found := resolver.Resolve(AppAndroid, "com.slack")
+	assert.True(t, found)
+	assert.Equal(t, "Slack", app.Name)
+	assert.Empty(t, app.Icon)
+	app, found = resolver.Resolve(AppIOS, "618783545")
+	assert.True(t, found)
+	assert.Equal(t, "Slack", app.Name)
+	app, found = resolver.Resolve(AppScheme, "slack")
+	assert.True(t, found)
+	assert.Equal(t, "Slack", app.Name)
+	_, found = resolver.Resolve(AppAndroid, "does-not-exist")
+	assert.False(t, found)
+	path := filepath.Join(t.TempDir(), "apps.txt")
+	assert.NoError(t, os.WriteFile(path, []byte("android-app://com.example\tExample\thttps://example.com/icon.png\n"), 0644))
+	resolver, err := LoadStaticAppResolver(path)
+	assert.NoError(t, err)
+	app, found = resolver.Resolve(AppAndroid, "com.example")
+	assert.True(t, found)
+	assert.Equal(t, "Example", app.Name)
+	assert.Equal(t, "https://example.com/icon.png", app.Icon)
+	_, found = resolver.Resolve(AppAndroid, "com.slack")
+	assert.False(t, found)
+	_, err = LoadStaticAppResolver(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.Error(t, err)
+}
+
+func TestAppResolvers(t *testing.T) {
+	custom := NewStaticAppResolver(lists.New(lists.MustParse([]byte("slack://\tCustom Slack\nexample://\tExample"))))
+	resolver := AppResolvers{custom, NewStaticAppResolver(Apps)}
+	app, found := resolver.Resolve(AppScheme, "slack")
+	assert.True(t, found)
+	assert.Equal(t, "Custom Slack", app.Name)
+	app, found = resolver.Resolve(AppScheme, "example")
+	assert.True(t, found)
+	assert.Equal(t, "Example", app.Name)
+	app, found = resolver.Resolve(AppAndroid, "com.slack")
+	assert.True(t, found)
+	assert.Equal(t, "Slack", app.Name)
+	_, found = resolver.Resolve(AppAndroid, "does-not-exist")
+	assert.False(t, found)
+}
+
+func TestSetAppResolver(t *testing.T) {
+	defer SetAppResolver(nil)
+	SetAppResolver(NewStaticAppResolver(lists.New(lists.MustParse([]byte("example://\tExample")))))
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Add("Referer", "example://path")
+	ref, name, _ := Get(r, "", "")
+	assert.Equal(t, "example://path", ref)
+	assert.Equal(t, "Example", name)
+	SetAppResolver(nil)
+	ref, name, _ = Get(r, "", "")
+	assert.Equal(t, "example://path", ref)
+	assert.Empty(t, name)
+}
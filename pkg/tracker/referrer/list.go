@@ -1,2513 +1,29 @@
 package referrer
 
+import (
+	_ "embed"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/tracker/lists"
+)
+
 var (
-	groups = map[string]string{
-		"1.cz":                                     "1.cz",
-		"247realmedia.com":                         "AppNexus",
-		"2gis.ru":                                  "2gis",
-		"a.mozo.com.au":                            "Mozo",
-		"abcsolk.no":                               "ABCsøk",
-		"accounts.google.com":                      "Google",
-		"acuityplatform.com":                       "Acuity Ads",
-		"ad-apac.doubleclick.net":                  "Doubleclick",
-		"ad.doubleclick.net":                       "Doubleclick",
-		"adadvisor.net":                            "Neustar AdAdvisor",
-		"adform.net":                               "Adform",
-		"adfox.ru":                                 "ADFOX",
-		"adingo.jp":                                "Fluct",
-		"adition.com":                              "Adition",
-		"adnet.de":                                 "AdNET",
-		"adnxs.com":                                "AppNexus",
-		"adroll.com":                               "AdRoll",
-		"ads.adfox.ru":                             "ADFOX",
-		"ads.google.com":                           "Google Ads",
-		"adspirit.de":                              "AdSpirit",
-		"aim.search.aol.com":                       "AOL",
-		"alexa.com":                                "Alexa",
-		"alicesuche.aol.de":                        "AOL",
-		"alicesuchet.aol.de":                       "AOL",
-		"all.by":                                   "all.by",
-		"altavista.de":                             "Altavista",
-		"altavista.fr":                             "Altavista",
-		"amazon.com":                               "Amazon",
-		"an.yandex.ru":                             "Yandex.Direct",
-		"answers.yahoo.com":                        "Yahoo!",
-		"aolbusqueda.aol.com.mx":                   "AOL",
-		"aolrecherche.aol.fr":                      "AOL",
-		"aolsearch.aol.co.uk":                      "AOL",
-		"aolsearch.aol.com":                        "AOL",
-		"aolsearch.com":                            "AOL",
-		"api.taboola.com":                          "Taboola",
-		"apollo.lv/portal/search/":                 "Apollo Latvia",
-		"apollo7.de":                               "APOLL07",
-		"apontador.com.br":                         "Apontador",
-		"apple.com/maps":                           "Apple Maps",
-		"ar.search.yahoo.com":                      "Yahoo!",
-		"ar.yahoo.com":                             "Yahoo!",
-		"arama.com":                                "arama",
-		"ariadna.elmundo.es":                       "El Mundo",
-		"arianna.libero.it":                        "Arianna",
-		"ask.com":                                  "Ask",
-		"ask.reference.com":                        "Ask",
-		"astrology.yahoo.com":                      "Yahoo!",
-		"at.indeed.com":                            "Indeed",
-		"at.search.yahoo.com":                      "Yahoo",
-		"au.indeed.com":                            "Indeed",
-		"au.search.yahoo.com":                      "Yahoo!",
-		"au.yahoo.com":                             "Yahoo!",
-		"away.vk.com":                              "Vkontakte",
-		"badoo.com":                                "Badoo",
-		"baidu.com":                                "Baidu ",
-		"basic.messaging.bigpond.com":              "Bigpond",
-		"be-fr.altavista.com":                      "Altavista",
-		"be-nl.altavista.com":                      "Altavista",
-		"be.gaultmillau.com":                       "Gault&Milau",
-		"bebo.com":                                 "Bebo",
-		"bidswitch.net":                            "BidSwitch",
-		"bing.com":                                 "Bing",
-		"bing.com/images/search":                   "Bing Images",
-		"blackplanet.com":                          "BlackPlanet",
-		"blekko.com":                               "blekko",
-		"blogs.icerocket.com":                      "Icerockeet",
-		"blogsearch.google.ac":                     "Google Blogsearch",
-		"blogsearch.google.ad":                     "Google Blogsearch",
-		"blogsearch.google.ae":                     "Google Blogsearch",
-		"blogsearch.google.am":                     "Google Blogsearch",
-		"blogsearch.google.as":                     "Google Blogsearch",
-		"blogsearch.google.at":                     "Google Blogsearch",
-		"blogsearch.google.az":                     "Google Blogsearch",
-		"blogsearch.google.ba":                     "Google Blogsearch",
-		"blogsearch.google.be":                     "Google Blogsearch",
-		"blogsearch.google.bf":                     "Google Blogsearch",
-		"blogsearch.google.bg":                     "Google Blogsearch",
-		"blogsearch.google.bi":                     "Google Blogsearch",
-		"blogsearch.google.bj":                     "Google Blogsearch",
-		"blogsearch.google.bs":                     "Google Blogsearch",
-		"blogsearch.google.by":                     "Google Blogsearch",
-		"blogsearch.google.ca":                     "Google Blogsearch",
-		"blogsearch.google.cat":                    "Google Blogsearch",
-		"blogsearch.google.cc":                     "Google Blogsearch",
-		"blogsearch.google.cd":                     "Google Blogsearch",
-		"blogsearch.google.cf":                     "Google Blogsearch",
-		"blogsearch.google.cg":                     "Google Blogsearch",
-		"blogsearch.google.ch":                     "Google Blogsearch",
-		"blogsearch.google.ci":                     "Google Blogsearch",
-		"blogsearch.google.cl":                     "Google Blogsearch",
-		"blogsearch.google.cm":                     "Google Blogsearch",
-		"blogsearch.google.cn":                     "Google Blogsearch",
-		"blogsearch.google.co.bw":                  "Google Blogsearch",
-		"blogsearch.google.co.ck":                  "Google Blogsearch",
-		"blogsearch.google.co.cr":                  "Google Blogsearch",
-		"blogsearch.google.co.id":                  "Google Blogsearch",
-		"blogsearch.google.co.il":                  "Google Blogsearch",
-		"blogsearch.google.co.in":                  "Google Blogsearch",
-		"blogsearch.google.co.jp":                  "Google Blogsearch",
-		"blogsearch.google.co.ke":                  "Google Blogsearch",
-		"blogsearch.google.co.kr":                  "Google Blogsearch",
-		"blogsearch.google.co.ls":                  "Google Blogsearch",
-		"blogsearch.google.co.ma":                  "Google Blogsearch",
-		"blogsearch.google.co.mz":                  "Google Blogsearch",
-		"blogsearch.google.co.nz":                  "Google Blogsearch",
-		"blogsearch.google.co.th":                  "Google Blogsearch",
-		"blogsearch.google.co.tz":                  "Google Blogsearch",
-		"blogsearch.google.co.ug":                  "Google Blogsearch",
-		"blogsearch.google.co.uk":                  "Google Blogsearch",
-		"blogsearch.google.co.uz":                  "Google Blogsearch",
-		"blogsearch.google.co.ve":                  "Google Blogsearch",
-		"blogsearch.google.co.vi":                  "Google Blogsearch",
-		"blogsearch.google.co.za":                  "Google Blogsearch",
-		"blogsearch.google.co.zm":                  "Google Blogsearch",
-		"blogsearch.google.co.zw":                  "Google Blogsearch",
-		"blogsearch.google.com":                    "Google Blogsearch",
-		"blogsearch.google.com.af":                 "Google Blogsearch",
-		"blogsearch.google.com.ag":                 "Google Blogsearch",
-		"blogsearch.google.com.ai":                 "Google Blogsearch",
-		"blogsearch.google.com.ar":                 "Google Blogsearch",
-		"blogsearch.google.com.au":                 "Google Blogsearch",
-		"blogsearch.google.com.bd":                 "Google Blogsearch",
-		"blogsearch.google.com.bh":                 "Google Blogsearch",
-		"blogsearch.google.com.bn":                 "Google Blogsearch",
-		"blogsearch.google.com.bo":                 "Google Blogsearch",
-		"blogsearch.google.com.br":                 "Google Blogsearch",
-		"blogsearch.google.com.by":                 "Google Blogsearch",
-		"blogsearch.google.com.bz":                 "Google Blogsearch",
-		"blogsearch.google.com.co":                 "Google Blogsearch",
-		"blogsearch.google.com.cu":                 "Google Blogsearch",
-		"blogsearch.google.com.cy":                 "Google Blogsearch",
-		"blogsearch.google.com.do":                 "Google Blogsearch",
-		"blogsearch.google.com.ec":                 "Google Blogsearch",
-		"blogsearch.google.com.eg":                 "Google Blogsearch",
-		"blogsearch.google.com.et":                 "Google Blogsearch",
-		"blogsearch.google.com.fj":                 "Google Blogsearch",
-		"blogsearch.google.com.gh":                 "Google Blogsearch",
-		"blogsearch.google.com.gi":                 "Google Blogsearch",
-		"blogsearch.google.com.gt":                 "Google Blogsearch",
-		"blogsearch.google.com.hk":                 "Google Blogsearch",
-		"blogsearch.google.com.jm":                 "Google Blogsearch",
-		"blogsearch.google.com.kh":                 "Google Blogsearch",
-		"blogsearch.google.com.kw":                 "Google Blogsearch",
-		"blogsearch.google.com.lb":                 "Google Blogsearch",
-		"blogsearch.google.com.lc":                 "Google Blogsearch",
-		"blogsearch.google.com.ly":                 "Google Blogsearch",
-		"blogsearch.google.com.mt":                 "Google Blogsearch",
-		"blogsearch.google.com.mx":                 "Google Blogsearch",
-		"blogsearch.google.com.my":                 "Google Blogsearch",
-		"blogsearch.google.com.na":                 "Google Blogsearch",
-		"blogsearch.google.com.nf":                 "Google Blogsearch",
-		"blogsearch.google.com.ng":                 "Google Blogsearch",
-		"blogsearch.google.com.ni":                 "Google Blogsearch",
-		"blogsearch.google.com.np":                 "Google Blogsearch",
-		"blogsearch.google.com.om":                 "Google Blogsearch",
-		"blogsearch.google.com.pa":                 "Google Blogsearch",
-		"blogsearch.google.com.pe":                 "Google Blogsearch",
-		"blogsearch.google.com.ph":                 "Google Blogsearch",
-		"blogsearch.google.com.pk":                 "Google Blogsearch",
-		"blogsearch.google.com.pr":                 "Google Blogsearch",
-		"blogsearch.google.com.py":                 "Google Blogsearch",
-		"blogsearch.google.com.qa":                 "Google Blogsearch",
-		"blogsearch.google.com.sa":                 "Google Blogsearch",
-		"blogsearch.google.com.sb":                 "Google Blogsearch",
-		"blogsearch.google.com.sg":                 "Google Blogsearch",
-		"blogsearch.google.com.sl":                 "Google Blogsearch",
-		"blogsearch.google.com.sv":                 "Google Blogsearch",
-		"blogsearch.google.com.tj":                 "Google Blogsearch",
-		"blogsearch.google.com.tn":                 "Google Blogsearch",
-		"blogsearch.google.com.tr":                 "Google Blogsearch",
-		"blogsearch.google.com.tw":                 "Google Blogsearch",
-		"blogsearch.google.com.ua":                 "Google Blogsearch",
-		"blogsearch.google.com.uy":                 "Google Blogsearch",
-		"blogsearch.google.com.vc":                 "Google Blogsearch",
-		"blogsearch.google.com.vn":                 "Google Blogsearch",
-		"blogsearch.google.cv":                     "Google Blogsearch",
-		"blogsearch.google.cz":                     "Google Blogsearch",
-		"blogsearch.google.de":                     "Google Blogsearch",
-		"blogsearch.google.dj":                     "Google Blogsearch",
-		"blogsearch.google.dk":                     "Google Blogsearch",
-		"blogsearch.google.dm":                     "Google Blogsearch",
-		"blogsearch.google.dz":                     "Google Blogsearch",
-		"blogsearch.google.ee":                     "Google Blogsearch",
-		"blogsearch.google.es":                     "Google Blogsearch",
-		"blogsearch.google.fi":                     "Google Blogsearch",
-		"blogsearch.google.fm":                     "Google Blogsearch",
-		"blogsearch.google.fr":                     "Google Blogsearch",
-		"blogsearch.google.ga":                     "Google Blogsearch",
-		"blogsearch.google.gd":                     "Google Blogsearch",
-		"blogsearch.google.ge":                     "Google Blogsearch",
-		"blogsearch.google.gf":                     "Google Blogsearch",
-		"blogsearch.google.gg":                     "Google Blogsearch",
-		"blogsearch.google.gl":                     "Google Blogsearch",
-		"blogsearch.google.gm":                     "Google Blogsearch",
-		"blogsearch.google.gp":                     "Google Blogsearch",
-		"blogsearch.google.gr":                     "Google Blogsearch",
-		"blogsearch.google.gy":                     "Google Blogsearch",
-		"blogsearch.google.hn":                     "Google Blogsearch",
-		"blogsearch.google.hr":                     "Google Blogsearch",
-		"blogsearch.google.ht":                     "Google Blogsearch",
-		"blogsearch.google.hu":                     "Google Blogsearch",
-		"blogsearch.google.ie":                     "Google Blogsearch",
-		"blogsearch.google.im":                     "Google Blogsearch",
-		"blogsearch.google.io":                     "Google Blogsearch",
-		"blogsearch.google.iq":                     "Google Blogsearch",
-		"blogsearch.google.is":                     "Google Blogsearch",
-		"blogsearch.google.it":                     "Google Blogsearch",
-		"blogsearch.google.it.ao":                  "Google Blogsearch",
-		"blogsearch.google.je":                     "Google Blogsearch",
-		"blogsearch.google.jo":                     "Google Blogsearch",
-		"blogsearch.google.kg":                     "Google Blogsearch",
-		"blogsearch.google.ki":                     "Google Blogsearch",
-		"blogsearch.google.kz":                     "Google Blogsearch",
-		"blogsearch.google.la":                     "Google Blogsearch",
-		"blogsearch.google.li":                     "Google Blogsearch",
-		"blogsearch.google.lk":                     "Google Blogsearch",
-		"blogsearch.google.lt":                     "Google Blogsearch",
-		"blogsearch.google.lu":                     "Google Blogsearch",
-		"blogsearch.google.lv":                     "Google Blogsearch",
-		"blogsearch.google.md":                     "Google Blogsearch",
-		"blogsearch.google.me":                     "Google Blogsearch",
-		"blogsearch.google.mg":                     "Google Blogsearch",
-		"blogsearch.google.mk":                     "Google Blogsearch",
-		"blogsearch.google.ml":                     "Google Blogsearch",
-		"blogsearch.google.mn":                     "Google Blogsearch",
-		"blogsearch.google.ms":                     "Google Blogsearch",
-		"blogsearch.google.mu":                     "Google Blogsearch",
-		"blogsearch.google.mv":                     "Google Blogsearch",
-		"blogsearch.google.mw":                     "Google Blogsearch",
-		"blogsearch.google.ne":                     "Google Blogsearch",
-		"blogsearch.google.nl":                     "Google Blogsearch",
-		"blogsearch.google.no":                     "Google Blogsearch",
-		"blogsearch.google.nr":                     "Google Blogsearch",
-		"blogsearch.google.nu":                     "Google Blogsearch",
-		"blogsearch.google.pl":                     "Google Blogsearch",
-		"blogsearch.google.pn":                     "Google Blogsearch",
-		"blogsearch.google.ps":                     "Google Blogsearch",
-		"blogsearch.google.pt":                     "Google Blogsearch",
-		"blogsearch.google.ro":                     "Google Blogsearch",
-		"blogsearch.google.rs":                     "Google Blogsearch",
-		"blogsearch.google.ru":                     "Google Blogsearch",
-		"blogsearch.google.rw":                     "Google Blogsearch",
-		"blogsearch.google.sc":                     "Google Blogsearch",
-		"blogsearch.google.se":                     "Google Blogsearch",
-		"blogsearch.google.sh":                     "Google Blogsearch",
-		"blogsearch.google.si":                     "Google Blogsearch",
-		"blogsearch.google.sk":                     "Google Blogsearch",
-		"blogsearch.google.sm":                     "Google Blogsearch",
-		"blogsearch.google.sn":                     "Google Blogsearch",
-		"blogsearch.google.so":                     "Google Blogsearch",
-		"blogsearch.google.st":                     "Google Blogsearch",
-		"blogsearch.google.td":                     "Google Blogsearch",
-		"blogsearch.google.tg":                     "Google Blogsearch",
-		"blogsearch.google.tk":                     "Google Blogsearch",
-		"blogsearch.google.tl":                     "Google Blogsearch",
-		"blogsearch.google.tm":                     "Google Blogsearch",
-		"blogsearch.google.to":                     "Google Blogsearch",
-		"blogsearch.google.tt":                     "Google Blogsearch",
-		"blogsearch.google.us":                     "Google Blogsearch",
-		"blogsearch.google.vg":                     "Google Blogsearch",
-		"blogsearch.google.vu":                     "Google Blogsearch",
-		"blogsearch.google.ws":                     "Google Blogsearch",
-		"br.search.yahoo.com":                      "Yahoo!",
-		"br.yahoo.com":                             "Yahoo!",
-		"brisbane.t-online.de":                     "T-Online",
-		"bs.serving-sys.com":                       "Sizmek",
-		"busca.orange.es":                          "Orange",
-		"busca.uol.com.br":                         "uol.com.br",
-		"buscador.terra.cl":                        "Terra",
-		"buscador.terra.com.br":                    "Terra",
-		"buscador.terra.es":                        "Terra",
-		"business.facebook.com":                    "Facebook",
-		"buzznet.com":                              "Buzznet",
-		"ca.search.yahoo.com":                      "Yahoo!",
-		"ca.yahoo.com":                             "Yahoo!",
-		"cade.searchde.yahoo.com":                  "Yahoo!",
-		"cade.yahoo.com":                           "Yahoo!",
-		"cars.yahoo.com":                           "Yahoo!",
-		"cas.criteo.com":                           "Criteo",
-		"cas.jp.as.criteo.com":                     "Criteo",
-		"casalemedia.com":                          "Casale Media",
-		"cc.bingj.com":                             "Bing",
-		"cdnx.tribalfusion.com":                    "Tribal Fusion",
-		"cgi.search.biglobe.ne.jp":                 "Biglobe",
-		"ch.indeed.com":                            "Indeed",
-		"chinese.searchinese.yahoo.com":            "Yahoo!",
-		"chinese.yahoo.com":                        "Yahoo!",
-		"class.hit-parade.com":                     "Hit-Parade",
-		"classmates.com":                           "Classmates",
-		"clck.yandex.by":                           "Yandex",
-		"clck.yandex.com":                          "Yandex",
-		"clck.yandex.ru":                           "Yandex",
-		"clck.yandex.ua":                           "Yandex",
-		"clusty.com":                               "InfoSpace",
-		"cn.bing.com":                              "Bing",
-		"cn.search.yahoo.com":                      "Yahoo!",
-		"cn.yahoo.com":                             "Yahoo!",
-		"com.pinterest":                            "Pinterest",
-		"daemon-search.com":                        "Daemon search",
-		"darkoogle.com":                            "Google",
-		"de-de.facebook.com":                       "Facebook",
-		"de.foursquare.com":                        "Foursquare",
-		"de.indeed.com":                            "Indeed",
-		"de.search.yahoo.com":                      "Yahoo!",
-		"de.yahoo.com":                             "Yahoo!",
-		"delicious.com":                            "Delicious",
-		"developers.facebook.com":                  "Facebook",
-		"developers.google.com":                    "Google",
-		"digg.com":                                 "Digg",
-		"dir.gigablast.com":                        "Gigablast",
-		"disq.us":                                  "Disqus",
-		"disqus.com":                               "Disqus",
-		"dizionario.it.msn.com":                    "Bing",
-		"dk.search.yahoo.com":                      "Yahoo!",
-		"dk.yahoo.com":                             "Yahoo!",
-		"dmoz.org":                                 "dmoz",
-		"dogpile.com":                              "InfoSpace",
-		"donanimhaber.com":                         "Donanimhaber",
-		"douban.com":                               "Douban",
-		"dp.g.doubleclick.net":                     "Doubleclick",
-		"drive.google.com":                         "Google",
-		"duckduckgo.com":                           "DuckDuckGo",
-		"ducksports.com":                           "DuckDuckGo",
-		"e.mail.ru":                                "Mail.ru",
-		"ecosia.org":                               "Ecosia",
-		"edgeservices.bing.com":                    "Bing",
-		"editors.dmoz.org":                         "dmoz",
-		"email.seznam.cz":                          "Seznam Mail",
-		"email.telstra.com":                        "Bigpond",
-		"en.m.yelp.be":                             "Yelp",
-		"en.resto.be":                              "Resto",
-		"en.yelp.be":                               "Yelp",
-		"encrypted.google.com":                     "Google",
-		"eo.st":                                    "eo",
-		"es-es.facebook.com":                       "Facebook",
-		"es.foursquare.com":                        "Foursquare",
-		"es.search.yahoo.com":                      "Yahoo!",
-		"es.yahoo.com":                             "Yahoo!",
-		"espanol.searchpanol.yahoo.com":            "Yahoo!",
-		"espanol.yahoo.com":                        "Yahoo!",
-		"eu.ixquick.com":                           "IXquick",
-		"eurosport.yahoo.com":                      "Yahoo!",
-		"exmail.qq.com":                            "QQ Mail",
-		"eyeota.net":                               "Eyeota",
-		"facebook.com":                             "Facebook",
-		"farm.plista.com":                          "Plista",
-		"fb.me":                                    "Facebook",
-		"finance.yahoo.com":                        "Yahoo!",
-		"find.tdc.dk":                              "Google",
-		"find.web.aol.com":                         "AOL",
-		"flashtalking.com":                         "Flashtalking",
-		"flickr.com":                               "Flickr",
-		"flixster.com":                             "Flixster",
-		"forestle.mobi":                            "Forestle",
-		"forestle.org":                             "Forestle",
-		"forums.whirlpool.net.au":                  "Whirlpool",
-		"fotolog.com":                              "Fotolog",
-		"foursquare.com":                           "Foursquare",
-		"fr-fr.facebook.com":                       "Facebook",
-		"fr-mg42.mail.yahoo.com":                   "Yahoo",
-		"fr-restaurantguru-com.cdn.ampproject.org": "Restaurantguru",
-		"fr.foursquare.com":                        "Foursquare",
-		"fr.gaultmillau.com":                       "Gault&Milau",
-		"fr.images.search.yahoo.com":               "Yahoo",
-		"fr.indeed.com":                            "Indeed",
-		"fr.m.yelp.be":                             "Yelp",
-		"fr.m.yelp.ca":                             "Yelp",
-		"fr.m.yelp.ch":                             "Yelp",
-		"fr.news.yahoo.com":                        "Yahoo",
-		"fr.restaurantguru.com":                    "Restaurantguru",
-		"fr.search.yahoo.com":                      "Yahoo!",
-		"fr.style.yahoo.com":                       "Yahoo",
-		"fr.tripadvisor.ca":                        "Tripadvisor",
-		"fr.tripadvisor.ch":                        "Tripadvisor",
-		"fr.yahoo.com":                             "Yahoo",
-		"fr.yelp.ca":                               "Yelp",
-		"fr2.rpmfind.net":                          "RPMFind",
-		"friendfeed.com":                           "FriendFeed",
-		"friendsreunited.com":                      "Friends Reunited",
-		"friendster.com":                           "Friendster",
-		"gaiaonline.com":                           "Gaia Online",
-		"gais.cs.ccu.edu.tw":                       "GAIS",
-		"games.yahoo.com":                          "Yahoo!",
-		"gaultmillau.be":                           "Gault&Milau",
-		"gaultmillau.com":                          "Gault&Milau",
-		"geni.com":                                 "Geni",
-		"geona.net":                                "Geona",
-		"getpocket.com":                            "Pocket",
-		"github.com":                               "GitHub",
-		"global.cyworld.com":                       "Cyworld",
-		"go.mail.ru":                               "Mail.ru",
-		"google.ac":                                "Google",
-		"google.ac/imgres":                         "Google Images",
-		"google.ac/products":                       "Google Product Search",
-		"google.ad":                                "Google",
-		"google.ad/imgres":                         "Google Images",
-		"google.ad/products":                       "Google Product Search",
-		"google.ae":                                "Google",
-		"google.ae/imgres":                         "Google Images",
-		"google.ae/products":                       "Google Product Search",
-		"google.am":                                "Google",
-		"google.am/imgres":                         "Google Images",
-		"google.am/products":                       "Google Product Search",
-		"google.as":                                "Google",
-		"google.as/imgres":                         "Google Images",
-		"google.as/products":                       "Google Product Search",
-		"google.at":                                "Google",
-		"google.at/imgres":                         "Google Images",
-		"google.at/products":                       "Google Product Search",
-		"google.az":                                "Google",
-		"google.az/imgres":                         "Google Images",
-		"google.az/products":                       "Google Product Search",
-		"google.ba":                                "Google",
-		"google.ba/imgres":                         "Google Images",
-		"google.ba/products":                       "Google Product Search",
-		"google.be":                                "Google",
-		"google.be/imgres":                         "Google Images",
-		"google.be/products":                       "Google Product Search",
-		"google.bf":                                "Google",
-		"google.bf/imgres":                         "Google Images",
-		"google.bf/products":                       "Google Product Search",
-		"google.bg":                                "Google",
-		"google.bg/imgres":                         "Google Images",
-		"google.bg/products":                       "Google Product Search",
-		"google.bi":                                "Google",
-		"google.bi/imgres":                         "Google Images",
-		"google.bi/products":                       "Google Product Search",
-		"google.bj":                                "Google",
-		"google.bj/imgres":                         "Google Images",
-		"google.bj/products":                       "Google Product Search",
-		"google.bs":                                "Google",
-		"google.bs/imgres":                         "Google Images",
-		"google.bs/products":                       "Google Product Search",
-		"google.by":                                "Google",
-		"google.by/imgres":                         "Google Images",
-		"google.by/products":                       "Google Product Search",
-		"google.ca":                                "Google",
-		"google.ca/imgres":                         "Google Images",
-		"google.ca/products":                       "Google Product Search",
-		"google.cat":                               "Google",
-		"google.cat/imgres":                        "Google Images",
-		"google.cat/products":                      "Google Product Search",
-		"google.cc":                                "Google",
-		"google.cc/imgres":                         "Google Images",
-		"google.cc/products":                       "Google Product Search",
-		"google.cd":                                "Google",
-		"google.cd/imgres":                         "Google Images",
-		"google.cd/products":                       "Google Product Search",
-		"google.cf":                                "Google",
-		"google.cf/imgres":                         "Google Images",
-		"google.cf/products":                       "Google Product Search",
-		"google.cg":                                "Google",
-		"google.cg/imgres":                         "Google Images",
-		"google.cg/products":                       "Google Product Search",
-		"google.ch":                                "Google",
-		"google.ch/imgres":                         "Google Images",
-		"google.ch/products":                       "Google Product Search",
-		"google.ci":                                "Google",
-		"google.ci/imgres":                         "Google Images",
-		"google.ci/products":                       "Google Product Search",
-		"google.cl":                                "Google",
-		"google.cl/imgres":                         "Google Images",
-		"google.cl/products":                       "Google Product Search",
-		"google.cm":                                "Google",
-		"google.cm/imgres":                         "Google Images",
-		"google.cm/products":                       "Google Product Search",
-		"google.cn":                                "Google",
-		"google.cn/imgres":                         "Google Images",
-		"google.cn/products":                       "Google Product Search",
-		"google.co.bw":                             "Google",
-		"google.co.bw/imgres":                      "Google Images",
-		"google.co.bw/products":                    "Google Product Search",
-		"google.co.ck":                             "Google",
-		"google.co.ck/imgres":                      "Google Images",
-		"google.co.ck/products":                    "Google Product Search",
-		"google.co.cr":                             "Google",
-		"google.co.cr/imgres":                      "Google Images",
-		"google.co.cr/products":                    "Google Product Search",
-		"google.co.id":                             "Google",
-		"google.co.id/imgres":                      "Google Images",
-		"google.co.id/products":                    "Google Product Search",
-		"google.co.il":                             "Google",
-		"google.co.il/imgres":                      "Google Images",
-		"google.co.il/products":                    "Google Product Search",
-		"google.co.in":                             "Google",
-		"google.co.in/imgres":                      "Google Images",
-		"google.co.in/products":                    "Google Product Search",
-		"google.co.jp":                             "Google",
-		"google.co.jp/imgres":                      "Google Images",
-		"google.co.jp/products":                    "Google Product Search",
-		"google.co.ke":                             "Google",
-		"google.co.ke/imgres":                      "Google Images",
-		"google.co.ke/products":                    "Google Product Search",
-		"google.co.kr":                             "Google",
-		"google.co.kr/imgres":                      "Google Images",
-		"google.co.kr/products":                    "Google Product Search",
-		"google.co.ls":                             "Google",
-		"google.co.ls/imgres":                      "Google Images",
-		"google.co.ls/products":                    "Google Product Search",
-		"google.co.ma":                             "Google",
-		"google.co.ma/imgres":                      "Google Images",
-		"google.co.ma/products":                    "Google Product Search",
-		"google.co.mz":                             "Google",
-		"google.co.mz/imgres":                      "Google Images",
-		"google.co.mz/products":                    "Google Product Search",
-		"google.co.nz":                             "Google",
-		"google.co.nz/imgres":                      "Google Images",
-		"google.co.nz/products":                    "Google Product Search",
-		"google.co.th":                             "Google",
-		"google.co.th/imgres":                      "Google Images",
-		"google.co.th/products":                    "Google Product Search",
-		"google.co.tz":                             "Google",
-		"google.co.tz/imgres":                      "Google Images",
-		"google.co.tz/products":                    "Google Product Search",
-		"google.co.ug":                             "Google",
-		"google.co.ug/imgres":                      "Google Images",
-		"google.co.ug/products":                    "Google Product Search",
-		"google.co.uk":                             "Google",
-		"google.co.uk/imgres":                      "Google Images",
-		"google.co.uk/products":                    "Google Product Search",
-		"google.co.uz":                             "Google",
-		"google.co.uz/imgres":                      "Google Images",
-		"google.co.uz/products":                    "Google Product Search",
-		"google.co.ve":                             "Google",
-		"google.co.ve/imgres":                      "Google Images",
-		"google.co.ve/products":                    "Google Product Search",
-		"google.co.vi":                             "Google",
-		"google.co.vi/imgres":                      "Google Images",
-		"google.co.vi/products":                    "Google Product Search",
-		"google.co.za":                             "Google",
-		"google.co.za/imgres":                      "Google Images",
-		"google.co.za/products":                    "Google Product Search",
-		"google.co.zm":                             "Google",
-		"google.co.zm/imgres":                      "Google Images",
-		"google.co.zm/products":                    "Google Product Search",
-		"google.co.zw":                             "Google",
-		"google.co.zw/imgres":                      "Google Images",
-		"google.co.zw/products":                    "Google Product Search",
-		"google.com":                               "Google",
-		"google.com.af":                            "Google",
-		"google.com.af/imgres":                     "Google Images",
-		"google.com.af/products":                   "Google Product Search",
-		"google.com.ag":                            "Google",
-		"google.com.ag/imgres":                     "Google Images",
-		"google.com.ag/products":                   "Google Product Search",
-		"google.com.ai":                            "Google",
-		"google.com.ai/imgres":                     "Google Images",
-		"google.com.ai/products":                   "Google Product Search",
-		"google.com.ar":                            "Google",
-		"google.com.ar/imgres":                     "Google Images",
-		"google.com.ar/products":                   "Google Product Search",
-		"google.com.au":                            "Google",
-		"google.com.au/imgres":                     "Google Images",
-		"google.com.au/products":                   "Google Product Search",
-		"google.com.bd":                            "Google",
-		"google.com.bd/imgres":                     "Google Images",
-		"google.com.bd/products":                   "Google Product Search",
-		"google.com.bh":                            "Google",
-		"google.com.bh/imgres":                     "Google Images",
-		"google.com.bh/products":                   "Google Product Search",
-		"google.com.bn":                            "Google",
-		"google.com.bn/imgres":                     "Google Images",
-		"google.com.bn/products":                   "Google Product Search",
-		"google.com.bo":                            "Google",
-		"google.com.bo/imgres":                     "Google Images",
-		"google.com.bo/products":                   "Google Product Search",
-		"google.com.br":                            "Google",
-		"google.com.br/imgres":                     "Google Images",
-		"google.com.br/products":                   "Google Product Search",
-		"google.com.by":                            "Google",
-		"google.com.by/imgres":                     "Google Images",
-		"google.com.by/products":                   "Google Product Search",
-		"google.com.bz":                            "Google",
-		"google.com.bz/imgres":                     "Google Images",
-		"google.com.bz/products":                   "Google Product Search",
-		"google.com.co":                            "Google",
-		"google.com.co/imgres":                     "Google Images",
-		"google.com.co/products":                   "Google Product Search",
-		"google.com.cu":                            "Google",
-		"google.com.cu/imgres":                     "Google Images",
-		"google.com.cu/products":                   "Google Product Search",
-		"google.com.cy":                            "Google",
-		"google.com.cy/imgres":                     "Google Images",
-		"google.com.cy/products":                   "Google Product Search",
-		"google.com.do":                            "Google",
-		"google.com.do/imgres":                     "Google Images",
-		"google.com.do/products":                   "Google Product Search",
-		"google.com.ec":                            "Google",
-		"google.com.ec/imgres":                     "Google Images",
-		"google.com.ec/products":                   "Google Product Search",
-		"google.com.eg":                            "Google",
-		"google.com.eg/imgres":                     "Google Images",
-		"google.com.eg/products":                   "Google Product Search",
-		"google.com.et":                            "Google",
-		"google.com.et/imgres":                     "Google Images",
-		"google.com.et/products":                   "Google Product Search",
-		"google.com.fj":                            "Google",
-		"google.com.fj/imgres":                     "Google Images",
-		"google.com.fj/products":                   "Google Product Search",
-		"google.com.gh":                            "Google",
-		"google.com.gh/imgres":                     "Google Images",
-		"google.com.gh/products":                   "Google Product Search",
-		"google.com.gi":                            "Google",
-		"google.com.gi/imgres":                     "Google Images",
-		"google.com.gi/products":                   "Google Product Search",
-		"google.com.gt":                            "Google",
-		"google.com.gt/imgres":                     "Google Images",
-		"google.com.gt/products":                   "Google Product Search",
-		"google.com.hk":                            "Google",
-		"google.com.hk/imgres":                     "Google Images",
-		"google.com.hk/products":                   "Google Product Search",
-		"google.com.jm":                            "Google",
-		"google.com.jm/imgres":                     "Google Images",
-		"google.com.jm/products":                   "Google Product Search",
-		"google.com.kh":                            "Google",
-		"google.com.kh/imgres":                     "Google Images",
-		"google.com.kh/products":                   "Google Product Search",
-		"google.com.kw":                            "Google",
-		"google.com.kw/imgres":                     "Google Images",
-		"google.com.kw/products":                   "Google Product Search",
-		"google.com.lb":                            "Google",
-		"google.com.lb/imgres":                     "Google Images",
-		"google.com.lb/products":                   "Google Product Search",
-		"google.com.lc":                            "Google",
-		"google.com.lc/imgres":                     "Google Images",
-		"google.com.lc/products":                   "Google Product Search",
-		"google.com.ly":                            "Google",
-		"google.com.ly/imgres":                     "Google Images",
-		"google.com.ly/products":                   "Google Product Search",
-		"google.com.mt":                            "Google",
-		"google.com.mt/imgres":                     "Google Images",
-		"google.com.mt/products":                   "Google Product Search",
-		"google.com.mx":                            "Google",
-		"google.com.mx/imgres":                     "Google Images",
-		"google.com.mx/products":                   "Google Product Search",
-		"google.com.my":                            "Google",
-		"google.com.my/imgres":                     "Google Images",
-		"google.com.my/products":                   "Google Product Search",
-		"google.com.na":                            "Google",
-		"google.com.na/imgres":                     "Google Images",
-		"google.com.na/products":                   "Google Product Search",
-		"google.com.nf":                            "Google",
-		"google.com.nf/imgres":                     "Google Images",
-		"google.com.nf/products":                   "Google Product Search",
-		"google.com.ng":                            "Google",
-		"google.com.ng/imgres":                     "Google Images",
-		"google.com.ng/products":                   "Google Product Search",
-		"google.com.ni":                            "Google",
-		"google.com.ni/imgres":                     "Google Images",
-		"google.com.ni/products":                   "Google Product Search",
-		"google.com.np":                            "Google",
-		"google.com.np/imgres":                     "Google Images",
-		"google.com.np/products":                   "Google Product Search",
-		"google.com.om":                            "Google",
-		"google.com.om/imgres":                     "Google Images",
-		"google.com.om/products":                   "Google Product Search",
-		"google.com.pa":                            "Google",
-		"google.com.pa/imgres":                     "Google Images",
-		"google.com.pa/products":                   "Google Product Search",
-		"google.com.pe":                            "Google",
-		"google.com.pe/imgres":                     "Google Images",
-		"google.com.pe/products":                   "Google Product Search",
-		"google.com.ph":                            "Google",
-		"google.com.ph/imgres":                     "Google Images",
-		"google.com.ph/products":                   "Google Product Search",
-		"google.com.pk":                            "Google",
-		"google.com.pk/imgres":                     "Google Images",
-		"google.com.pk/products":                   "Google Product Search",
-		"google.com.pr":                            "Google",
-		"google.com.pr/imgres":                     "Google Images",
-		"google.com.pr/products":                   "Google Product Search",
-		"google.com.py":                            "Google",
-		"google.com.py/imgres":                     "Google Images",
-		"google.com.py/products":                   "Google Product Search",
-		"google.com.qa":                            "Google",
-		"google.com.qa/imgres":                     "Google Images",
-		"google.com.qa/products":                   "Google Product Search",
-		"google.com.sa":                            "Google",
-		"google.com.sa/imgres":                     "Google Images",
-		"google.com.sa/products":                   "Google Product Search",
-		"google.com.sb":                            "Google",
-		"google.com.sb/imgres":                     "Google Images",
-		"google.com.sb/products":                   "Google Product Search",
-		"google.com.sg":                            "Google",
-		"google.com.sg/imgres":                     "Google Images",
-		"google.com.sg/products":                   "Google Product Search",
-		"google.com.sl":                            "Google",
-		"google.com.sl/imgres":                     "Google Images",
-		"google.com.sl/products":                   "Google Product Search",
-		"google.com.sv":                            "Google",
-		"google.com.sv/imgres":                     "Google Images",
-		"google.com.sv/products":                   "Google Product Search",
-		"google.com.tj":                            "Google",
-		"google.com.tj/imgres":                     "Google Images",
-		"google.com.tj/products":                   "Google Product Search",
-		"google.com.tn":                            "Google",
-		"google.com.tn/imgres":                     "Google Images",
-		"google.com.tn/products":                   "Google Product Search",
-		"google.com.tr":                            "Google",
-		"google.com.tr/imgres":                     "Google Images",
-		"google.com.tr/products":                   "Google Product Search",
-		"google.com.tw":                            "Google",
-		"google.com.tw/imgres":                     "Google Images",
-		"google.com.tw/products":                   "Google Product Search",
-		"google.com.ua":                            "Google",
-		"google.com.ua/imgres":                     "Google Images",
-		"google.com.ua/products":                   "Google Product Search",
-		"google.com.uy":                            "Google",
-		"google.com.uy/imgres":                     "Google Images",
-		"google.com.uy/products":                   "Google Product Search",
-		"google.com.vc":                            "Google",
-		"google.com.vc/imgres":                     "Google Images",
-		"google.com.vc/products":                   "Google Product Search",
-		"google.com.vn":                            "Google",
-		"google.com.vn/imgres":                     "Google Images",
-		"google.com.vn/products":                   "Google Product Search",
-		"google.com/imgres":                        "Google Images",
-		"google.com/products":                      "Google Product Search",
-		"google.cv":                                "Google",
-		"google.cv/imgres":                         "Google Images",
-		"google.cv/products":                       "Google Product Search",
-		"google.cz":                                "Google",
-		"google.cz/imgres":                         "Google Images",
-		"google.cz/products":                       "Google Product Search",
-		"google.de":                                "Google",
-		"google.de/imgres":                         "Google Images",
-		"google.de/products":                       "Google Product Search",
-		"google.dj":                                "Google",
-		"google.dj/imgres":                         "Google Images",
-		"google.dj/products":                       "Google Product Search",
-		"google.dk":                                "Google",
-		"google.dk/imgres":                         "Google Images",
-		"google.dk/products":                       "Google Product Search",
-		"google.dm":                                "Google",
-		"google.dm/imgres":                         "Google Images",
-		"google.dm/products":                       "Google Product Search",
-		"google.dodo.com.au":                       "Dodo",
-		"google.dz":                                "Google",
-		"google.dz/imgres":                         "Google Images",
-		"google.dz/products":                       "Google Product Search",
-		"google.ee":                                "Google",
-		"google.ee/imgres":                         "Google Images",
-		"google.ee/products":                       "Google Product Search",
-		"google.es":                                "Google",
-		"google.es/imgres":                         "Google Images",
-		"google.es/products":                       "Google Product Search",
-		"google.fi":                                "Google",
-		"google.fi/imgres":                         "Google Images",
-		"google.fi/products":                       "Google Product Search",
-		"google.fm":                                "Google",
-		"google.fm/imgres":                         "Google Images",
-		"google.fm/products":                       "Google Product Search",
-		"google.fr":                                "Google",
-		"google.fr/imgres":                         "Google Images",
-		"google.fr/products":                       "Google Product Search",
-		"google.ga":                                "Google",
-		"google.ga/imgres":                         "Google Images",
-		"google.ga/products":                       "Google Product Search",
-		"google.gd":                                "Google",
-		"google.gd/imgres":                         "Google Images",
-		"google.gd/products":                       "Google Product Search",
-		"google.ge":                                "Google",
-		"google.ge/imgres":                         "Google Images",
-		"google.ge/products":                       "Google Product Search",
-		"google.gf":                                "Google",
-		"google.gf/imgres":                         "Google Images",
-		"google.gf/products":                       "Google Product Search",
-		"google.gg":                                "Google",
-		"google.gg/imgres":                         "Google Images",
-		"google.gg/products":                       "Google Product Search",
-		"google.gl":                                "Google",
-		"google.gl/imgres":                         "Google Images",
-		"google.gl/products":                       "Google Product Search",
-		"google.gm":                                "Google",
-		"google.gm/imgres":                         "Google Images",
-		"google.gm/products":                       "Google Product Search",
-		"google.gp":                                "Google",
-		"google.gp/imgres":                         "Google Images",
-		"google.gp/products":                       "Google Product Search",
-		"google.gr":                                "Google",
-		"google.gr/imgres":                         "Google Images",
-		"google.gr/products":                       "Google Product Search",
-		"google.gy":                                "Google",
-		"google.gy/imgres":                         "Google Images",
-		"google.gy/products":                       "Google Product Search",
-		"google.hn":                                "Google",
-		"google.hn/imgres":                         "Google Images",
-		"google.hn/products":                       "Google Product Search",
-		"google.hr":                                "Google",
-		"google.hr/imgres":                         "Google Images",
-		"google.hr/products":                       "Google Product Search",
-		"google.ht":                                "Google",
-		"google.ht/imgres":                         "Google Images",
-		"google.ht/products":                       "Google Product Search",
-		"google.hu":                                "Google",
-		"google.hu/imgres":                         "Google Images",
-		"google.hu/products":                       "Google Product Search",
-		"google.ie":                                "Google",
-		"google.ie/imgres":                         "Google Images",
-		"google.ie/products":                       "Google Product Search",
-		"google.im":                                "Google",
-		"google.im/imgres":                         "Google Images",
-		"google.im/products":                       "Google Product Search",
-		"google.io":                                "Google",
-		"google.io/imgres":                         "Google Images",
-		"google.io/products":                       "Google Product Search",
-		"google.iq":                                "Google",
-		"google.iq/imgres":                         "Google Images",
-		"google.iq/products":                       "Google Product Search",
-		"google.is":                                "Google",
-		"google.is/imgres":                         "Google Images",
-		"google.is/products":                       "Google Product Search",
-		"google.it":                                "Google",
-		"google.it.ao":                             "Google",
-		"google.it.ao/imgres":                      "Google Images",
-		"google.it.ao/products":                    "Google Product Search",
-		"google.it/imgres":                         "Google Images",
-		"google.it/products":                       "Google Product Search",
-		"google.je":                                "Google",
-		"google.je/imgres":                         "Google Images",
-		"google.je/products":                       "Google Product Search",
-		"google.jo":                                "Google",
-		"google.jo/imgres":                         "Google Images",
-		"google.jo/products":                       "Google Product Search",
-		"google.kg":                                "Google",
-		"google.kg/imgres":                         "Google Images",
-		"google.kg/products":                       "Google Product Search",
-		"google.ki":                                "Google",
-		"google.ki/imgres":                         "Google Images",
-		"google.ki/products":                       "Google Product Search",
-		"google.kz":                                "Google",
-		"google.kz/imgres":                         "Google Images",
-		"google.kz/products":                       "Google Product Search",
-		"google.la":                                "Google",
-		"google.la/imgres":                         "Google Images",
-		"google.la/products":                       "Google Product Search",
-		"google.li":                                "Google",
-		"google.li/imgres":                         "Google Images",
-		"google.li/products":                       "Google Product Search",
-		"google.lk":                                "Google",
-		"google.lk/imgres":                         "Google Images",
-		"google.lk/products":                       "Google Product Search",
-		"google.lt":                                "Google",
-		"google.lt/imgres":                         "Google Images",
-		"google.lt/products":                       "Google Product Search",
-		"google.lu":                                "Google",
-		"google.lu/imgres":                         "Google Images",
-		"google.lu/products":                       "Google Product Search",
-		"google.lv":                                "Google",
-		"google.lv/imgres":                         "Google Images",
-		"google.lv/products":                       "Google Product Search",
-		"google.md":                                "Google",
-		"google.md/imgres":                         "Google Images",
-		"google.md/products":                       "Google Product Search",
-		"google.me":                                "Google",
-		"google.me/imgres":                         "Google Images",
-		"google.me/products":                       "Google Product Search",
-		"google.mg":                                "Google",
-		"google.mg/imgres":                         "Google Images",
-		"google.mg/products":                       "Google Product Search",
-		"google.mk":                                "Google",
-		"google.mk/imgres":                         "Google Images",
-		"google.mk/products":                       "Google Product Search",
-		"google.ml":                                "Google",
-		"google.ml/imgres":                         "Google Images",
-		"google.ml/products":                       "Google Product Search",
-		"google.mn":                                "Google",
-		"google.mn/imgres":                         "Google Images",
-		"google.mn/products":                       "Google Product Search",
-		"google.ms":                                "Google",
-		"google.ms/imgres":                         "Google Images",
-		"google.ms/products":                       "Google Product Search",
-		"google.mu":                                "Google",
-		"google.mu/imgres":                         "Google Images",
-		"google.mu/products":                       "Google Product Search",
-		"google.mv":                                "Google",
-		"google.mv/imgres":                         "Google Images",
-		"google.mv/products":                       "Google Product Search",
-		"google.mw":                                "Google",
-		"google.mw/imgres":                         "Google Images",
-		"google.mw/products":                       "Google Product Search",
-		"google.ne":                                "Google",
-		"google.ne/imgres":                         "Google Images",
-		"google.ne/products":                       "Google Product Search",
-		"google.nl":                                "Google",
-		"google.nl/imgres":                         "Google Images",
-		"google.nl/products":                       "Google Product Search",
-		"google.no":                                "Google",
-		"google.no/imgres":                         "Google Images",
-		"google.no/products":                       "Google Product Search",
-		"google.nr":                                "Google",
-		"google.nr/imgres":                         "Google Images",
-		"google.nr/products":                       "Google Product Search",
-		"google.nu":                                "Google",
-		"google.nu/imgres":                         "Google Images",
-		"google.nu/products":                       "Google Product Search",
-		"google.pl":                                "Google",
-		"google.pl/imgres":                         "Google Images",
-		"google.pl/products":                       "Google Product Search",
-		"google.pn":                                "Google",
-		"google.pn/imgres":                         "Google Images",
-		"google.pn/products":                       "Google Product Search",
-		"google.ps":                                "Google",
-		"google.ps/imgres":                         "Google Images",
-		"google.ps/products":                       "Google Product Search",
-		"google.pt":                                "Google",
-		"google.pt/imgres":                         "Google Images",
-		"google.pt/products":                       "Google Product Search",
-		"google.ro":                                "Google",
-		"google.ro/imgres":                         "Google Images",
-		"google.ro/products":                       "Google Product Search",
-		"google.rs":                                "Google",
-		"google.rs/imgres":                         "Google Images",
-		"google.rs/products":                       "Google Product Search",
-		"google.ru":                                "Google",
-		"google.ru/imgres":                         "Google Images",
-		"google.ru/products":                       "Google Product Search",
-		"google.rw":                                "Google",
-		"google.rw/imgres":                         "Google Images",
-		"google.rw/products":                       "Google Product Search",
-		"google.sc":                                "Google",
-		"google.sc/imgres":                         "Google Images",
-		"google.sc/products":                       "Google Product Search",
-		"google.se":                                "Google",
-		"google.se/imgres":                         "Google Images",
-		"google.se/products":                       "Google Product Search",
-		"google.sh":                                "Google",
-		"google.sh/imgres":                         "Google Images",
-		"google.sh/products":                       "Google Product Search",
-		"google.si":                                "Google",
-		"google.si/imgres":                         "Google Images",
-		"google.si/products":                       "Google Product Search",
-		"google.sk":                                "Google",
-		"google.sk/imgres":                         "Google Images",
-		"google.sk/products":                       "Google Product Search",
-		"google.sm":                                "Google",
-		"google.sm/imgres":                         "Google Images",
-		"google.sm/products":                       "Google Product Search",
-		"google.sn":                                "Google",
-		"google.sn/imgres":                         "Google Images",
-		"google.sn/products":                       "Google Product Search",
-		"google.so":                                "Google",
-		"google.so/imgres":                         "Google Images",
-		"google.so/products":                       "Google Product Search",
-		"google.st":                                "Google",
-		"google.st/imgres":                         "Google Images",
-		"google.st/products":                       "Google Product Search",
-		"google.td":                                "Google",
-		"google.td/imgres":                         "Google Images",
-		"google.td/products":                       "Google Product Search",
-		"google.tg":                                "Google",
-		"google.tg/imgres":                         "Google Images",
-		"google.tg/products":                       "Google Product Search",
-		"google.tk":                                "Google",
-		"google.tk/imgres":                         "Google Images",
-		"google.tk/products":                       "Google Product Search",
-		"google.tl":                                "Google",
-		"google.tl/imgres":                         "Google Images",
-		"google.tl/products":                       "Google Product Search",
-		"google.tm":                                "Google",
-		"google.tm/imgres":                         "Google Images",
-		"google.tm/products":                       "Google Product Search",
-		"google.tn":                                "Google",
-		"google.to":                                "Google",
-		"google.to/imgres":                         "Google Images",
-		"google.to/products":                       "Google Product Search",
-		"google.tt":                                "Google",
-		"google.tt/imgres":                         "Google Images",
-		"google.tt/products":                       "Google Product Search",
-		"google.us":                                "Google",
-		"google.us/imgres":                         "Google Images",
-		"google.us/products":                       "Google Product Search",
-		"google.vg":                                "Google",
-		"google.vg/imgres":                         "Google Images",
-		"google.vg/products":                       "Google Product Search",
-		"google.vu":                                "Google",
-		"google.vu/imgres":                         "Google Images",
-		"google.vu/products":                       "Google Product Search",
-		"google.ws":                                "Google",
-		"google.ws/products":                       "Google Product Search",
-		"googleads.g.doubleclick.net":              "Google Ads",
-		"googleadservices.com":                     "Google",
-		"googlesyndicatedsearch.com":               "Google",
-		"groups.google.co.uk":                      "Google",
-		"groups.google.com":                        "Google",
-		"guide.michelin.com":                       "Guide Michelin",
-		"habbo.com":                                "Habbo",
-		"hi5.com":                                  "hi5",
-		"hk.search.yahoo.com":                      "Yahoo!",
-		"hk.yahoo.com":                             "Yahoo!",
-		"hledani.tiscali.cz":                       "Tiscali",
-		"hocam.com":                                "Hocam.com",
-		"holmes.ge":                                "Holmes",
-		"html.duckduckgo.com":                      "DuckDuckGo",
-		"hyves.nl":                                 "Hyves",
-		"ib.adnxs.com":                             "AppNexus",
-		"identi.ca":                                "Identi.ca",
-		"ie.search.yahoo.com":                      "Yahoo!",
-		"ie.yahoo.com":                             "Yahoo!",
-		"image.search.naver.com":                   "Naver Images",
-		"image.yahoo.cn":                           "Yahoo! Images",
-		"images.ask.com":                           "Ask",
-		"images.google.ac":                         "Google Images",
-		"images.google.ad":                         "Google Images",
-		"images.google.ae":                         "Google Images",
-		"images.google.am":                         "Google Images",
-		"images.google.as":                         "Google Images",
-		"images.google.at":                         "Google Images",
-		"images.google.az":                         "Google Images",
-		"images.google.ba":                         "Google Images",
-		"images.google.be":                         "Google Images",
-		"images.google.bf":                         "Google Images",
-		"images.google.bg":                         "Google Images",
-		"images.google.bi":                         "Google Images",
-		"images.google.bj":                         "Google Images",
-		"images.google.bs":                         "Google Images",
-		"images.google.by":                         "Google Images",
-		"images.google.ca":                         "Google Images",
-		"images.google.cat":                        "Google Images",
-		"images.google.cc":                         "Google Images",
-		"images.google.cd":                         "Google Images",
-		"images.google.cf":                         "Google Images",
-		"images.google.cg":                         "Google Images",
-		"images.google.ch":                         "Google Images",
-		"images.google.ci":                         "Google Images",
-		"images.google.cl":                         "Google Images",
-		"images.google.cm":                         "Google Images",
-		"images.google.cn":                         "Google Images",
-		"images.google.co.bw":                      "Google Images",
-		"images.google.co.ck":                      "Google Images",
-		"images.google.co.cr":                      "Google Images",
-		"images.google.co.id":                      "Google Images",
-		"images.google.co.il":                      "Google Images",
-		"images.google.co.in":                      "Google Images",
-		"images.google.co.jp":                      "Google Images",
-		"images.google.co.ke":                      "Google Images",
-		"images.google.co.kr":                      "Google Images",
-		"images.google.co.ls":                      "Google Images",
-		"images.google.co.ma":                      "Google Images",
-		"images.google.co.mz":                      "Google Images",
-		"images.google.co.nz":                      "Google Images",
-		"images.google.co.th":                      "Google Images",
-		"images.google.co.tz":                      "Google Images",
-		"images.google.co.ug":                      "Google Images",
-		"images.google.co.uk":                      "Google Images",
-		"images.google.co.uz":                      "Google Images",
-		"images.google.co.ve":                      "Google Images",
-		"images.google.co.vi":                      "Google Images",
-		"images.google.co.za":                      "Google Images",
-		"images.google.co.zm":                      "Google Images",
-		"images.google.co.zw":                      "Google Images",
-		"images.google.com":                        "Google Images",
-		"images.google.com.af":                     "Google Images",
-		"images.google.com.ag":                     "Google Images",
-		"images.google.com.ai":                     "Google Images",
-		"images.google.com.ar":                     "Google Images",
-		"images.google.com.au":                     "Google Images",
-		"images.google.com.bd":                     "Google Images",
-		"images.google.com.bh":                     "Google Images",
-		"images.google.com.bn":                     "Google Images",
-		"images.google.com.bo":                     "Google Images",
-		"images.google.com.br":                     "Google Images",
-		"images.google.com.by":                     "Google Images",
-		"images.google.com.bz":                     "Google Images",
-		"images.google.com.co":                     "Google Images",
-		"images.google.com.cu":                     "Google Images",
-		"images.google.com.cy":                     "Google Images",
-		"images.google.com.do":                     "Google Images",
-		"images.google.com.ec":                     "Google Images",
-		"images.google.com.eg":                     "Google Images",
-		"images.google.com.et":                     "Google Images",
-		"images.google.com.fj":                     "Google Images",
-		"images.google.com.gh":                     "Google Images",
-		"images.google.com.gi":                     "Google Images",
-		"images.google.com.gt":                     "Google Images",
-		"images.google.com.hk":                     "Google Images",
-		"images.google.com.jm":                     "Google Images",
-		"images.google.com.kh":                     "Google Images",
-		"images.google.com.kw":                     "Google Images",
-		"images.google.com.lb":                     "Google Images",
-		"images.google.com.lc":                     "Google Images",
-		"images.google.com.ly":                     "Google Images",
-		"images.google.com.mt":                     "Google Images",
-		"images.google.com.mx":                     "Google Images",
-		"images.google.com.my":                     "Google Images",
-		"images.google.com.na":                     "Google Images",
-		"images.google.com.nf":                     "Google Images",
-		"images.google.com.ng":                     "Google Images",
-		"images.google.com.ni":                     "Google Images",
-		"images.google.com.np":                     "Google Images",
-		"images.google.com.om":                     "Google Images",
-		"images.google.com.pa":                     "Google Images",
-		"images.google.com.pe":                     "Google Images",
-		"images.google.com.ph":                     "Google Images",
-		"images.google.com.pk":                     "Google Images",
-		"images.google.com.pr":                     "Google Images",
-		"images.google.com.py":                     "Google Images",
-		"images.google.com.qa":                     "Google Images",
-		"images.google.com.sa":                     "Google Images",
-		"images.google.com.sb":                     "Google Images",
-		"images.google.com.sg":                     "Google Images",
-		"images.google.com.sl":                     "Google Images",
-		"images.google.com.sv":                     "Google Images",
-		"images.google.com.tj":                     "Google Images",
-		"images.google.com.tn":                     "Google Images",
-		"images.google.com.tr":                     "Google Images",
-		"images.google.com.tw":                     "Google Images",
-		"images.google.com.ua":                     "Google Images",
-		"images.google.com.uy":                     "Google Images",
-		"images.google.com.vc":                     "Google Images",
-		"images.google.com.vn":                     "Google Images",
-		"images.google.cv":                         "Google Images",
-		"images.google.cz":                         "Google Images",
-		"images.google.de":                         "Google Images",
-		"images.google.dj":                         "Google Images",
-		"images.google.dk":                         "Google Images",
-		"images.google.dm":                         "Google Images",
-		"images.google.dz":                         "Google Images",
-		"images.google.ee":                         "Google Images",
-		"images.google.es":                         "Google Images",
-		"images.google.fi":                         "Google Images",
-		"images.google.fm":                         "Google Images",
-		"images.google.fr":                         "Google Images",
-		"images.google.ga":                         "Google Images",
-		"images.google.gd":                         "Google Images",
-		"images.google.ge":                         "Google Images",
-		"images.google.gf":                         "Google Images",
-		"images.google.gg":                         "Google Images",
-		"images.google.gl":                         "Google Images",
-		"images.google.gm":                         "Google Images",
-		"images.google.gp":                         "Google Images",
-		"images.google.gr":                         "Google Images",
-		"images.google.gy":                         "Google Images",
-		"images.google.hn":                         "Google Images",
-		"images.google.hr":                         "Google Images",
-		"images.google.ht":                         "Google Images",
-		"images.google.hu":                         "Google Images",
-		"images.google.ie":                         "Google Images",
-		"images.google.im":                         "Google Images",
-		"images.google.io":                         "Google Images",
-		"images.google.iq":                         "Google Images",
-		"images.google.is":                         "Google Images",
-		"images.google.it":                         "Google Images",
-		"images.google.it.ao":                      "Google Images",
-		"images.google.je":                         "Google Images",
-		"images.google.jo":                         "Google Images",
-		"images.google.kg":                         "Google Images",
-		"images.google.ki":                         "Google Images",
-		"images.google.kz":                         "Google Images",
-		"images.google.la":                         "Google Images",
-		"images.google.li":                         "Google Images",
-		"images.google.lk":                         "Google Images",
-		"images.google.lt":                         "Google Images",
-		"images.google.lu":                         "Google Images",
-		"images.google.lv":                         "Google Images",
-		"images.google.md":                         "Google Images",
-		"images.google.me":                         "Google Images",
-		"images.google.mg":                         "Google Images",
-		"images.google.mk":                         "Google Images",
-		"images.google.ml":                         "Google Images",
-		"images.google.mn":                         "Google Images",
-		"images.google.ms":                         "Google Images",
-		"images.google.mu":                         "Google Images",
-		"images.google.mv":                         "Google Images",
-		"images.google.mw":                         "Google Images",
-		"images.google.ne":                         "Google Images",
-		"images.google.nl":                         "Google Images",
-		"images.google.no":                         "Google Images",
-		"images.google.nr":                         "Google Images",
-		"images.google.nu":                         "Google Images",
-		"images.google.pl":                         "Google Images",
-		"images.google.pn":                         "Google Images",
-		"images.google.ps":                         "Google Images",
-		"images.google.pt":                         "Google Images",
-		"images.google.ro":                         "Google Images",
-		"images.google.rs":                         "Google Images",
-		"images.google.ru":                         "Google Images",
-		"images.google.rw":                         "Google Images",
-		"images.google.sc":                         "Google Images",
-		"images.google.se":                         "Google Images",
-		"images.google.sh":                         "Google Images",
-		"images.google.si":                         "Google Images",
-		"images.google.sk":                         "Google Images",
-		"images.google.sm":                         "Google Images",
-		"images.google.sn":                         "Google Images",
-		"images.google.so":                         "Google Images",
-		"images.google.st":                         "Google Images",
-		"images.google.td":                         "Google Images",
-		"images.google.tg":                         "Google Images",
-		"images.google.tk":                         "Google Images",
-		"images.google.tl":                         "Google Images",
-		"images.google.tm":                         "Google Images",
-		"images.google.to":                         "Google Images",
-		"images.google.tt":                         "Google Images",
-		"images.google.us":                         "Google Images",
-		"images.google.vg":                         "Google Images",
-		"images.google.vu":                         "Google Images",
-		"images.google.ws":                         "Google Images",
-		"images.search.yahoo.com":                  "Yahoo! Images",
-		"images.yandex.by":                         "Yandex Images",
-		"images.yandex.com":                        "Yandex Images",
-		"images.yandex.ru":                         "Yandex Images",
-		"images.yandex.ua":                         "Yandex Images",
-		"imagesearch.naver.com":                    "Naver Images",
-		"imasdk.googleapis.com":                    "Google",
-		"in.search.yahoo.com":                      "Yahoo!",
-		"in.yahoo.com":                             "Yahoo!",
-		"inbox.com":                                "Inbox.com",
-		"inbox.com/search/":                        "Inbox.com",
-		"inbox.google.com":                         "Gmail",
-		"inci.sozlukspot.com":                      "Inci Sozluk",
-		"incisozluk.cc":                            "Inci Sozluk",
-		"incisozluk.com":                           "Inci Sozluk",
-		"infospace.com":                            "InfoSpace",
-		"inspsearch.com":                           "Flyingbird",
-		"instagram.com":                            "Instagram",
-		"instela.com":                              "Instela",
-		"int.ask.com":                              "Ask",
-		"int.search-results.com":                   "Ask",
-		"int.search.tb.ask.com":                    "Ask",
-		"isearch.avg.com":                          "Google",
-		"isearch.babylon.com":                      "InfoSpace",
-		"it-it.facebook.com":                       "Facebook",
-		"it.foursquare.com":                        "Foursquare",
-		"it.indeed.com":                            "Indeed",
-		"it.search.yahoo.com":                      "Yahoo!",
-		"it.yahoo.com":                             "Yahoo!",
-		"itusozluk.com":                            "ITU Sozluk",
-		"iwon.ask.com":                             "Ask",
-		"ixquick.com":                              "IXquick",
-		"ixquick.de":                               "IXquick",
-		"jivox.com":                                "Jivox",
-		"junglekey.com":                            "Jungle Key",
-		"junglekey.fr":                             "Jungle Key",
-		"jyxo.1188.cz":                             "Jyxo",
-		"kf.mysearch.myway.com":                    "MySearch",
-		"ki.mysearch.myway.com":                    "MySearch",
-		"ko.search.need2find.com":                  "Needtofind",
-		"kr.search.yahoo.com":                      "Yahoo!",
-		"kr.yahoo.com":                             "Yahoo!",
-		"kununu.com":                               "kununu",
-		"l.facebook.com":                           "Facebook",
-		"l.instagram.com":                          "Instagram",
-		"l.messenger.com":                          "Facebook Messenger",
-		"lastfm.ru":                                "Last.fm",
-		"lebonbon.fr":                              "Le Bonbon",
-		"lefooding.com":                            "LeFooding",
-		"lemoteur.orange.fr":                       "Orange",
-		"lfstmedia.com":                            "LifeStreet",
-		"lifestyle.yahoo.com":                      "Yahoo!",
-		"lijit.com":                                "Sovrn",
-		"lilo.org":                                 "Lilo",
-		"link.2gis.ru":                             "2gis",
-		"linkedin.com":                             "LinkedIn",
-		"listings.altavista.com":                   "Altavista",
-		"lite.qwant.com":                           "Qwant",
-		"liveinternet.ru":                          "Liveinternet",
-		"livejournal.ru":                           "LiveJournal",
-		"lm.facebook.com":                          "Facebook",
-		"lnkd.in":                                  "LinkedIn",
-		"lo.st":                                    "Lo.st",
-		"login.live.com":                           "Windows Live Spaces",
-		"login.tagged.com":                         "Tagged",
-		"lowermybills.com":                         "LowerMyBills",
-		"lu.gaultmillau.com":                       "Gault&Milau",
-		"lycos.com":                                "Lycos",
-		"m.baidu.com":                              "Baidu ",
-		"m.bing.com":                               "Bing",
-		"m.facebook.com":                           "Facebook",
-		"m.mail.ru":                                "Mail.ru",
-		"m.market.yandex.ru":                       "Yandex.Market",
-		"m.mastermail.ru":                          "Mastermail",
-		"m.sm.cn":                                  "Shenma",
-		"m.sp.sm.cn":                               "Shenma",
-		"m.vk.com":                                 "Vkontakte",
-		"m.yelp.ca":                                "Yelp",
-		"m.yelp.com":                               "Yelp",
-		"m.yelp.de":                                "Yelp",
-		"m.yelp.fr":                                "Yelp",
-		"m.yelp.it":                                "Yelp",
-		"m.yelp.nl":                                "Yelp",
-		"m.yz.sm.cn":                               "Shenma",
-		"m.yz2.sm.cn":                              "Shenma",
-		"mail.126.com":                             "126 Mail",
-		"mail.163.com":                             "163 Mail",
-		"mail.aol.com":                             "AOL Mail",
-		"mail.daum.net":                            "Daum Mail",
-		"mail.e1.ru":                               "E1.ru",
-		"mail.google.com":                          "Gmail",
-		"mail.iinet.net.au":                        "iiNet",
-		"mail.live.com":                            "Outlook.com",
-		"mail.mynet.com":                           "Mynet Mail",
-		"mail.naver.com":                           "Naver Mail",
-		"mail.qip.ru":                              "QIP",
-		"mail.qq.com":                              "QQ Mail",
-		"mail.rambler.ru":                          "Rambler",
-		"mail.ru":                                  "Mail.ru",
-		"mail.ukr.net":                             "Ukr.net",
-		"mail.yahoo.co.jp":                         "Yahoo! Mail",
-		"mail.yahoo.co.uk":                         "Yahoo! Mail",
-		"mail.yahoo.com":                           "Yahoo! Mail",
-		"mail.yahoo.net":                           "Yahoo! Mail",
-		"mail.yandex.by":                           "Yandex",
-		"mail.yandex.com":                          "Yandex",
-		"mail.yandex.kz":                           "Yandex",
-		"mail.yandex.ru":                           "Yandex",
-		"mail.yandex.ua":                           "Yandex",
-		"mail.zoho.com":                            "Zoho",
-		"mail2.daum.net":                           "Daum Mail",
-		"mamma75.mamma.com":                        "Mamma",
-		"maps.google.com":                          "Google",
-		"maps.yandex.by":                           "Yandex Maps",
-		"maps.yandex.com":                          "Yandex Maps",
-		"maps.yandex.ru":                           "Yandex Maps",
-		"maps.yandex.ua":                           "Yandex Maps",
-		"market.yandex.ru":                         "Yandex.Market",
-		"mastermail.ru":                            "Mastermail",
-		"match.yahoo.net":                          "Yahoo!",
-		"maxwebsearch.com":                         "Maxwebsearch",
-		"messenger.com":                            "Facebook Messenger",
-		"messenger.yahoo.com":                      "Yahoo!",
-		"meta.rrzn.uni-hannover.de":                "Metager",
-		"meta.ua":                                  "Meta",
-		"metacrawler.com":                          "InfoSpace",
-		"metager2.de":                              "Metager2",
-		"microad.jp":                               "MicroAd",
-		"mixi.jp":                                  "Mixi",
-		"mixpo.com":                                "Mixpo",
-		"mobile.virgilio.it":                       "Virgilio",
-		"mobile.whitepages.com.au":                 "White Pages",
-		"moikrug.ru":                               "MoiKrug.ru",
-		"morfeo.centrum.cz":                        "Centrum",
-		"movies.yahoo.com":                         "Yahoo!",
-		"mozo.com.au":                              "Mozo",
-		"ms114.mysearch.com":                       "MySearch",
-		"ms146.mysearch.com":                       "MySearch",
-		"msnbc.msn.com":                            "Bing",
-		"msxml.excite.com":                         "Excite",
-		"multiply.com":                             "Multiply",
-		"mws.ask.com":                              "Ask",
-		"mx.search.yahoo.com":                      "Yahoo!",
-		"mx.yahoo.com":                             "Yahoo!",
-		"my.daemon-search.com":                     "Daemon search",
-		"my.mail.ru":                               "Mail.ru",
-		"myheritage.com":                           "MyHeritage",
-		"mylife.ru":                                "MyLife",
-		"mysearch.com":                             "MySearch",
-		"myspace.com":                              "Myspace",
-		"myyearbook.com":                           "myYearbook",
-		"n.maps.yandex.ru":                         "Yandex Maps",
-		"navigationshilfe.t-online.de":             "T-Online",
-		"netlog.com":                               "Netlog",
-		"news.baidu.com":                           "Baidu",
-		"news.google.ac":                           "Google News",
-		"news.google.ad":                           "Google News",
-		"news.google.ae":                           "Google News",
-		"news.google.am":                           "Google News",
-		"news.google.as":                           "Google News",
-		"news.google.at":                           "Google News",
-		"news.google.az":                           "Google News",
-		"news.google.ba":                           "Google News",
-		"news.google.be":                           "Google News",
-		"news.google.bf":                           "Google News",
-		"news.google.bg":                           "Google News",
-		"news.google.bi":                           "Google News",
-		"news.google.bj":                           "Google News",
-		"news.google.bs":                           "Google News",
-		"news.google.by":                           "Google News",
-		"news.google.ca":                           "Google News",
-		"news.google.cat":                          "Google News",
-		"news.google.cc":                           "Google News",
-		"news.google.cd":                           "Google News",
-		"news.google.cf":                           "Google News",
-		"news.google.cg":                           "Google News",
-		"news.google.ch":                           "Google News",
-		"news.google.ci":                           "Google News",
-		"news.google.cl":                           "Google News",
-		"news.google.cm":                           "Google News",
-		"news.google.cn":                           "Google News",
-		"news.google.co.bw":                        "Google News",
-		"news.google.co.ck":                        "Google News",
-		"news.google.co.cr":                        "Google News",
-		"news.google.co.id":                        "Google News",
-		"news.google.co.il":                        "Google News",
-		"news.google.co.in":                        "Google News",
-		"news.google.co.jp":                        "Google News",
-		"news.google.co.ke":                        "Google News",
-		"news.google.co.kr":                        "Google News",
-		"news.google.co.ls":                        "Google News",
-		"news.google.co.ma":                        "Google News",
-		"news.google.co.mz":                        "Google News",
-		"news.google.co.nz":                        "Google News",
-		"news.google.co.th":                        "Google News",
-		"news.google.co.tz":                        "Google News",
-		"news.google.co.ug":                        "Google News",
-		"news.google.co.uk":                        "Google News",
-		"news.google.co.uz":                        "Google News",
-		"news.google.co.ve":                        "Google News",
-		"news.google.co.vi":                        "Google News",
-		"news.google.co.za":                        "Google News",
-		"news.google.co.zm":                        "Google News",
-		"news.google.co.zw":                        "Google News",
-		"news.google.com":                          "Google News",
-		"news.google.com.af":                       "Google News",
-		"news.google.com.ag":                       "Google News",
-		"news.google.com.ai":                       "Google News",
-		"news.google.com.ar":                       "Google News",
-		"news.google.com.au":                       "Google News",
-		"news.google.com.bd":                       "Google News",
-		"news.google.com.bh":                       "Google News",
-		"news.google.com.bn":                       "Google News",
-		"news.google.com.bo":                       "Google News",
-		"news.google.com.br":                       "Google News",
-		"news.google.com.by":                       "Google News",
-		"news.google.com.bz":                       "Google News",
-		"news.google.com.co":                       "Google News",
-		"news.google.com.cu":                       "Google News",
-		"news.google.com.cy":                       "Google News",
-		"news.google.com.do":                       "Google News",
-		"news.google.com.ec":                       "Google News",
-		"news.google.com.eg":                       "Google News",
-		"news.google.com.et":                       "Google News",
-		"news.google.com.fj":                       "Google News",
-		"news.google.com.gh":                       "Google News",
-		"news.google.com.gi":                       "Google News",
-		"news.google.com.gt":                       "Google News",
-		"news.google.com.hk":                       "Google News",
-		"news.google.com.jm":                       "Google News",
-		"news.google.com.kh":                       "Google News",
-		"news.google.com.kw":                       "Google News",
-		"news.google.com.lb":                       "Google News",
-		"news.google.com.lc":                       "Google News",
-		"news.google.com.ly":                       "Google News",
-		"news.google.com.mt":                       "Google News",
-		"news.google.com.mx":                       "Google News",
-		"news.google.com.my":                       "Google News",
-		"news.google.com.na":                       "Google News",
-		"news.google.com.nf":                       "Google News",
-		"news.google.com.ng":                       "Google News",
-		"news.google.com.ni":                       "Google News",
-		"news.google.com.np":                       "Google News",
-		"news.google.com.om":                       "Google News",
-		"news.google.com.pa":                       "Google News",
-		"news.google.com.pe":                       "Google News",
-		"news.google.com.ph":                       "Google News",
-		"news.google.com.pk":                       "Google News",
-		"news.google.com.pr":                       "Google News",
-		"news.google.com.py":                       "Google News",
-		"news.google.com.qa":                       "Google News",
-		"news.google.com.sa":                       "Google News",
-		"news.google.com.sb":                       "Google News",
-		"news.google.com.sg":                       "Google News",
-		"news.google.com.sl":                       "Google News",
-		"news.google.com.sv":                       "Google News",
-		"news.google.com.tj":                       "Google News",
-		"news.google.com.tn":                       "Google News",
-		"news.google.com.tr":                       "Google News",
-		"news.google.com.tw":                       "Google News",
-		"news.google.com.ua":                       "Google News",
-		"news.google.com.uy":                       "Google News",
-		"news.google.com.vc":                       "Google News",
-		"news.google.com.vn":                       "Google News",
-		"news.google.cv":                           "Google News",
-		"news.google.cz":                           "Google News",
-		"news.google.de":                           "Google News",
-		"news.google.dj":                           "Google News",
-		"news.google.dk":                           "Google News",
-		"news.google.dm":                           "Google News",
-		"news.google.dz":                           "Google News",
-		"news.google.ee":                           "Google News",
-		"news.google.es":                           "Google News",
-		"news.google.fi":                           "Google News",
-		"news.google.fm":                           "Google News",
-		"news.google.fr":                           "Google News",
-		"news.google.ga":                           "Google News",
-		"news.google.gd":                           "Google News",
-		"news.google.ge":                           "Google News",
-		"news.google.gf":                           "Google News",
-		"news.google.gg":                           "Google News",
-		"news.google.gl":                           "Google News",
-		"news.google.gm":                           "Google News",
-		"news.google.gp":                           "Google News",
-		"news.google.gr":                           "Google News",
-		"news.google.gy":                           "Google News",
-		"news.google.hn":                           "Google News",
-		"news.google.hr":                           "Google News",
-		"news.google.ht":                           "Google News",
-		"news.google.hu":                           "Google News",
-		"news.google.ie":                           "Google News",
-		"news.google.im":                           "Google News",
-		"news.google.io":                           "Google News",
-		"news.google.iq":                           "Google News",
-		"news.google.is":                           "Google News",
-		"news.google.it":                           "Google News",
-		"news.google.it.ao":                        "Google News",
-		"news.google.je":                           "Google News",
-		"news.google.jo":                           "Google News",
-		"news.google.kg":                           "Google News",
-		"news.google.ki":                           "Google News",
-		"news.google.kz":                           "Google News",
-		"news.google.la":                           "Google News",
-		"news.google.li":                           "Google News",
-		"news.google.lk":                           "Google News",
-		"news.google.lt":                           "Google News",
-		"news.google.lu":                           "Google News",
-		"news.google.lv":                           "Google News",
-		"news.google.md":                           "Google News",
-		"news.google.me":                           "Google News",
-		"news.google.mg":                           "Google News",
-		"news.google.mk":                           "Google News",
-		"news.google.ml":                           "Google News",
-		"news.google.mn":                           "Google News",
-		"news.google.ms":                           "Google News",
-		"news.google.mu":                           "Google News",
-		"news.google.mv":                           "Google News",
-		"news.google.mw":                           "Google News",
-		"news.google.ne":                           "Google News",
-		"news.google.nl":                           "Google News",
-		"news.google.no":                           "Google News",
-		"news.google.nr":                           "Google News",
-		"news.google.nu":                           "Google News",
-		"news.google.pl":                           "Google News",
-		"news.google.pn":                           "Google News",
-		"news.google.ps":                           "Google News",
-		"news.google.pt":                           "Google News",
-		"news.google.ro":                           "Google News",
-		"news.google.rs":                           "Google News",
-		"news.google.ru":                           "Google News",
-		"news.google.rw":                           "Google News",
-		"news.google.sc":                           "Google News",
-		"news.google.se":                           "Google News",
-		"news.google.sh":                           "Google News",
-		"news.google.si":                           "Google News",
-		"news.google.sk":                           "Google News",
-		"news.google.sm":                           "Google News",
-		"news.google.sn":                           "Google News",
-		"news.google.so":                           "Google News",
-		"news.google.st":                           "Google News",
-		"news.google.td":                           "Google News",
-		"news.google.tg":                           "Google News",
-		"news.google.tk":                           "Google News",
-		"news.google.tl":                           "Google News",
-		"news.google.tm":                           "Google News",
-		"news.google.to":                           "Google News",
-		"news.google.tt":                           "Google News",
-		"news.google.us":                           "Google News",
-		"news.google.vg":                           "Google News",
-		"news.google.vu":                           "Google News",
-		"news.google.ws":                           "Google News",
-		"news.yahoo.com":                           "Yahoo!",
-		"news.ycombinator.com":                     "Hacker News",
-		"nexage.com":                               "ONE by AOL",
-		"next.duckduckgo.com":                      "DuckDuckGo",
-		"nigma.ru":                                 "Nigma",
-		"nk.pl":                                    "Nasza-klasa.pl",
-		"nl-nl.facebook.com":                       "Facebook",
-		"nl.gaultmillau.com":                       "Gault&Milau",
-		"nl.resto.be":                              "Resto",
-		"nl.viamichelin.be":                        "ViaMichelin",
-		"nl.yelp.be":                               "Yelp",
-		"no.search.yahoo.com":                      "Yahoo!",
-		"no.tripadvisor.com":                       "Tripadvisor",
-		"no.yahoo.com":                             "Yahoo!",
-		"nova.rambler.ru":                          "Rambler",
-		"nz.search.yahoo.com":                      "Yahoo!",
-		"nz.yahoo.com":                             "Yahoo!",
-		"ocnsearch.goo.ne.jp":                      "goo",
-		"odnoklassniki.ru":                         "Odnoklassniki",
-		"ok.ru":                                    "Odnoklassniki",
-		"omg.yahoo.com":                            "Yahoo!",
-		"one.cn.yahoo.com":                         "Yahoo!",
-		"one.searchn.yahoo.com":                    "Yahoo!",
-		"online.no":                                "Online.no",
-		"openx.net":                                "OpenX",
-		"openxenterprise.com":                      "OpenX",
-		"optimized-by.rubiconproject.com":          "Rubicon Project",
-		"orange.fr/webmail":                        "Orange Webmail",
-		"org.qwant.com":                            "Qwant",
-		"orkut.com":                                "Orkut",
-		"otsing.delfi.ee":                          "Delfi",
-		"outlook.live.com":                         "Outlook.com",
-		"p.zhongsou.com":                           "Zhongsou",
-		"pagesjaunes.fr":                           "Pages jaune",
-		"paid.outbrain.com":                        "Outbrain",
-		"paper.li":                                 "Paper.li",
-		"partner.googleadservices.com":             "Google",
-		"pesquisa.clix.pt":                         "Clix",
-		"pesquisa.sapo.pt":                         "Sapo",
-		"pinterest.at":                             "Pinterest",
-		"pinterest.ca":                             "Pinterest",
-		"pinterest.ch":                             "Pinterest",
-		"pinterest.cl":                             "Pinterest",
-		"pinterest.co.kr":                          "Pinterest",
-		"pinterest.co.uk":                          "Pinterest",
-		"pinterest.com":                            "Pinterest",
-		"pinterest.com.au":                         "Pinterest",
-		"pinterest.com.mx":                         "Pinterest",
-		"pinterest.de":                             "Pinterest",
-		"pinterest.dk":                             "Pinterest",
-		"pinterest.es":                             "Pinterest",
-		"pinterest.fr":                             "Pinterest",
-		"pinterest.ie":                             "Pinterest",
-		"pinterest.it":                             "Pinterest",
-		"pinterest.jp":                             "Pinterest",
-		"pinterest.nz":                             "Pinterest",
-		"pinterest.ph":                             "Pinterest",
-		"pinterest.pt":                             "Pinterest",
-		"pinterest.ru":                             "Pinterest",
-		"pinterest.se":                             "Pinterest",
-		"plaxo.com":                                "Plaxo",
-		"plus.google.com":                          "Google+",
-		"plusperformance.com":                      "AdSpirit",
-		"poisk.ru":                                 "Poisk.ru",
-		"post.ru":                                  "Beeline",
-		"price.ru":                                 "Price.ru",
-		"pt.foursquare.com":                        "Foursquare",
-		"pubads.g.doubleclick.net":                 "Doubleclick",
-		"qc.search.yahoo.com":                      "Yahoo!",
-		"qc.yahoo.com":                             "Yahoo!",
-		"quark.sm.cn":                              "Shenma",
-		"quora.com":                                "Quora",
-		"qwant.com":                                "Qwant",
-		"qzone.qq.com":                             "Qzone",
-		"r.duckduckgo.com":                         "DuckDuckGo",
-		"r.search.yahoo.com":                       "Yahoo",
-		"recherche.aol.ca":                         "AOL",
-		"recherche.aol.fr":                         "AOL",
-		"recherche.francite.com":                   "Francite",
-		"rechercher.aliceadsl.fr":                  "Alice Adsl",
-		"reddit.com":                               "Reddit",
-		"redirect.disqus.com":                      "Disqus",
-		"redirect.foursquare.com":                  "Foursquare",
-		"renren.com":                               "Renren",
-		"req.-hit-parade.com":                      "Hit-Parade",
-		"restaurantguru-com.cdn.ampproject.org":    "Restaurantguru",
-		"restaurantguru.com":                       "Restaurantguru",
-		"resto.be":                                 "Resto",
-		"ricerca.virgilio.it":                      "Virgilio",
-		"ricercaimmagini.virgilio.it":              "Virgilio",
-		"ricercanews.virgilio.it":                  "Virgilio",
-		"ricercavideo.virgilio.it":                 "Virgilio",
-		"ro.gaultmillau.com":                       "Gault&Milau",
-		"rpmfind.net":                              "RPMFind",
-		"rtbcity.com":                              "AdSpirit",
-		"ru.foursquare.com":                        "Foursquare",
-		"ru.search.yahoo.com":                      "Yahoo!",
-		"ru.yahoo.com":                             "Yahoo!",
-		"s0.2mdn.net":                              "Doubleclick",
-		"s1-eu.ixquick.de":                         "IXquick",
-		"s1.2mdn.net":                              "Doubleclick",
-		"s1.metacrawler.de":                        "MetaCrawler.de",
-		"s1.us.ixquick.com":                        "IXquick",
-		"s2.metacrawler.de":                        "MetaCrawler.de",
-		"s2.us.ixquick.com":                        "IXquick",
-		"s3.metacrawler.de":                        "MetaCrawler.de",
-		"s3.us.ixquick.com":                        "IXquick",
-		"s4.us.ixquick.com":                        "IXquick",
-		"s5.us.ixquick.com":                        "IXquick",
-		"s8-eu.ixquick.com":                        "IXquick",
-		"safe.duckduckgo.com":                      "DuckDuckGo",
-		"screen.yahoo.com":                         "Yahoo!",
-		"se.search.yahoo.com":                      "Yahoo!",
-		"se.yahoo.com":                             "Yahoo!",
-		"search-dyn.tiscali.it":                    "Tiscali",
-		"search-intl.netscape.com":                 "AOL",
-		"search-results.com":                       "Ask",
-		"search.1and1.com":                         "1&1",
-		"search.1und1.de":                          "1und1",
-		"search.alot.com":                          "Google",
-		"search.altavista.com":                     "Altavista",
-		"search.aol.co.uk":                         "AOL",
-		"search.aol.com":                           "AOL",
-		"search.aol.it":                            "AOL",
-		"search.avg.com":                           "Google",
-		"search.babylon.com":                       "Babylon",
-		"search.bluewin.ch":                        "Bluewin",
-		"search.brave.com":                         "Yahoo",
-		"search.bt.com":                            "British Telecommunications",
-		"search.certified-toolbar.com":             "Certified-Toolbar",
-		"search.conduit.com":                       "Conduit",
-		"search.darkoogle.com":                     "Google",
-		"search.daum.net":                          "Daum",
-		"search.earthlink.net":                     "earthlink",
-		"search.excite.co.uk":                      "Excite",
-		"search.excite.de":                         "Excite",
-		"search.excite.fr":                         "Excite",
-		"search.excite.it":                         "Excite",
-		"search.excite.nl":                         "Excite",
-		"search.findwide.com":                      "Findwide",
-		"search.foxtab.com":                        "Google",
-		"search.free.fr":                           "Free",
-		"search.freecause.com":                     "Freecause",
-		"search.genieo.com":                        "Genieo",
-		"search.globososo.com":                     "Globososo",
-		"search.goo.ne.jp":                         "goo",
-		"search.hiyo.com":                          "Google",
-		"search.hp.my.aol.com.au":                  "AOL",
-		"search.hp.my.aol.de":                      "AOL",
-		"search.hp.my.aol.it":                      "AOL",
-		"search.i.ua":                              "I.ua",
-		"search.icq.com":                           "ICQ",
-		"search.incredibar.com":                    "Google",
-		"search.incredimail.com":                   "Google",
-		"search.juno.com":                          "Google",
-		"search.ke.voila.fr":                       "Voila",
-		"search.kiwee.com":                         "InfoSpace",
-		"search.lilo.org":                          "Lilo",
-		"search.lycos.com":                         "Lycos",
-		"search.magnetic.com":                      "InfoSpace",
-		"search.media.telstra.com.au":              "Telstra",
-		"search.myway.com":                         "MySearch",
-		"search.mywebsearch.com":                   "MySearch",
-		"search.nate.com":                          "Nate",
-		"search.naver.com":                         "Naver",
-		"search.nifty.com":                         "Nifty",
-		"search.offerbox.com":                      "Yahoo!",
-		"search.orange.co.uk":                      "Orange",
-		"search.peoplepc.com":                      "PeoplePC",
-		"search.qip.ru":                            "qip",
-		"search.restaurantguru.com":                "Restaurantguru",
-		"search.rr.com":                            "Road Runner Search",
-		"search.searcharch.yahoo.com":              "Yahoo!",
-		"search.searchcompletion.com":              "InfoSpace",
-		"search.seznam.cz":                         "Seznam",
-		"search.snapdo.com":                        "Snapdo",
-		"search.softonic.com":                      "Softonic",
-		"search.sosodesktop.com":                   "SoSoDesk",
-		"search.sweetim.com":                       "Google",
-		"search.tb.ask.com":                        "Ask Toolbar",
-		"search.tiscali.it":                        "Tiscali",
-		"search.toolbars.alexa.com":                "Alexa",
-		"search.tut.by":                            "Tut.by",
-		"search.ukr.net":                           "UKR.net",
-		"search.uselilo.org":                       "Lilo",
-		"search.vindex.nl":                         "Vindex",
-		"search.walla.co.il":                       "Google",
-		"search.winamp.com":                        "Winamp",
-		"search.www.ee":                            "WWW",
-		"search.yahoo.co.jp":                       "Yahoo!",
-		"search.yahoo.com":                         "Yahoo!",
-		"search.yam.com":                           "Yam",
-		"search.yippy.com":                         "Yippy",
-		"search1-1.free.fr":                        "Free",
-		"search1-2.free.fr":                        "Free",
-		"search1.incredimail.com":                  "Google",
-		"search2.incredimail.com":                  "Google",
-		"search3.incredimail.com":                  "Google",
-		"search4.incredimail.com":                  "Google",
-		"searchalot.com":                           "Searchalot",
-		"searchassist.babylon.com":                 "Babylon",
-		"searchatlas.centrum.cz":                   "Atlas",
-		"searches.globososo.com":                   "Globososo",
-		"searchresults.verizon.com":                "Google",
-		"serach.centrum.cz":                        "Centrum",
-		"serach.comcast.net":                       "Comcast",
-		"serach.excite.es":                         "Excite",
-		"servedby.flashtalking.com":                "Flashtalking",
-		"servedbyopenx.com":                        "OpenX",
-		"sfx.stickyadstv.com":                      "StickyADS.tv",
-		"shopping.yahoo.net":                       "Yahoo!",
-		"sibmail.com":                              "Sibmail",
-		"sites.google.com":                         "Google",
-		"skyrock.com":                              "Skyrock",
-		"sm.aport.ru":                              "Aport",
-		"smart.delfi.lv":                           "Delfi latvia",
-		"snapchat.com":                             "Snapchat",
-		"so.360.cn":                                "360.cn",
-		"so.m.sm.cn":                               "Shenma",
-		"sociomantic.com":                          "Sociomantic Labs",
-		"sonico.com":                               "Sonico.com",
-		"sonobi.com":                               "Sonobi",
-		"sosodesktop.com":                          "SoSoDesk",
-		"sourceforge.net":                          "SourceForge",
-		"sourtimes.org":                            "Eksi Sozluk",
-		"sozluk.com":                               "Eksi Sozluk",
-		"sports.yahoo.com":                         "Yahoo!",
-		"sshowads.pubmatic.com":                    "PubMatic",
-		"stackoverflow.com":                        "StackOverflow",
-		"start.duckduckgo.com":                     "DuckDuckGo",
-		"start.facemoods.com":                      "InfoSpace",
-		"start.iplay.com":                          "I-play",
-		"startgoogle.startpagina.nl":               "Startpagina",
-		"startpage.com":                            "Startpage",
-		"steelhousemedia.com":                      "SteelHouse",
-		"stickyadstv.com":                          "StickyADS.tv",
-		"studivz.net":                              "StudiVZ",
-		"stumbleupon.com":                          "StumbleUpon",
-		"suche.aol.de":                             "AOL",
-		"suche.aolsvc.de":                          "AOL",
-		"suche.freenet.de":                         "Freenet",
-		"suche.gmx.net":                            "GMX",
-		"suche.info":                               "suche.info",
-		"suche.t-online.de":                        "T-Online",
-		"suche.web.de":                             "Web.de",
-		"sucheaol.aol.de":                          "AOL",
-		"suchet2.aol.de":                           "AOL",
-		"support.google.com":                       "Google",
-		"szukaj.onet.pl":                           "Onet",
-		"szukaj.wp.pl":                             "Wirtualna Polska",
-		"t.cn":                                     "Weibo",
-		"t.co":                                     "Twitter",
-		"t.umblr.com":                              "Tumblr",
-		"taboola.com":                              "Taboola",
-		"taringa.net":                              "Taringa!",
-		"technorati.com":                           "Technorati",
-		"thesmartsearch.net":                       "The Smart Search",
-		"tieba.baidu.com":                          "Baidu",
-		"tiktok.com":                               "TikTok",
-		"torg.mail.ru":                             "Torg.Mail.ru",
-		"touch.mail.ru":                            "Mail.ru",
-		"tpc.googlesyndication.com":                "Google",
-		"travel.yahoo.com":                         "Yahoo!",
-		"trc.taboola.com":                          "Taboola",
-		"tripadvisor.at":                           "Tripadvisor",
-		"tripadvisor.be":                           "Tripadvisor",
-		"tripadvisor.ca":                           "Tripadvisor",
-		"tripadvisor.ch":                           "Tripadvisor",
-		"tripadvisor.co":                           "Tripadvisor",
-		"tripadvisor.co.id":                        "Tripadvisor",
-		"tripadvisor.co.il":                        "Tripadvisor",
-		"tripadvisor.com":                          "Tripadvisor",
-		"tripadvisor.com.au":                       "Tripadvisor",
-		"tripadvisor.com.br":                       "Tripadvisor",
-		"tripadvisor.com.gr":                       "Tripadvisor",
-		"tripadvisor.com.tr":                       "Tripadvisor",
-		"tripadvisor.de":                           "Tripadvisor",
-		"tripadvisor.dk":                           "Tripadvisor",
-		"tripadvisor.es":                           "Tripadvisor",
-		"tripadvisor.ie":                           "Tripadvisor",
-		"tripadvisor.in":                           "Tripadvisor",
-		"tripadvisor.it":                           "Tripadvisor",
-		"tripadvisor.jp":                           "Tripadvisor",
-		"tripadvisor.nl":                           "Tripadvisor",
-		"tripadvisor.pt":                           "Tripadvisor",
-		"tripadvisor.ru":                           "Tripadvisor",
-		"tripadvisor.se":                           "Tripadvisor",
-		"tuenti.com":                               "Tuenti",
-		"tumblr.com":                               "Tumblr",
-		"tw.search.yahoo.com":                      "Yahoo!",
-		"tw.yahoo.com":                             "Yahoo!",
-		"twitter.com":                              "Twitter",
-		"uk.ask.com":                               "Ask",
-		"uk.search-results.com":                    "Ask",
-		"uk.search.yahoo.com":                      "Yahoo!",
-		"uk.yahoo.com":                             "Yahoo!",
-		"uk.zapmeta.com":                           "Zapmeta",
-		"uludagsozluk.com":                         "Uludag Sozluk",
-		"ulusozluk.com":                            "Uludag Sozluk",
-		"url.google.com":                           "Google+",
-		"us-ads.openx.net":                         "OpenX",
-		"us.ixquick.com":                           "IXquick",
-		"us.search.yahoo.com":                      "Yahoo!",
-		"us.yahoo.com":                             "Yahoo!",
-		"v.price.ru":                               "Price.ru",
-		"verden.abcsok.no":                         "ABCsøk",
-		"viadeo.com":                               "Viadeo",
-		"viamichelin.co.uk":                        "ViaMichelin",
-		"viamichelin.de":                           "ViaMichelin",
-		"viamichelin.it":                           "ViaMichelin",
-		"viamichelin.nl":                           "ViaMichelin",
-		"video.google.com":                         "Google Video",
-		"vimeo.com":                                "Vimeo",
-		"visit.brussels":                           "VisitBrussels",
-		"viview.inspsearch.com":                    "Flyingbird",
-		"vk.com":                                   "Vkontakte",
-		"vkontakte.ru":                             "Vkontakte",
-		"vkrugudruzei.ru":                          "vKruguDruzei.ru",
-		"vshare.toolbarhome.com":                   "Toolbarhome",
-		"walloniebelgietoerisme.be":                "VisitWallonia",
-		"walloniebelgiquetourisme.be":              "VisitWallonia",
-		"wayn.com":                                 "WAYN",
-		"weather.yahoo.com":                        "Yahoo!",
-		"web.ask.com":                              "Ask",
-		"web.canoe.ca":                             "canoe.ca",
-		"web.facebook.com":                         "Facebook",
-		"web.gougou.com":                           "Baidu",
-		"web.skype.com":                            "Skype",
-		"web.toile.com":                            "La Toile Du Quebec Via Google",
-		"web.volny.cz":                             "Volny",
-		"web.whatsapp.com":                         "WhatsApp",
-		"webcache.googleusercontent.com":           "Google",
-		"webcrawler.com":                           "InfoSpace",
-		"webfetch.com":                             "InfoSpace",
-		"webmail.2degreesbroadband.co.nz":          "2degrees",
-		"webmail.adam.com.au":                      "Adam Internet",
-		"webmail.bigpond.com":                      "Bigpond",
-		"webmail.commander.net.au":                 "Commander",
-		"webmail.dodo.com.au":                      "Dodo",
-		"webmail.freenet.de":                       "Freenet",
-		"webmail.iinet.net.au":                     "iiNet",
-		"webmail.iprimus.com.au":                   "iPrimus",
-		"webmail.netspace.net.au":                  "Netspace",
-		"webmail.optusnet.com.au":                  "Optus Zoo",
-		"webmail.optuszoo.com.au":                  "Optus Zoo",
-		"webmail.virginbroadband.com.au":           "Virgin",
-		"webmail.vodafone.co.nz":                   "Vodafone",
-		"webmail.westnet.com.au":                   "Westnet",
-		"webmail2.bigpond.com":                     "Bigpond",
-		"websearch.cs.com":                         "Compuserve",
-		"websearch.rakuten.co.jp":                  "Rakuten",
-		"weeworld.com":                             "WeeWorld",
-		"weibo.com":                                "Weibo",
-		"wunderloop.net":                           "AudienceScience",
-		"www.1881.no":                              "Opplysningen 1881",
-		"www.2gis.ru":                              "2gis",
-		"www.abacho.at":                            "Abacho",
-		"www.abacho.ch":                            "Abacho",
-		"www.abacho.co.uk":                         "Abacho",
-		"www.abacho.com":                           "Abacho",
-		"www.abacho.de":                            "Abacho",
-		"www.abacho.es":                            "Abacho",
-		"www.abacho.fr":                            "Abacho",
-		"www.abacho.it":                            "Abacho",
-		"www.acoon.de":                             "Acoon",
-		"www.adfox.ru":                             "ADFOX",
-		"www.ads.adfox.ru":                         "ADFOX",
-		"www.alltheweb.com":                        "AllTheWeb",
-		"www.altavista.com":                        "Altavista",
-		"www.amazon.com":                           "Amazon",
-		"www.aolimages.aol.fr":                     "AOL",
-		"www.aolrecherche.aol.fr":                  "AOL",
-		"www.aolrecherches.aol.fr":                 "AOL",
-		"www.apontador.com.br":                     "Apontador",
-		"www.arcor.de":                             "Arcor",
-		"www.arianna.com":                          "Arianna",
-		"www.ask.co.uk":                            "Ask",
-		"www.ask.com":                              "Ask",
-		"www.askkids.com":                          "Ask",
-		"www.baidu.com":                            "Baidu",
-		"www.bing.com":                             "Bing",
-		"www.bing.com/images/search":               "Bing Images",
-		"www.blogdigger.com":                       "Blogdigger",
-		"www.blogpulse.com":                        "Blogpulse",
-		"www.cercato.it":                           "Yahoo!",
-		"www.charter.net":                          "Charter",
-		"www.cnn.com":                              "Google",
-		"www.crawler.com":                          "Crawler",
-		"www.cuil.com":                             "Cuil",
-		"www.dalesearch.com":                       "Dalesearch",
-		"www.dasoertliche.de":                      "DasOertliche",
-		"www.dogpile.com":                          "InfoSpace",
-		"www.eniro.se":                             "Eniro",
-		"www.eu.ixquick.com":                       "IXquick",
-		"www.eurip.com":                            "Eurip",
-		"www.euroseek.com":                         "Euroseek",
-		"www.everyclick.com":                       "Everyclick",
-		"www.exalead.com":                          "Exalead",
-		"www.exalead.fr":                           "Exalead",
-		"www.excite.co.jp":                         "Excite",
-		"www.fastbrowsersearch.com":                "Fast Browser Search",
-		"www.fastweb.it":                           "Google",
-		"www.finderoo.com":                         "Finderoo",
-		"www.fireball.de":                          "Fireball",
-		"www.firstsfind.com":                       "Firstfind",
-		"www.fixsuche.de":                          "Fixsuche",
-		"www.flix.de":                              "Flix",
-		"www.forestle.org":                         "Forestle",
-		"www.fresh-weather.com":                    "Freshweather",
-		"www.gigablast.com":                        "Gigablast",
-		"www.gnadenmeer.de":                        "Gnadenmeer",
-		"www.gomeo.com":                            "Gomeo",
-		"www.google.ac":                            "Google",
-		"www.google.ac/products":                   "Google Product Search",
-		"www.google.ad":                            "Google",
-		"www.google.ad/products":                   "Google Product Search",
-		"www.google.ae":                            "Google",
-		"www.google.ae/products":                   "Google Product Search",
-		"www.google.am":                            "Google",
-		"www.google.am/products":                   "Google Product Search",
-		"www.google.as":                            "Google",
-		"www.google.as/products":                   "Google Product Search",
-		"www.google.at":                            "Google",
-		"www.google.at/products":                   "Google Product Search",
-		"www.google.az":                            "Google",
-		"www.google.az/products":                   "Google Product Search",
-		"www.google.ba":                            "Google",
-		"www.google.ba/products":                   "Google Product Search",
-		"www.google.be":                            "Google",
-		"www.google.be/products":                   "Google Product Search",
-		"www.google.bf":                            "Google",
-		"www.google.bf/products":                   "Google Product Search",
-		"www.google.bg":                            "Google",
-		"www.google.bg/products":                   "Google Product Search",
-		"www.google.bi":                            "Google",
-		"www.google.bi/products":                   "Google Product Search",
-		"www.google.bj":                            "Google",
-		"www.google.bj/products":                   "Google Product Search",
-		"www.google.bs":                            "Google",
-		"www.google.bs/products":                   "Google Product Search",
-		"www.google.by":                            "Google",
-		"www.google.by/products":                   "Google Product Search",
-		"www.google.ca":                            "Google",
-		"www.google.ca/products":                   "Google Product Search",
-		"www.google.cat":                           "Google",
-		"www.google.cat/products":                  "Google Product Search",
-		"www.google.cc":                            "Google",
-		"www.google.cc/products":                   "Google Product Search",
-		"www.google.cd":                            "Google",
-		"www.google.cd/products":                   "Google Product Search",
-		"www.google.cf":                            "Google",
-		"www.google.cf/products":                   "Google Product Search",
-		"www.google.cg":                            "Google",
-		"www.google.cg/products":                   "Google Product Search",
-		"www.google.ch":                            "Google",
-		"www.google.ch/products":                   "Google Product Search",
-		"www.google.ci":                            "Google",
-		"www.google.ci/products":                   "Google Product Search",
-		"www.google.cl":                            "Google",
-		"www.google.cl/products":                   "Google Product Search",
-		"www.google.cm":                            "Google",
-		"www.google.cm/products":                   "Google Product Search",
-		"www.google.cn":                            "Google",
-		"www.google.cn/products":                   "Google Product Search",
-		"www.google.co.bw":                         "Google",
-		"www.google.co.bw/products":                "Google Product Search",
-		"www.google.co.ck":                         "Google",
-		"www.google.co.ck/products":                "Google Product Search",
-		"www.google.co.cr":                         "Google",
-		"www.google.co.cr/products":                "Google Product Search",
-		"www.google.co.id":                         "Google",
-		"www.google.co.id/products":                "Google Product Search",
-		"www.google.co.il":                         "Google",
-		"www.google.co.il/products":                "Google Product Search",
-		"www.google.co.in":                         "Google",
-		"www.google.co.in/products":                "Google Product Search",
-		"www.google.co.jp":                         "Google",
-		"www.google.co.jp/products":                "Google Product Search",
-		"www.google.co.ke":                         "Google",
-		"www.google.co.ke/products":                "Google Product Search",
-		"www.google.co.kr":                         "Google",
-		"www.google.co.kr/products":                "Google Product Search",
-		"www.google.co.ls":                         "Google",
-		"www.google.co.ls/products":                "Google Product Search",
-		"www.google.co.ma":                         "Google",
-		"www.google.co.ma/products":                "Google Product Search",
-		"www.google.co.mz":                         "Google",
-		"www.google.co.mz/products":                "Google Product Search",
-		"www.google.co.nz":                         "Google",
-		"www.google.co.nz/products":                "Google Product Search",
-		"www.google.co.th":                         "Google",
-		"www.google.co.th/products":                "Google Product Search",
-		"www.google.co.tz":                         "Google",
-		"www.google.co.tz/products":                "Google Product Search",
-		"www.google.co.ug":                         "Google",
-		"www.google.co.ug/products":                "Google Product Search",
-		"www.google.co.uk":                         "Google",
-		"www.google.co.uk/products":                "Google Product Search",
-		"www.google.co.uz":                         "Google",
-		"www.google.co.uz/products":                "Google Product Search",
-		"www.google.co.ve":                         "Google",
-		"www.google.co.ve/products":                "Google Product Search",
-		"www.google.co.vi":                         "Google",
-		"www.google.co.vi/products":                "Google Product Search",
-		"www.google.co.za":                         "Google",
-		"www.google.co.za/products":                "Google Product Search",
-		"www.google.co.zm":                         "Google",
-		"www.google.co.zm/products":                "Google Product Search",
-		"www.google.co.zw":                         "Google",
-		"www.google.co.zw/products":                "Google Product Search",
-		"www.google.com":                           "Google",
-		"www.google.com.af":                        "Google",
-		"www.google.com.af/products":               "Google Product Search",
-		"www.google.com.ag":                        "Google",
-		"www.google.com.ag/products":               "Google Product Search",
-		"www.google.com.ai":                        "Google",
-		"www.google.com.ai/products":               "Google Product Search",
-		"www.google.com.ar":                        "Google",
-		"www.google.com.ar/products":               "Google Product Search",
-		"www.google.com.au":                        "Google",
-		"www.google.com.au/products":               "Google Product Search",
-		"www.google.com.bd":                        "Google",
-		"www.google.com.bd/products":               "Google Product Search",
-		"www.google.com.bh":                        "Google",
-		"www.google.com.bh/products":               "Google Product Search",
-		"www.google.com.bn":                        "Google",
-		"www.google.com.bn/products":               "Google Product Search",
-		"www.google.com.bo":                        "Google",
-		"www.google.com.bo/products":               "Google Product Search",
-		"www.google.com.br":                        "Google",
-		"www.google.com.br/products":               "Google Product Search",
-		"www.google.com.by":                        "Google",
-		"www.google.com.by/products":               "Google Product Search",
-		"www.google.com.bz":                        "Google",
-		"www.google.com.bz/products":               "Google Product Search",
-		"www.google.com.co":                        "Google",
-		"www.google.com.co/products":               "Google Product Search",
-		"www.google.com.cu":                        "Google",
-		"www.google.com.cu/products":               "Google Product Search",
-		"www.google.com.cy":                        "Google",
-		"www.google.com.cy/products":               "Google Product Search",
-		"www.google.com.do":                        "Google",
-		"www.google.com.do/products":               "Google Product Search",
-		"www.google.com.ec":                        "Google",
-		"www.google.com.ec/products":               "Google Product Search",
-		"www.google.com.eg":                        "Google",
-		"www.google.com.eg/products":               "Google Product Search",
-		"www.google.com.et":                        "Google",
-		"www.google.com.et/products":               "Google Product Search",
-		"www.google.com.fj":                        "Google",
-		"www.google.com.fj/products":               "Google Product Search",
-		"www.google.com.gh":                        "Google",
-		"www.google.com.gh/products":               "Google Product Search",
-		"www.google.com.gi":                        "Google",
-		"www.google.com.gi/products":               "Google Product Search",
-		"www.google.com.gt":                        "Google",
-		"www.google.com.gt/products":               "Google Product Search",
-		"www.google.com.hk":                        "Google",
-		"www.google.com.hk/products":               "Google Product Search",
-		"www.google.com.jm":                        "Google",
-		"www.google.com.jm/products":               "Google Product Search",
-		"www.google.com.kh":                        "Google",
-		"www.google.com.kh/products":               "Google Product Search",
-		"www.google.com.kw":                        "Google",
-		"www.google.com.kw/products":               "Google Product Search",
-		"www.google.com.lb":                        "Google",
-		"www.google.com.lb/products":               "Google Product Search",
-		"www.google.com.lc":                        "Google",
-		"www.google.com.lc/products":               "Google Product Search",
-		"www.google.com.ly":                        "Google",
-		"www.google.com.ly/products":               "Google Product Search",
-		"www.google.com.mt":                        "Google",
-		"www.google.com.mt/products":               "Google Product Search",
-		"www.google.com.mx":                        "Google",
-		"www.google.com.mx/products":               "Google Product Search",
-		"www.google.com.my":                        "Google",
-		"www.google.com.my/products":               "Google Product Search",
-		"www.google.com.na":                        "Google",
-		"www.google.com.na/products":               "Google Product Search",
-		"www.google.com.nf":                        "Google",
-		"www.google.com.nf/products":               "Google Product Search",
-		"www.google.com.ng":                        "Google",
-		"www.google.com.ng/products":               "Google Product Search",
-		"www.google.com.ni":                        "Google",
-		"www.google.com.ni/products":               "Google Product Search",
-		"www.google.com.np":                        "Google",
-		"www.google.com.np/products":               "Google Product Search",
-		"www.google.com.om":                        "Google",
-		"www.google.com.om/products":               "Google Product Search",
-		"www.google.com.pa":                        "Google",
-		"www.google.com.pa/products":               "Google Product Search",
-		"www.google.com.pe":                        "Google",
-		"www.google.com.pe/products":               "Google Product Search",
-		"www.google.com.ph":                        "Google",
-		"www.google.com.ph/products":               "Google Product Search",
-		"www.google.com.pk":                        "Google",
-		"www.google.com.pk/products":               "Google Product Search",
-		"www.google.com.pr":                        "Google",
-		"www.google.com.pr/products":               "Google Product Search",
-		"www.google.com.py":                        "Google",
-		"www.google.com.py/products":               "Google Product Search",
-		"www.google.com.qa":                        "Google",
-		"www.google.com.qa/products":               "Google Product Search",
-		"www.google.com.sa":                        "Google",
-		"www.google.com.sa/products":               "Google Product Search",
-		"www.google.com.sb":                        "Google",
-		"www.google.com.sb/products":               "Google Product Search",
-		"www.google.com.sg":                        "Google",
-		"www.google.com.sg/products":               "Google Product Search",
-		"www.google.com.sl":                        "Google",
-		"www.google.com.sl/products":               "Google Product Search",
-		"www.google.com.sv":                        "Google",
-		"www.google.com.sv/products":               "Google Product Search",
-		"www.google.com.tj":                        "Google",
-		"www.google.com.tj/products":               "Google Product Search",
-		"www.google.com.tn":                        "Google",
-		"www.google.com.tn/products":               "Google Product Search",
-		"www.google.com.tr":                        "Google",
-		"www.google.com.tr/products":               "Google Product Search",
-		"www.google.com.tw":                        "Google",
-		"www.google.com.tw/products":               "Google Product Search",
-		"www.google.com.ua":                        "Google",
-		"www.google.com.ua/products":               "Google Product Search",
-		"www.google.com.uy":                        "Google",
-		"www.google.com.uy/products":               "Google Product Search",
-		"www.google.com.vc":                        "Google",
-		"www.google.com.vc/products":               "Google Product Search",
-		"www.google.com.vn":                        "Google",
-		"www.google.com.vn/products":               "Google Product Search",
-		"www.google.com/products":                  "Google Product Search",
-		"www.google.cv":                            "Google",
-		"www.google.cv/products":                   "Google Product Search",
-		"www.google.cz":                            "Google",
-		"www.google.cz/products":                   "Google Product Search",
-		"www.google.de":                            "Google",
-		"www.google.de/products":                   "Google Product Search",
-		"www.google.dj":                            "Google",
-		"www.google.dj/products":                   "Google Product Search",
-		"www.google.dk":                            "Google",
-		"www.google.dk/products":                   "Google Product Search",
-		"www.google.dm":                            "Google",
-		"www.google.dm/products":                   "Google Product Search",
-		"www.google.dz":                            "Google",
-		"www.google.dz/products":                   "Google Product Search",
-		"www.google.ee":                            "Google",
-		"www.google.ee/products":                   "Google Product Search",
-		"www.google.es":                            "Google",
-		"www.google.es/products":                   "Google Product Search",
-		"www.google.fi":                            "Google",
-		"www.google.fi/products":                   "Google Product Search",
-		"www.google.fm":                            "Google",
-		"www.google.fm/products":                   "Google Product Search",
-		"www.google.fr":                            "Google",
-		"www.google.fr/products":                   "Google Product Search",
-		"www.google.ga":                            "Google",
-		"www.google.ga/products":                   "Google Product Search",
-		"www.google.gd":                            "Google",
-		"www.google.gd/products":                   "Google Product Search",
-		"www.google.ge":                            "Google",
-		"www.google.ge/products":                   "Google Product Search",
-		"www.google.gf":                            "Google",
-		"www.google.gf/products":                   "Google Product Search",
-		"www.google.gg":                            "Google",
-		"www.google.gg/products":                   "Google Product Search",
-		"www.google.gl":                            "Google",
-		"www.google.gl/products":                   "Google Product Search",
-		"www.google.gm":                            "Google",
-		"www.google.gm/products":                   "Google Product Search",
-		"www.google.gp":                            "Google",
-		"www.google.gp/products":                   "Google Product Search",
-		"www.google.gr":                            "Google",
-		"www.google.gr/products":                   "Google Product Search",
-		"www.google.gy":                            "Google",
-		"www.google.gy/products":                   "Google Product Search",
-		"www.google.hn":                            "Google",
-		"www.google.hn/products":                   "Google Product Search",
-		"www.google.hr":                            "Google",
-		"www.google.hr/products":                   "Google Product Search",
-		"www.google.ht":                            "Google",
-		"www.google.ht/products":                   "Google Product Search",
-		"www.google.hu":                            "Google",
-		"www.google.hu/products":                   "Google Product Search",
-		"www.google.ie":                            "Google",
-		"www.google.ie/products":                   "Google Product Search",
-		"www.google.im":                            "Google",
-		"www.google.im/products":                   "Google Product Search",
-		"www.google.interia.pl":                    "Interia",
-		"www.google.io":                            "Google",
-		"www.google.io/products":                   "Google Product Search",
-		"www.google.iq":                            "Google",
-		"www.google.iq/products":                   "Google Product Search",
-		"www.google.is":                            "Google",
-		"www.google.is/products":                   "Google Product Search",
-		"www.google.it":                            "Google",
-		"www.google.it.ao":                         "Google",
-		"www.google.it.ao/products":                "Google Product Search",
-		"www.google.it/products":                   "Google Product Search",
-		"www.google.je":                            "Google",
-		"www.google.je/products":                   "Google Product Search",
-		"www.google.jo":                            "Google",
-		"www.google.jo/products":                   "Google Product Search",
-		"www.google.kg":                            "Google",
-		"www.google.kg/products":                   "Google Product Search",
-		"www.google.ki":                            "Google",
-		"www.google.ki/products":                   "Google Product Search",
-		"www.google.kz":                            "Google",
-		"www.google.kz/products":                   "Google Product Search",
-		"www.google.la":                            "Google",
-		"www.google.la/products":                   "Google Product Search",
-		"www.google.li":                            "Google",
-		"www.google.li/products":                   "Google Product Search",
-		"www.google.lk":                            "Google",
-		"www.google.lk/products":                   "Google Product Search",
-		"www.google.lt":                            "Google",
-		"www.google.lt/products":                   "Google Product Search",
-		"www.google.lu":                            "Google",
-		"www.google.lu/products":                   "Google Product Search",
-		"www.google.lv":                            "Google",
-		"www.google.lv/products":                   "Google Product Search",
-		"www.google.md":                            "Google",
-		"www.google.md/products":                   "Google Product Search",
-		"www.google.me":                            "Google",
-		"www.google.me/products":                   "Google Product Search",
-		"www.google.mg":                            "Google",
-		"www.google.mg/products":                   "Google Product Search",
-		"www.google.mk":                            "Google",
-		"www.google.mk/products":                   "Google Product Search",
-		"www.google.ml":                            "Google",
-		"www.google.ml/products":                   "Google Product Search",
-		"www.google.mn":                            "Google",
-		"www.google.mn/products":                   "Google Product Search",
-		"www.google.ms":                            "Google",
-		"www.google.ms/products":                   "Google Product Search",
-		"www.google.mu":                            "Google",
-		"www.google.mu/products":                   "Google Product Search",
-		"www.google.mv":                            "Google",
-		"www.google.mv/products":                   "Google Product Search",
-		"www.google.mw":                            "Google",
-		"www.google.mw/products":                   "Google Product Search",
-		"www.google.ne":                            "Google",
-		"www.google.ne/products":                   "Google Product Search",
-		"www.google.nl":                            "Google",
-		"www.google.nl/products":                   "Google Product Search",
-		"www.google.no":                            "Google",
-		"www.google.no/products":                   "Google Product Search",
-		"www.google.nr":                            "Google",
-		"www.google.nr/products":                   "Google Product Search",
-		"www.google.nu":                            "Google",
-		"www.google.nu/products":                   "Google Product Search",
-		"www.google.pl":                            "Google",
-		"www.google.pl/products":                   "Google Product Search",
-		"www.google.pn":                            "Google",
-		"www.google.pn/products":                   "Google Product Search",
-		"www.google.ps":                            "Google",
-		"www.google.ps/products":                   "Google Product Search",
-		"www.google.pt":                            "Google",
-		"www.google.pt/products":                   "Google Product Search",
-		"www.google.ro":                            "Google",
-		"www.google.ro/products":                   "Google Product Search",
-		"www.google.rs":                            "Google",
-		"www.google.rs/products":                   "Google Product Search",
-		"www.google.ru":                            "Google",
-		"www.google.ru/products":                   "Google Product Search",
-		"www.google.rw":                            "Google",
-		"www.google.rw/products":                   "Google Product Search",
-		"www.google.sc":                            "Google",
-		"www.google.sc/products":                   "Google Product Search",
-		"www.google.se":                            "Google",
-		"www.google.se/products":                   "Google Product Search",
-		"www.google.sh":                            "Google",
-		"www.google.sh/products":                   "Google Product Search",
-		"www.google.si":                            "Google",
-		"www.google.si/products":                   "Google Product Search",
-		"www.google.sk":                            "Google",
-		"www.google.sk/products":                   "Google Product Search",
-		"www.google.sm":                            "Google",
-		"www.google.sm/products":                   "Google Product Search",
-		"www.google.sn":                            "Google",
-		"www.google.sn/products":                   "Google Product Search",
-		"www.google.so":                            "Google",
-		"www.google.so/products":                   "Google Product Search",
-		"www.google.st":                            "Google",
-		"www.google.st/products":                   "Google Product Search",
-		"www.google.td":                            "Google",
-		"www.google.td/products":                   "Google Product Search",
-		"www.google.tg":                            "Google",
-		"www.google.tg/products":                   "Google Product Search",
-		"www.google.tk":                            "Google",
-		"www.google.tk/products":                   "Google Product Search",
-		"www.google.tl":                            "Google",
-		"www.google.tl/products":                   "Google Product Search",
-		"www.google.tm":                            "Google",
-		"www.google.tm/products":                   "Google Product Search",
-		"www.google.tn":                            "Google",
-		"www.google.to":                            "Google",
-		"www.google.to/products":                   "Google Product Search",
-		"www.google.tt":                            "Google",
-		"www.google.tt/products":                   "Google Product Search",
-		"www.google.us":                            "Google",
-		"www.google.us/products":                   "Google Product Search",
-		"www.google.vg":                            "Google",
-		"www.google.vg/products":                   "Google Product Search",
-		"www.google.vu":                            "Google",
-		"www.google.vu/products":                   "Google Product Search",
-		"www.google.ws":                            "Google",
-		"www.google.ws/products":                   "Google Product Search",
-		"www.googleadservices.com":                 "Google",
-		"www.googleearth.de":                       "Google",
-		"www.googleearth.fr":                       "Google",
-		"www.gooofullsearch.com":                   "Google",
-		"www.goyellow.de":                          "Goyellow.de",
-		"www.gulesider.no":                         "Gule Sider",
-		"www.highbeam.com":                         "HighBeam",
-		"www.hit-parade.com":                       "Hit-Parade",
-		"www.hooseek.com":                          "Hooseek.com",
-		"www.hotbot.com":                           "Hotbot",
-		"www.icq.com":                              "ICQ",
-		"www.ilse.nl":                              "Ilse",
-		"www.ixquick.de":                           "IXquick",
-		"www.jungle-spider.de":                     "Jungle Spider",
-		"www.kataweb.it":                           "Kataweb",
-		"www.kvasir.no":                            "Kvasir",
-		"www.latne.lv":                             "Latne",
-		"www.lemoteur.fr":                          "Voila",
-		"www.link.2gis.ru":                         "2gis",
-		"www.looksmart.com":                        "Looksmart",
-		"www.lycos.com":                            "Lycos",
-		"www.maailm.com":                           "maailm",
-		"www.mamma.com":                            "Mamma",
-		"www.marktplaats.nl":                       "Marktplaats",
-		"www.meinestadt.de":                        "Meinestadt",
-		"www.metager.de":                           "Metager",
-		"www.mister-wong.com":                      "Mister Wong",
-		"www.mister-wong.de":                       "Mister Wong",
-		"www.monster.be":                           "Monster",
-		"www.monster.ch":                           "Monster",
-		"www.monster.co.uk":                        "Monster",
-		"www.monster.cz":                           "Monster",
-		"www.monster.de":                           "Monster",
-		"www.monster.fi":                           "Monster",
-		"www.monster.fr":                           "Monster",
-		"www.monster.ie":                           "Monster",
-		"www.monster.it":                           "Monster",
-		"www.monster.lu":                           "Monster",
-		"www.monstercrawler.com":                   "Monstercrawler",
-		"www.mozbot.co.uk":                         "Mozbot",
-		"www.mozbot.com":                           "Mozbot",
-		"www.mozbot.fr":                            "Mozbot",
-		"www.mysearch.com":                         "MySearch",
-		"www.najdi.si":                             "Najdi",
-		"www.neti.ee":                              "Neti",
-		"www.paperball.de":                         "Paperball",
-		"www.picsearch.com":                        "Picsearch",
-		"www.plazoo.com":                           "Plazoo",
-		"www.pricerunner.co.uk":                    "PriceRunner",
-		"www.qbyrd.com":                            "Ask",
-		"www.qualigo.at":                           "Qualigo",
-		"www.qualigo.ch":                           "Qualigo",
-		"www.qualigo.de":                           "Qualigo",
-		"www.qualigo.nl":                           "Qualigo",
-		"www.qwant.com":                            "Qwant",
-		"www.recherche.aol.fr":                     "AOL",
-		"www.se.abacho.com":                        "Abacho",
-		"www.search-results.com":                   "Ask",
-		"www.search.ch":                            "Search.ch",
-		"www.search.com":                           "Search.com",
-		"www.searchcanvas.com":                     "SearchCanvas",
-		"www.searchthis.com":                       "Search This",
-		"www.searchy.co.uk":                        "Searchy",
-		"www.sharelook.fr":                         "Sharelook",
-		"www.skynet.be":                            "Skynet",
-		"www.so.com":                               "360.cn",
-		"www.soso.com":                             "Sogou",
-		"www.sougou.com":                           "Sogou",
-		"www.startpage.com":                        "Startpage",
-		"www.startsiden.no":                        "Startsiden",
-		"www.stepstone.at":                         "StepStone",
-		"www.stepstone.be":                         "StepStone",
-		"www.stepstone.de":                         "StepStone",
-		"www.stepstone.dk":                         "StepStone",
-		"www.stepstone.fr":                         "StepStone",
-		"www.stepstone.nl":                         "StepStone",
-		"www.stepstone.se":                         "StepStone",
-		"www.suchmaschine.com":                     "Suchmaschine.com",
-		"www.suchnase.de":                          "Suchnase",
-		"www.talktalk.co.uk":                       "TalkTalk",
-		"www.teoma.com":                            "Teoma",
-		"www.thesmartsearch.net":                   "The Smart Search",
-		"www.tixuma.de":                            "Tixuma",
-		"www.toile.com":                            "La Toile Du Quebec Via Google",
-		"www.toolbarhome.com":                      "Toolbarhome",
-		"www.tr.abacho.com":                        "Abacho",
-		"www.trouvez.com":                          "Trouvez.com",
-		"www.trovarapido.com":                      "TrovaRapido",
-		"www.trusted--search.com":                  "Trusted-Search",
-		"www.twingly.com":                          "Twingly",
-		"www.url.org":                              "URL.ORGanizier",
-		"www.vinden.nl":                            "Vinden",
-		"www.vindex.nl":                            "Vindex",
-		"www.walhello.com":                         "Walhello",
-		"www.walhello.de":                          "Walhello",
-		"www.walhello.info":                        "Walhello",
-		"www.walhello.nl":                          "Walhello",
-		"www.web.nl":                               "Web.nl",
-		"www.weborama.com":                         "Weborama",
-		"www.websearch.com":                        "WebSearch",
-		"www.whitepages.com.au":                    "White Pages",
-		"www.witch.de":                             "Witch",
-		"www.x-recherche.com":                      "X-recherche",
-		"www.yahoo.co.jp":                          "Yahoo!",
-		"www.yandex.by":                            "Yandex",
-		"www.yandex.com":                           "Yandex",
-		"www.yandex.ru":                            "Yandex",
-		"www.yandex.ua":                            "Yandex",
-		"www.yasni.at":                             "Yasni",
-		"www.yasni.ch":                             "Yasni",
-		"www.yasni.co.uk":                          "Yasni",
-		"www.yasni.com":                            "Yasni",
-		"www.yasni.de":                             "Yasni",
-		"www.yatedo.com":                           "Yatedo",
-		"www.yatedo.fr":                            "Yatedo",
-		"www.yougoo.fr":                            "YouGoo",
-		"www.zapmeta.com":                          "Zapmeta",
-		"www.zapmeta.de":                           "Zapmeta",
-		"www.zapmeta.nl":                           "Zapmeta",
-		"www.zoeken.nl":                            "Zoeken",
-		"www1.astronaut.at":                        "Austronaut",
-		"www1.baidu.com":                           "Baidu",
-		"www1.dastelefonbuch.de":                   "DasTelefonbuch",
-		"www2.austronaut.at":                       "Austronaut",
-		"www2.bing.com":                            "Bing",
-		"www3.zoek.nl":                             "Zoek",
-		"www4.bing.com":                            "Bing",
-		"xanga.com":                                "Xanga",
-		"xing.com":                                 "XING",
-		"yabs.yandex.by":                           "Yandex.Direct",
-		"yabs.yandex.com":                          "Yandex.Direct",
-		"yabs.yandex.ru":                           "Yandex.Direct",
-		"yabs.yandex.ua":                           "Yandex.Direct",
-		"yahoo.com":                                "Yahoo",
-		"yandex.by":                                "Yandex",
-		"yandex.com":                               "Yandex",
-		"yandex.ru":                                "Yandex",
-		"yandex.ua":                                "Yandex",
-		"yelp.com":                                 "Yelp",
-		"yelp.fr":                                  "Yelp",
-		"yelp.ie":                                  "Yelp",
-		"yelp.it":                                  "Yelp",
-		"yieldmo.com":                              "Yieldmo",
-		"youtu.be":                                 "Youtube",
-		"youtube.com":                              "Youtube",
-		"ys.mirostart.com":                         "Yahoo!",
-		"yz.m.sm.cn":                               "Shenma",
-		"z1.zedo.com":                              "ZEDO",
-		"zedo.com":                                 "ZEDO",
-		"zhidao.baidu.com":                         "Baidu",
-		"zoohoo.cz":                                "Zoohoo",
-	}
+	//go:embed blacklist.txt
+	blacklistData []byte
+
+	//go:embed groups.txt
+	groupsData []byte
+
+	//go:embed apps.txt
+	appsData []byte
+
+	// Blacklist is the list of referrer hosts to ignore.
+	// It defaults to the embedded list and can be updated at runtime.
+	Blacklist = lists.New(lists.MustParse(blacklistData))
+
+	// Groups maps referrer hosts (optionally including the path) to a name.
+	// It defaults to the embedded list and can be updated at runtime.
+	Groups = lists.New(lists.MustParse(groupsData))
+
+	// Apps maps app referrers to the app name and an optional icon, separated by a tab.
+	// It's used by the default AppResolver and can be updated at runtime.
+	Apps = lists.New(lists.MustParse(appsData))
 )
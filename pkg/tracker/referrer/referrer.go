@@ -26,7 +26,14 @@ var QueryParams = []struct {
 var isDomain = regexp.MustCompile("^.*\\.[a-zA-Z]+$")
 
 // Ignore returns whether a referrer should be ignored or not.
+// This is a shortcut for IgnoreForClient using client 0.
 func Ignore(r *http.Request) bool {
+	return IgnoreForClient(r, 0)
+}
+
+// IgnoreForClient returns whether a referrer should be ignored or not,
+// taking the Blacklist entries added and excluded for given client into account.
+func IgnoreForClient(r *http.Request, clientID uint64) bool {
 	referrer := getFromHeaderOrQuery(r)
 
 	if referrer == "" {
@@ -39,13 +46,19 @@ func Ignore(r *http.Request) bool {
 		referrer = u.Hostname()
 	}
 
-	referrer = stripSubdomain(referrer)
-	_, found := blacklist[referrer]
+	_, found := Blacklist.Get(clientID, stripSubdomain(strings.ToLower(referrer)))
 	return found
 }
 
 // Get returns the referrer for given request.
+// This is a shortcut for GetForClient using client 0.
 func Get(r *http.Request, ref, requestHostname string) (string, string, string) {
+	return GetForClient(r, 0, ref, requestHostname)
+}
+
+// GetForClient returns the referrer for given request,
+// taking the Groups entries added and excluded for given client into account.
+func GetForClient(r *http.Request, clientID uint64, ref, requestHostname string) (string, string, string) {
 	referrer := ""
 
 	if ref != "" {
@@ -58,9 +71,9 @@ func Get(r *http.Request, ref, requestHostname string) (string, string, string)
 		return "", "", ""
 	}
 
-	if strings.HasPrefix(strings.ToLower(referrer), androidAppPrefix) {
-		name, icon := androidAppCache.get(referrer)
-		return referrer, name, icon
+	if platform, id, ok := parseApp(referrer); ok {
+		app, _ := getAppResolver().Resolve(platform, id)
+		return referrer, app.Name, app.Icon
 	}
 
 	var u *url.URL
@@ -101,11 +114,11 @@ func Get(r *http.Request, ref, requestHostname string) (string, string, string)
 		return "", "", ""
 	}
 
-	name := groups[hostname+u.Path]
+	name, _ := Groups.Get(clientID, strings.ToLower(hostname+u.Path))
 
 	if name == "" {
 		// try again without path
-		name = groups[hostname]
+		name, _ = Groups.Get(clientID, hostname)
 	}
 
 	if name == "" {
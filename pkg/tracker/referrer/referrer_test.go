@@ -2,6 +2,7 @@ package referrer
 
 import (
 	"fmt"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/tracker/lists"
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
@@ -196,6 +197,46 @@ func TestGetFromHeaderOrQuery(t *testing.T) {
 	}
 }
 
+func TestIgnoreForClient(t *testing.T) {
+	Blacklist.SetClient(42, lists.Entries{"spam.com": ""}, []string{"temp-mail.org"})
+	defer Blacklist.RemoveClient(42)
+	input := []string{
+		"https://temp-mail.org/",
+		"https://sub.spam.com/",
+		"https://example.com/",
+	}
+	expectedGlobal := []bool{true, false, false}
+	expectedClient := []bool{false, true, false}
+
+	for i, in := range input {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Add("Referer", in)
+		assert.Equal(t, expectedGlobal[i], Ignore(r), in)
+		assert.Equal(t, expectedClient[i], IgnoreForClient(r, 42), in)
+	}
+}
+
+func TestGetForClient(t *testing.T) {
+	Groups.SetClient(42, lists.Entries{"example.com": "Example"}, []string{"t.co"})
+	defer Groups.RemoveClient(42)
+	input := []string{
+		"https://example.com/",
+		"https://t.co/",
+		"https://www.google.com/",
+	}
+	expectedGlobal := []string{"example.com", "Twitter", "Google"}
+	expectedClient := []string{"Example", "t.co", "Google"}
+
+	for i, in := range input {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Add("Referer", in)
+		_, referrerName, _ := Get(r, "", "")
+		assert.Equal(t, expectedGlobal[i], referrerName)
+		_, referrerName, _ = GetForClient(r, 42, "", "")
+		assert.Equal(t, expectedClient[i], referrerName)
+	}
+}
+
 func TestStripSubdomain(t *testing.T) {
 	input := []string{
 		"",
@@ -225,20 +266,30 @@ func TestStripSubdomain(t *testing.T) {
 	}
 }
 
-func TestGetAndroidApp(t *testing.T) {
-	r := httptest.NewRequest(http.MethodGet, "/", nil)
-	r.Header.Add("Referer", androidAppPrefix+"com.Slack")
-	_, name, icon := Get(r, "", "")
-	assert.Equal(t, "Slack", name)
-	assert.NotEmpty(t, icon)
-	r = httptest.NewRequest(http.MethodGet, "/", nil)
-	r.Header.Add("Referer", androidAppPrefix+"com.pinterest/")
-	_, name, icon = Get(r, "", "")
-	assert.Equal(t, "Pinterest", name)
-	assert.NotEmpty(t, icon)
-	r.Header.Set("Referer", androidAppPrefix+"does-not-exist")
-	ref, name, icon := Get(r, "", "")
-	assert.Equal(t, androidAppPrefix+"does-not-exist", ref)
-	assert.Empty(t, name)
-	assert.Empty(t, icon)
+func TestGetApp(t *testing.T) {
+	input := []string{
+		androidAppPrefix + "com.Slack",
+		androidAppPrefix + "com.pinterest/",
+		androidAppPrefix + "does-not-exist",
+		iosAppPrefix + "618783545/slack/open",
+		"slack://open",
+		"unknown-app://",
+	}
+	expected := []string{
+		"Slack",
+		"Pinterest",
+		"",
+		"Slack",
+		"Slack",
+		"",
+	}
+
+	for i, in := range input {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Add("Referer", in)
+		ref, name, icon := Get(r, "", "")
+		assert.Equal(t, in, ref)
+		assert.Equal(t, expected[i], name)
+		assert.Empty(t, icon)
+	}
 }
@@ -0,0 +1,208 @@
+package session
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/model"
+	"time"
+)
+
+// sessionEncodingVersion must be increased whenever the binary layout of a session changes.
+const sessionEncodingVersion = 3
+
+var errSessionEncoding = errors.New("invalid session encoding")
+
+// encodeSession encodes a session into a compact binary representation.
+// Integers are stored as varints, strings are prefixed by their length, and times as Unix nanoseconds.
+func encodeSession(session *model.Session) []byte {
+	b := make([]byte, 0, 256)
+	b = append(b, sessionEncodingVersion, byte(session.Sign))
+	b = binary.AppendUvarint(b, session.ClientID)
+	b = binary.AppendUvarint(b, session.VisitorID)
+	b = binary.AppendUvarint(b, uint64(session.SessionID))
+	b = appendTime(b, session.Time)
+	b = appendTime(b, session.Start)
+	b = binary.AppendUvarint(b, uint64(session.DurationSeconds))
+	b = binary.AppendUvarint(b, uint64(session.PageViews))
+	b = binary.AppendUvarint(b, uint64(session.Extended))
+	b = binary.AppendUvarint(b, uint64(session.ASN))
+	b = append(b, encodeFlags(session.IsBounce, session.Desktop, session.Mobile))
+
+	for _, str := range sessionStrings(session) {
+		b = binary.AppendUvarint(b, uint64(len(*str)))
+		b = append(b, *str...)
+	}
+
+	return b
+}
+
+// decodeSession decodes a session encoded by encodeSession.
+// JSON is accepted as well, so that sessions stored by earlier versions can still be read.
+func decodeSession(data []byte) (*model.Session, error) {
+	session := new(model.Session)
+
+	if len(data) > 0 && data[0] == '{' {
+		if err := json.Unmarshal(data, session); err != nil {
+			return nil, err
+		}
+
+		return session, nil
+	}
+
+	if len(data) < 2 || data[0] != sessionEncodingVersion {
+		return nil, errSessionEncoding
+	}
+
+	d := decoder{data: data[2:]}
+	session.Sign = int8(data[1])
+	session.ClientID = d.uvarint()
+	session.VisitorID = d.uvarint()
+	session.SessionID = uint32(d.uvarint())
+	session.Time = d.time()
+	session.Start = d.time()
+	session.DurationSeconds = uint32(d.uvarint())
+	session.PageViews = uint16(d.uvarint())
+	session.Extended = uint16(d.uvarint())
+	session.ASN = uint32(d.uvarint())
+	f := d.byte()
+	session.IsBounce = f&1 != 0
+	session.Desktop = f&2 != 0
+	session.Mobile = f&4 != 0
+
+	for _, str := range sessionStrings(session) {
+		*str = d.string()
+	}
+
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	return session, nil
+}
+
+// sessionStrings returns pointers to all string fields of a session in encoding order.
+func sessionStrings(session *model.Session) []*string {
+	return []*string{
+		&session.EntryPath,
+		&session.ExitPath,
+		&session.EntryTitle,
+		&session.ExitTitle,
+		&session.Language,
+		&session.CountryCode,
+		&session.Region,
+		&session.City,
+		&session.TimeZone,
+		&session.ASOrganization,
+		&session.Referrer,
+		&session.ReferrerName,
+		&session.ReferrerIcon,
+		&session.OS,
+		&session.OSVersion,
+		&session.Browser,
+		&session.BrowserVersion,
+		&session.DeviceType,
+		&session.DeviceVendor,
+		&session.DeviceModel,
+		&session.ScreenClass,
+		&session.UTMSource,
+		&session.UTMMedium,
+		&session.UTMCampaign,
+		&session.UTMContent,
+		&session.UTMTerm,
+	}
+}
+
+func encodeFlags(values ...bool) byte {
+	var f byte
+
+	for i, v := range values {
+		if v {
+			f |= 1 << i
+		}
+	}
+
+	return f
+}
+
+func appendTime(b []byte, t time.Time) []byte {
+	if t.IsZero() {
+		return binary.AppendVarint(b, 0)
+	}
+
+	return binary.AppendVarint(b, t.UnixNano())
+}
+
+type decoder struct {
+	data []byte
+	err  error
+}
+
+func (d *decoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+
+	v, n := binary.Uvarint(d.data)
+
+	if n <= 0 {
+		d.err = errSessionEncoding
+		return 0
+	}
+
+	d.data = d.data[n:]
+	return v
+}
+
+func (d *decoder) time() time.Time {
+	if d.err != nil {
+		return time.Time{}
+	}
+
+	v, n := binary.Varint(d.data)
+
+	if n <= 0 {
+		d.err = errSessionEncoding
+		return time.Time{}
+	}
+
+	d.data = d.data[n:]
+
+	if v == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, v).UTC()
+}
+
+func (d *decoder) byte() byte {
+	if d.err != nil {
+		return 0
+	}
+
+	if len(d.data) == 0 {
+		d.err = errSessionEncoding
+		return 0
+	}
+
+	b := d.data[0]
+	d.data = d.data[1:]
+	return b
+}
+
+func (d *decoder) string() string {
+	n := d.uvarint()
+
+	if d.err != nil {
+		return ""
+	}
+
+	if uint64(len(d.data)) < n {
+		d.err = errSessionEncoding
+		return ""
+	}
+
+	s := string(d.data[:n])
+	d.data = d.data[n:]
+	return s
+}
@@ -0,0 +1,65 @@
+package session
+
+import (
+	"encoding/json"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestEncodeSession(t *testing.T) {
+	session := &model.Session{
+		Sign:            -1,
+		ClientID:        42,
+		VisitorID:       1234567890123,
+		SessionID:       987,
+		Time:            time.Now().UTC(),
+		Start:           time.Now().Add(-time.Minute).UTC(),
+		DurationSeconds: 60,
+		EntryPath:       "/",
+		ExitPath:        "/exit",
+		PageViews:       3,
+		IsBounce:        false,
+		EntryTitle:      "Entry",
+		ExitTitle:       "Exit",
+		Language:        "en",
+		CountryCode:     "gb",
+		Region:          "gb-eng",
+		City:            "London",
+		TimeZone:        "Europe/London",
+		ASN:             20712,
+		ASOrganization:  "Andrews & Arnold Ltd",
+		Referrer:        "https://example.com",
+		ReferrerName:    "Example",
+		OS:              "Windows",
+		OSVersion:       "10",
+		Browser:         "Chrome",
+		BrowserVersion:  "119",
+		Desktop:         true,
+		DeviceType:      "desktop",
+		DeviceVendor:    "Apple",
+		DeviceModel:     "Mac",
+		ScreenClass:     "XL",
+		UTMSource:       "source",
+		UTMTerm:         "term",
+		Extended:        2,
+	}
+	data := encodeSession(session)
+	out, err := json.Marshal(session)
+	assert.NoError(t, err)
+	assert.Less(t, len(data), len(out)/2)
+	decoded, err := decodeSession(data)
+	assert.NoError(t, err)
+	assert.Equal(t, session, decoded)
+	decoded, err = decodeSession(encodeSession(&model.Session{}))
+	assert.NoError(t, err)
+	assert.Equal(t, &model.Session{}, decoded)
+	decoded, err = decodeSession(out)
+	assert.NoError(t, err)
+	assert.Equal(t, session.ExitPath, decoded.ExitPath)
+	_, err = decodeSession(data[:len(data)-2])
+	assert.ErrorIs(t, err, errSessionEncoding)
+	_, err = decodeSession([]byte{99, 0})
+	assert.ErrorIs(t, err, errSessionEncoding)
+}
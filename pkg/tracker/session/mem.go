@@ -50,7 +50,22 @@ func (cache *MemCache) Get(clientID, fingerprint uint64, maxAge time.Time) *mode
 
 // Put implements the Cache interface.
 func (cache *MemCache) Put(clientID, fingerprint uint64, session *model.Session) {
-	key := getSessionKey(clientID, fingerprint)
+	cache.putIfNewer(getSessionKey(clientID, fingerprint), session)
+}
+
+// Clear implements the Cache interface.
+func (cache *MemCache) Clear() {
+	cache.m.Lock()
+	defer cache.m.Unlock()
+	cache.sessions = make(map[string]model.Session)
+}
+
+// NewMutex implements the Cache interface.
+func (cache *MemCache) NewMutex(uint64, uint64) sync.Locker {
+	return new(sync.Mutex)
+}
+
+func (cache *MemCache) putIfNewer(key string, session *model.Session) bool {
 	cache.m.Lock()
 	defer cache.m.Unlock()
 
@@ -62,19 +77,10 @@ func (cache *MemCache) Put(clientID, fingerprint uint64, session *model.Session)
 
 	if !found || existing.Time.Equal(session.Time) || existing.Time.Before(session.Time) {
 		cache.sessions[key] = *session
+		return true
 	}
-}
 
-// Clear implements the Cache interface.
-func (cache *MemCache) Clear() {
-	cache.m.Lock()
-	defer cache.m.Unlock()
-	cache.sessions = make(map[string]model.Session)
-}
-
-// NewMutex implements the Cache interface.
-func (cache *MemCache) NewMutex(uint64, uint64) sync.Locker {
-	return new(sync.Mutex)
+	return false
 }
 
 // Sessions returns all sessions.
@@ -2,96 +2,322 @@ package session
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"github.com/go-redis/redis/v8"
 	"github.com/go-redsync/redsync/v4"
 	"github.com/go-redsync/redsync/v4/redis/goredis/v8"
 	"github.com/pirsch-analytics/pirsch/v6/pkg/model"
 	"log/slog"
 	"os"
+	"strings"
 	"sync"
 	"time"
 )
 
+const (
+	defaultRedisPrefix      = "pirsch_session_"
+	defaultRedisLockTries   = 8
+	defaultRedisLockExpiry  = time.Second * 8
+	defaultRedisLockTimeout = time.Second * 2
+	redisScanCount          = 1000
+)
+
+// RedisCacheConfig is the configuration for the RedisCache.
+type RedisCacheConfig struct {
+	// MaxAge is the maximum age of a session in Redis.
+	MaxAge time.Duration
+
+	// Prefix is prepended to all keys stored by the cache.
+	// Defaults to "pirsch_session_".
+	Prefix string
+
+	// LockTries is the number of attempts to acquire a lock before checking whether Redis is available.
+	// Defaults to 8.
+	LockTries int
+
+	// LockRetryDelay is the delay between two attempts to acquire a lock.
+	// Defaults to a random delay between 50 and 250 milliseconds.
+	LockRetryDelay time.Duration
+
+	// LockExpiry is the time after which a lock is released in case it's not unlocked.
+	// Defaults to 8 seconds.
+	LockExpiry time.Duration
+
+	// LockTimeout is the maximum time spent trying to acquire a lock before checking whether Redis is available.
+	// In case Redis cannot be reached, the lock falls back to a local mutex, so that requests don't pile up.
+	// Otherwise, the lock is held by someone else and acquiring it is tried again.
+	// Defaults to 2 seconds.
+	LockTimeout time.Duration
+
+	// RedisOptions are the options used to connect to Redis.
+	RedisOptions *redis.Options
+
+	// Logger is the slog.Logger used for logging.
+	// Defaults to a text logger printing to os.Stdout.
+	Logger *slog.Logger
+}
+
+func (config *RedisCacheConfig) validate() {
+	if config.LockTries <= 0 {
+		config.LockTries = defaultRedisLockTries
+	}
+
+	if config.LockExpiry <= 0 {
+		config.LockExpiry = defaultRedisLockExpiry
+	}
+
+	if config.LockTimeout <= 0 {
+		config.LockTimeout = defaultRedisLockTimeout
+	}
+
+	if config.RedisOptions == nil {
+		config.RedisOptions = new(redis.Options)
+	}
+
+	if config.Logger == nil {
+		config.Logger = slog.New(slog.NewTextHandler(os.Stdout, nil))
+	}
+}
+
 // RedisCache caches sessions in Redis.
 type RedisCache struct {
-	maxAge time.Duration
+	config RedisCacheConfig
 	rds    *redis.Client
 	rs     *redsync.Redsync
-	logger *slog.Logger
+	locks  map[string]*redisLocalLock
+	m      sync.Mutex
+}
+
+type redisLocalLock struct {
+	m    sync.Mutex
+	refs int
 }
 
 // RedisMutex wraps a redis mutex.
+// In case Redis cannot be reached, it falls back to a mutex local to the RedisCache.
+// Otherwise, it waits until the lock has been released by its holder or expired.
 type RedisMutex struct {
-	m *redsync.Mutex
+	cache  *RedisCache
+	key    string
+	m      *redsync.Mutex
+	local  *redisLocalLock
+	locked bool
 }
 
+// Lock implements the sync.Locker interface.
 func (m *RedisMutex) Lock() {
-	if err := m.m.Lock(); err != nil {
-		panic(err)
+	m.local = m.cache.lockLocal(m.key)
+
+	if err := m.cache.lockRedsyncMutex(m.m, nil); err != nil {
+		m.cache.config.Logger.Error("error acquiring session lock, falling back to local lock", "err", err, "key", m.key)
+		m.locked = false
+		return
 	}
+
+	m.locked = true
 }
 
+// Unlock implements the sync.Locker interface.
 func (m *RedisMutex) Unlock() {
-	if _, err := m.m.Unlock(); err != nil {
-		panic(err)
+	if m.locked {
+		if _, err := m.m.Unlock(); err != nil {
+			m.cache.config.Logger.Error("error releasing session lock", "err", err, "key", m.key)
+		}
+
+		m.locked = false
 	}
+
+	m.cache.unlockLocal(m.key, m.local)
 }
 
 // NewRedisCache creates a new cache for given maximum age and redis connection.
+// Keys are stored without a prefix to stay compatible with existing sessions, so Clear deletes all keys of the database.
+//
+// Deprecated: use NewRedisCacheWithConfig instead.
 func NewRedisCache(maxAge time.Duration, log *slog.Logger, redisOptions *redis.Options) *RedisCache {
-	if log == nil {
-		log = slog.New(slog.NewTextHandler(os.Stdout, nil))
+	return newRedisCache(RedisCacheConfig{
+		MaxAge:       maxAge,
+		RedisOptions: redisOptions,
+		Logger:       log,
+	})
+}
+
+// NewRedisCacheWithConfig creates a new cache for given configuration.
+// The Prefix defaults to "pirsch_session_", so sessions stored without a prefix by NewRedisCache are not found after switching.
+func NewRedisCacheWithConfig(config RedisCacheConfig) *RedisCache {
+	if config.Prefix == "" {
+		config.Prefix = defaultRedisPrefix
 	}
 
-	client := redis.NewClient(redisOptions)
+	return newRedisCache(config)
+}
+
+func newRedisCache(config RedisCacheConfig) *RedisCache {
+	config.validate()
+	client := redis.NewClient(config.RedisOptions)
 	return &RedisCache{
-		maxAge: maxAge,
+		config: config,
 		rds:    client,
 		rs:     redsync.New(goredis.NewPool(client)),
-		logger: log,
+		locks:  make(map[string]*redisLocalLock),
 	}
 }
 
 // Get implements the Cache interface.
 func (cache *RedisCache) Get(clientID, fingerprint uint64, _ time.Time) *model.Session {
-	r, err := cache.rds.Get(context.Background(), getSessionKey(clientID, fingerprint)).Result()
+	r, err := cache.rds.Get(context.Background(), cache.key(clientID, fingerprint)).Bytes()
 
 	if err != nil {
 		if err != redis.Nil {
-			cache.logger.Error("error reading session from cache", "err", err)
+			cache.config.Logger.Error("error reading session from cache", "err", err)
 		}
 
 		return nil
 	}
 
-	var session model.Session
+	session, err := decodeSession(r)
 
-	if err := json.Unmarshal([]byte(r), &session); err != nil {
-		cache.logger.Error("error unmarshalling session from cache", "err", err)
+	if err != nil {
+		cache.config.Logger.Error("error decoding session from cache", "err", err)
 		return nil
 	}
 
-	return &session
+	return session
 }
 
 // Put implements the Cache interface.
 func (cache *RedisCache) Put(clientID, fingerprint uint64, session *model.Session) {
-	v, err := json.Marshal(session)
+	key := cache.key(clientID, fingerprint)
 
-	if err == nil {
-		cache.rds.SetEX(context.Background(), getSessionKey(clientID, fingerprint), v, cache.maxAge)
-	} else {
-		cache.logger.Error("error storing session in cache", "err", err)
+	if err := cache.rds.SetEX(context.Background(), key, encodeSession(session), cache.config.MaxAge).Err(); err != nil {
+		cache.config.Logger.Error("error storing session in cache", "err", err)
 	}
 }
 
 // Clear implements the Cache interface.
+// Only keys starting with the configured prefix are deleted.
 func (cache *RedisCache) Clear() {
-	cache.rds.FlushDB(context.Background())
+	ctx := context.Background()
+	match := escapeRedisPattern(cache.config.Prefix) + "*"
+	var cursor uint64
+
+	for {
+		keys, next, err := cache.rds.Scan(ctx, cursor, match, redisScanCount).Result()
+
+		if err != nil {
+			cache.config.Logger.Error("error scanning session keys", "err", err)
+			return
+		}
+
+		if len(keys) > 0 {
+			if err := cache.rds.Del(ctx, keys...).Err(); err != nil {
+				cache.config.Logger.Error("error deleting session keys", "err", err)
+				return
+			}
+		}
+
+		if next == 0 {
+			return
+		}
+
+		cursor = next
+	}
 }
 
 // NewMutex implements the Cache interface.
 func (cache *RedisCache) NewMutex(clientID, fingerprint uint64) sync.Locker {
-	return &RedisMutex{cache.rs.NewMutex(getSessionKey(clientID, fingerprint) + "_lock")}
+	key := cache.key(clientID, fingerprint) + "_lock"
+	return &RedisMutex{
+		cache: cache,
+		key:   key,
+		m:     cache.newRedsyncMutex(key, cache.config.LockExpiry),
+	}
+}
+
+func (cache *RedisCache) newRedsyncMutex(key string, expiry time.Duration) *redsync.Mutex {
+	options := []redsync.Option{
+		redsync.WithTries(cache.config.LockTries),
+		redsync.WithExpiry(expiry),
+	}
+
+	if cache.config.LockRetryDelay > 0 {
+		options = append(options, redsync.WithRetryDelay(cache.config.LockRetryDelay))
+	}
+
+	return cache.rs.NewMutex(key, options...)
+}
+
+// lockRedsyncMutex acquires the distributed lock.
+// It only gives up if the lock cannot be acquired within the LockTimeout and Redis cannot be reached.
+// Otherwise, the lock is held by someone else and acquiring it is tried again.
+// The optional request function is called before every attempt.
+func (cache *RedisCache) lockRedsyncMutex(lock *redsync.Mutex, request func()) error {
+	for {
+		if request != nil {
+			request()
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), cache.config.LockTimeout)
+		err := lock.LockContext(ctx)
+		cancel()
+
+		if err == nil {
+			return nil
+		}
+
+		if pingErr := cache.ping(); pingErr != nil {
+			return errors.Join(err, pingErr)
+		}
+	}
+}
+
+func (cache *RedisCache) ping() error {
+	ctx, cancel := context.WithTimeout(context.Background(), cache.config.LockTimeout)
+	defer cancel()
+	return cache.rds.Ping(ctx).Err()
+}
+
+func (cache *RedisCache) key(clientID, fingerprint uint64) string {
+	return cache.config.Prefix + getSessionKey(clientID, fingerprint)
+}
+
+func (cache *RedisCache) lockLocal(key string) *redisLocalLock {
+	cache.m.Lock()
+	lock, found := cache.locks[key]
+
+	if !found {
+		lock = new(redisLocalLock)
+		cache.locks[key] = lock
+	}
+
+	lock.refs++
+	cache.m.Unlock()
+	lock.m.Lock()
+	return lock
+}
+
+func (cache *RedisCache) unlockLocal(key string, lock *redisLocalLock) {
+	lock.m.Unlock()
+	cache.m.Lock()
+	defer cache.m.Unlock()
+	lock.refs--
+
+	if lock.refs == 0 {
+		delete(cache.locks, key)
+	}
+}
+
+func escapeRedisPattern(pattern string) string {
+	var sb strings.Builder
+
+	for _, c := range pattern {
+		switch c {
+		case '*', '?', '[', ']', '\\':
+			sb.WriteRune('\\')
+		}
+
+		sb.WriteRune(c)
+	}
+
+	return sb.String()
 }
@@ -0,0 +1,404 @@
+package session
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testRedis is a minimal stand-in for a Redis server.
+// It speaks RESP and implements the subset of commands used by the session caches and redsync.
+type testRedis struct {
+	listener    net.Listener
+	values      map[string]string
+	expires     map[string]time.Time
+	subscribers map[string][]*testRedisConn
+	commands    map[string]int
+	m           sync.Mutex
+}
+
+type testRedisConn struct {
+	conn net.Conn
+	w    *bufio.Writer
+	m    sync.Mutex
+}
+
+func newTestRedis(t *testing.T) *testRedis {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := &testRedis{
+		listener:    listener,
+		values:      make(map[string]string),
+		expires:     make(map[string]time.Time),
+		subscribers: make(map[string][]*testRedisConn),
+		commands:    make(map[string]int),
+	}
+	go server.serve()
+	t.Cleanup(func() {
+		server.listener.Close()
+	})
+	return server
+}
+
+func (server *testRedis) Addr() string {
+	return server.listener.Addr().String()
+}
+
+func (server *testRedis) Count(cmd string) int {
+	server.m.Lock()
+	defer server.m.Unlock()
+	return server.commands[strings.ToUpper(cmd)]
+}
+
+func (server *testRedis) Keys() []string {
+	server.m.Lock()
+	defer server.m.Unlock()
+	keys := make([]string, 0, len(server.values))
+
+	for k := range server.values {
+		if server.alive(k) {
+			keys = append(keys, k)
+		}
+	}
+
+	return keys
+}
+
+func (server *testRedis) serve() {
+	for {
+		conn, err := server.listener.Accept()
+
+		if err != nil {
+			return
+		}
+
+		go server.handle(&testRedisConn{conn: conn, w: bufio.NewWriter(conn)})
+	}
+}
+
+func (server *testRedis) handle(c *testRedisConn) {
+	defer server.unsubscribe(c)
+	defer c.conn.Close()
+	r := bufio.NewReader(c.conn)
+
+	for {
+		args, err := readTestRedisCommand(r)
+
+		if err != nil {
+			return
+		}
+
+		if len(args) == 0 {
+			continue
+		}
+
+		reply := server.exec(c, args)
+		c.m.Lock()
+		writeTestRedisReply(c.w, reply)
+		err = c.w.Flush()
+		c.m.Unlock()
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (server *testRedis) exec(c *testRedisConn, args []string) any {
+	server.m.Lock()
+	defer server.m.Unlock()
+	cmd := strings.ToUpper(args[0])
+	server.commands[cmd]++
+
+	switch cmd {
+	case "PING":
+		if server.isSubscriber(c) {
+			return []any{"pong", ""}
+		}
+
+		return "PONG"
+	case "GET":
+		if !server.alive(args[1]) {
+			return nil
+		}
+
+		return []byte(server.values[args[1]])
+	case "SET":
+		return server.set(args[1:])
+	case "SETEX":
+		seconds, _ := strconv.Atoi(args[2])
+		server.values[args[1]] = args[3]
+		server.expires[args[1]] = time.Now().Add(time.Duration(seconds) * time.Second)
+		return "OK"
+	case "DEL":
+		n := int64(0)
+
+		for _, k := range args[1:] {
+			if server.alive(k) {
+				server.del(k)
+				n++
+			}
+		}
+
+		return n
+	case "PTTL":
+		if !server.alive(args[1]) {
+			return int64(-2)
+		}
+
+		if exp, ok := server.expires[args[1]]; ok {
+			return time.Until(exp).Milliseconds()
+		}
+
+		return int64(-1)
+	case "FLUSHDB", "FLUSHALL":
+		server.values = make(map[string]string)
+		server.expires = make(map[string]time.Time)
+		return "OK"
+	case "SCAN":
+		return server.scan(args[1:])
+	case "EVALSHA":
+		return errors.New("NOSCRIPT No matching script")
+	case "EVAL":
+		return server.eval(args[1:])
+	case "PUBLISH":
+		return server.publish(args[1], args[2])
+	case "SUBSCRIBE":
+		replies := make([]any, 0, len(args)-1)
+
+		for _, channel := range args[1:] {
+			server.subscribers[channel] = append(server.subscribers[channel], c)
+			replies = append(replies, []any{"subscribe", channel, int64(len(server.channels(c)))})
+		}
+
+		return testRedisMulti(replies)
+	default:
+		return fmt.Errorf("ERR unknown command '%s'", args[0])
+	}
+}
+
+func (server *testRedis) set(args []string) any {
+	key, value := args[0], args[1]
+	nx := false
+	var ttl time.Duration
+
+	for i := 2; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "NX":
+			nx = true
+		case "PX":
+			ms, _ := strconv.Atoi(args[i+1])
+			ttl = time.Duration(ms) * time.Millisecond
+			i++
+		case "EX":
+			s, _ := strconv.Atoi(args[i+1])
+			ttl = time.Duration(s) * time.Second
+			i++
+		}
+	}
+
+	if nx && server.alive(key) {
+		return nil
+	}
+
+	server.values[key] = value
+	delete(server.expires, key)
+
+	if ttl > 0 {
+		server.expires[key] = time.Now().Add(ttl)
+	}
+
+	return "OK"
+}
+
+func (server *testRedis) scan(args []string) any {
+	pattern := "*"
+
+	for i := 1; i < len(args)-1; i++ {
+		if strings.ToUpper(args[i]) == "MATCH" {
+			pattern = args[i+1]
+		}
+	}
+
+	keys := make([]any, 0)
+
+	for k := range server.values {
+		if ok, _ := path.Match(pattern, k); ok && server.alive(k) {
+			keys = append(keys, []byte(k))
+		}
+	}
+
+	return []any{[]byte("0"), keys}
+}
+
+// eval emulates the two Lua scripts used by redsync to release and extend locks.
+func (server *testRedis) eval(args []string) any {
+	script := args[0]
+	key, value := args[2], args[3]
+
+	if !server.alive(key) || server.values[key] != value {
+		return int64(0)
+	}
+
+	if strings.Contains(script, "PEXPIRE") {
+		ms, _ := strconv.Atoi(args[4])
+		server.expires[key] = time.Now().Add(time.Duration(ms) * time.Millisecond)
+		return int64(1)
+	}
+
+	server.del(key)
+	return int64(1)
+}
+
+func (server *testRedis) publish(channel, msg string) any {
+	subscribers := server.subscribers[channel]
+
+	for _, sub := range subscribers {
+		sub.m.Lock()
+		writeTestRedisReply(sub.w, []any{"message", channel, msg})
+		sub.w.Flush()
+		sub.m.Unlock()
+	}
+
+	return int64(len(subscribers))
+}
+
+func (server *testRedis) alive(key string) bool {
+	if _, ok := server.values[key]; !ok {
+		return false
+	}
+
+	if exp, ok := server.expires[key]; ok && !time.Now().Before(exp) {
+		server.del(key)
+		return false
+	}
+
+	return true
+}
+
+func (server *testRedis) del(key string) {
+	delete(server.values, key)
+	delete(server.expires, key)
+}
+
+func (server *testRedis) isSubscriber(c *testRedisConn) bool {
+	return len(server.channels(c)) > 0
+}
+
+func (server *testRedis) channels(c *testRedisConn) []string {
+	var channels []string
+
+	for channel, subs := range server.subscribers {
+		for _, sub := range subs {
+			if sub == c {
+				channels = append(channels, channel)
+			}
+		}
+	}
+
+	return channels
+}
+
+func (server *testRedis) unsubscribe(c *testRedisConn) {
+	server.m.Lock()
+	defer server.m.Unlock()
+
+	for channel, subs := range server.subscribers {
+		for i, sub := range subs {
+			if sub == c {
+				server.subscribers[channel] = append(subs[:i], subs[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// testRedisMulti is a list of replies written one after another.
+type testRedisMulti []any
+
+func readTestRedisCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+
+	if err != nil {
+		return nil, err
+	}
+
+	line = strings.TrimRight(line, "\r\n")
+
+	if !strings.HasPrefix(line, "*") {
+		return strings.Fields(line), nil
+	}
+
+	n, err := strconv.Atoi(line[1:])
+
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, 0, n)
+
+	for i := 0; i < n; i++ {
+		header, err := r.ReadString('\n')
+
+		if err != nil {
+			return nil, err
+		}
+
+		size, err := strconv.Atoi(strings.TrimRight(header, "\r\n")[1:])
+
+		if err != nil {
+			return nil, err
+		}
+
+		buf := make([]byte, size+2)
+
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+
+		args = append(args, string(buf[:size]))
+	}
+
+	return args, nil
+}
+
+func writeTestRedisReply(w *bufio.Writer, reply any) {
+	switch v := reply.(type) {
+	case nil:
+		w.WriteString("$-1\r\n")
+	case string:
+		w.WriteString("+" + v + "\r\n")
+	case []byte:
+		fmt.Fprintf(w, "$%d\r\n%s\r\n", len(v), v)
+	case int64:
+		fmt.Fprintf(w, ":%d\r\n", v)
+	case error:
+		w.WriteString("-" + v.Error() + "\r\n")
+	case testRedisMulti:
+		for _, r := range v {
+			writeTestRedisReply(w, r)
+		}
+	case []any:
+		fmt.Fprintf(w, "*%d\r\n", len(v))
+
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				e = []byte(s)
+			}
+
+			writeTestRedisReply(w, e)
+		}
+	}
+}
@@ -1,16 +1,20 @@
 package session
 
 import (
+	"context"
 	"github.com/go-redis/redis/v8"
 	"github.com/pirsch-analytics/pirsch/v6/pkg/model"
 	"github.com/stretchr/testify/assert"
+	"sync"
 	"testing"
 	"time"
 )
 
 func TestRedisCache(t *testing.T) {
-	cache := NewRedisCache(time.Second, nil, &redis.Options{
-		Addr: "localhost:6379",
+	server := newTestRedis(t)
+	cache := NewRedisCacheWithConfig(RedisCacheConfig{
+		MaxAge:       time.Second,
+		RedisOptions: &redis.Options{Addr: server.Addr()},
 	})
 	cache.Clear()
 	session := cache.Get(1, 1, time.Time{})
@@ -27,3 +31,127 @@ func TestRedisCache(t *testing.T) {
 	session = cache.Get(1, 1, time.Time{})
 	assert.Nil(t, session)
 }
+
+func TestNewRedisCache(t *testing.T) {
+	server := newTestRedis(t)
+	cache := NewRedisCache(time.Minute, nil, &redis.Options{Addr: server.Addr()})
+	cache.Put(1, 1, &model.Session{ExitPath: "/test"})
+	assert.Equal(t, []string{"1_1"}, server.Keys())
+	session := cache.Get(1, 1, time.Time{})
+	assert.NotNil(t, session)
+	assert.Equal(t, "/test", session.ExitPath)
+}
+
+func TestRedisCache_Clear(t *testing.T) {
+	server := newTestRedis(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	assert.NoError(t, client.Set(context.Background(), "foreign", "value", 0).Err())
+	cache := NewRedisCacheWithConfig(RedisCacheConfig{
+		MaxAge:       time.Minute,
+		Prefix:       "test_",
+		RedisOptions: &redis.Options{Addr: server.Addr()},
+	})
+	cache.Put(1, 1, &model.Session{ExitPath: "/test"})
+	cache.Put(1, 2, &model.Session{ExitPath: "/test"})
+	assert.ElementsMatch(t, []string{"foreign", "test_1_1", "test_1_2"}, server.Keys())
+	cache.Clear()
+	assert.Equal(t, []string{"foreign"}, server.Keys())
+}
+
+func TestRedisCache_NewMutex(t *testing.T) {
+	server := newTestRedis(t)
+	cache := NewRedisCacheWithConfig(RedisCacheConfig{
+		LockTries:      2,
+		LockRetryDelay: time.Millisecond,
+		RedisOptions:   &redis.Options{Addr: server.Addr()},
+	})
+	m := cache.NewMutex(1, 1)
+	m.Lock()
+	assert.Equal(t, []string{"pirsch_session_1_1_lock"}, server.Keys())
+	m.Unlock()
+	assert.Empty(t, server.Keys())
+	assert.Empty(t, cache.locks)
+}
+
+func TestRedisCache_NewMutexOutage(t *testing.T) {
+	server := newTestRedis(t)
+	cache := NewRedisCacheWithConfig(RedisCacheConfig{
+		LockTries:      2,
+		LockRetryDelay: time.Millisecond,
+		RedisOptions:   &redis.Options{Addr: server.Addr()},
+	})
+	server.listener.Close()
+	var wg sync.WaitGroup
+	counter := 0
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			m := cache.NewMutex(1, 1)
+			m.Lock()
+			counter++
+			m.Unlock()
+			wg.Done()
+		}()
+	}
+
+	wg.Wait()
+	assert.Equal(t, 10, counter)
+	assert.Empty(t, cache.locks)
+}
+
+func TestRedisCache_NewMutexContention(t *testing.T) {
+	server := newTestRedis(t)
+	config := RedisCacheConfig{
+		LockTries:      5,
+		LockRetryDelay: time.Millisecond * 5,
+		LockTimeout:    time.Millisecond * 50,
+		RedisOptions:   &redis.Options{Addr: server.Addr()},
+	}
+	a := NewRedisCacheWithConfig(config)
+	b := NewRedisCacheWithConfig(config)
+	m := a.NewMutex(1, 1)
+	m.Lock()
+	locked := make(chan struct{})
+
+	go func() {
+		m := b.NewMutex(1, 1)
+		m.Lock()
+		close(locked)
+		m.Unlock()
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("lock acquired while being held by another cache")
+	case <-time.After(time.Millisecond * 300):
+	}
+
+	m.Unlock()
+
+	select {
+	case <-locked:
+	case <-time.After(time.Second * 5):
+		t.Fatal("lock not acquired after being released")
+	}
+
+	var wg sync.WaitGroup
+	counter := 0
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(cache *RedisCache) {
+			for j := 0; j < 5; j++ {
+				m := cache.NewMutex(1, 1)
+				m.Lock()
+				counter++
+				m.Unlock()
+			}
+
+			wg.Done()
+		}([]*RedisCache{a, b}[i%2])
+	}
+
+	wg.Wait()
+	assert.Equal(t, 50, counter)
+}
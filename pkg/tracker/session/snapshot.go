@@ -0,0 +1,191 @@
+package session
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	snapshotVersion      = 1
+	maxSnapshotFieldSize = 1 << 16
+)
+
+var (
+	snapshotMagic = []byte("PSCS")
+
+	// ErrInvalidSnapshot is returned when restoring a snapshot that has not been written by Snapshot.
+	ErrInvalidSnapshot = errors.New("invalid session snapshot")
+)
+
+// Snapshotter is implemented by caches that can persist their sessions, so that they survive restarts.
+type Snapshotter interface {
+	// Snapshot writes all sessions to given writer.
+	Snapshot(io.Writer) error
+
+	// Restore reads sessions from given reader, discarding all sessions older than maxAge.
+	// It returns the number of restored sessions.
+	// Sessions that cannot be decoded are skipped and reported by a SkippedSessionsError after all other sessions have been restored.
+	Restore(r io.Reader, maxAge time.Time) (int, error)
+}
+
+// SkippedSessionsError is returned when restoring a snapshot containing sessions that cannot be decoded.
+type SkippedSessionsError struct {
+	Skipped int
+}
+
+// Error implements the error interface.
+func (err *SkippedSessionsError) Error() string {
+	return fmt.Sprintf("skipped %d invalid sessions in snapshot", err.Skipped)
+}
+
+// SaveSnapshot writes a snapshot of given cache to a file.
+// The snapshot is written to a temporary file first and then moved to the path, so that the file is never incomplete.
+func SaveSnapshot(cache Snapshotter, path string) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+
+	if err != nil {
+		return err
+	}
+
+	tmp := f.Name()
+	defer os.Remove(tmp)
+
+	if err := cache.Snapshot(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// LoadSnapshot restores given cache from a snapshot file, discarding all sessions older than maxAge.
+// A missing file is not an error.
+func LoadSnapshot(cache Snapshotter, path string, maxAge time.Time) (int, error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	defer f.Close()
+	return cache.Restore(f, maxAge)
+}
+
+// Snapshot implements the Snapshotter interface.
+func (cache *MemCache) Snapshot(w io.Writer) error {
+	cache.m.RLock()
+	sessions := make(map[string][]byte, len(cache.sessions))
+
+	for key, session := range cache.sessions {
+		sessions[key] = encodeSession(&session)
+	}
+
+	cache.m.RUnlock()
+	bw := bufio.NewWriter(w)
+	header := append(append([]byte{}, snapshotMagic...), snapshotVersion)
+	header = binary.AppendUvarint(header, uint64(len(sessions)))
+
+	if _, err := bw.Write(header); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 0, binary.MaxVarintLen64)
+
+	for key, session := range sessions {
+		for _, field := range [][]byte{[]byte(key), session} {
+			if _, err := bw.Write(binary.AppendUvarint(buf[:0], uint64(len(field)))); err != nil {
+				return err
+			}
+
+			if _, err := bw.Write(field); err != nil {
+				return err
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+// Restore implements the Snapshotter interface.
+// Restored sessions do not overwrite newer sessions already present in the cache.
+func (cache *MemCache) Restore(r io.Reader, maxAge time.Time) (int, error) {
+	br := bufio.NewReader(r)
+	header := make([]byte, len(snapshotMagic)+1)
+
+	if _, err := io.ReadFull(br, header); err != nil {
+		return 0, ErrInvalidSnapshot
+	}
+
+	if string(header[:len(snapshotMagic)]) != string(snapshotMagic) || header[len(snapshotMagic)] != snapshotVersion {
+		return 0, ErrInvalidSnapshot
+	}
+
+	n, err := binary.ReadUvarint(br)
+
+	if err != nil {
+		return 0, ErrInvalidSnapshot
+	}
+
+	restored, skipped := 0, 0
+
+	for i := uint64(0); i < n; i++ {
+		key, err := readSnapshotField(br)
+
+		if err != nil {
+			return restored, err
+		}
+
+		data, err := readSnapshotField(br)
+
+		if err != nil {
+			return restored, err
+		}
+
+		session, err := decodeSession(data)
+
+		if err != nil {
+			skipped++
+			continue
+		}
+
+		if session.Time.After(maxAge) && cache.putIfNewer(string(key), session) {
+			restored++
+		}
+	}
+
+	if skipped > 0 {
+		return restored, &SkippedSessionsError{skipped}
+	}
+
+	return restored, nil
+}
+
+func readSnapshotField(r *bufio.Reader) ([]byte, error) {
+	n, err := binary.ReadUvarint(r)
+
+	if err != nil || n > maxSnapshotFieldSize {
+		return nil, ErrInvalidSnapshot
+	}
+
+	data := make([]byte, n)
+
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, ErrInvalidSnapshot
+	}
+
+	return data, nil
+}
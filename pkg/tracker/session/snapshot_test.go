@@ -0,0 +1,79 @@
+package session
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/db"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMemCache_Snapshot(t *testing.T) {
+	cache := NewMemCache(db.NewClientMock(), 10)
+	now := time.Now().UTC()
+	cache.Put(1, 1, &model.Session{Time: now, ExitPath: "/new"})
+	cache.Put(1, 2, &model.Session{Time: now.Add(-time.Hour), ExitPath: "/old"})
+	cache.Put(2, 1, &model.Session{Time: now.Add(-time.Minute), ExitPath: "/other"})
+	var buf bytes.Buffer
+	assert.NoError(t, cache.Snapshot(&buf))
+	restored := NewMemCache(db.NewClientMock(), 10)
+	restored.Put(2, 1, &model.Session{Time: now, ExitPath: "/newer"})
+	n, err := restored.Restore(bytes.NewReader(buf.Bytes()), now.Add(-time.Minute*30))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Len(t, restored.Sessions(), 2)
+	assert.Equal(t, "/new", restored.Get(1, 1, now.Add(-time.Minute)).ExitPath)
+	assert.Nil(t, restored.Get(1, 2, now.Add(-time.Minute*30)))
+	assert.Equal(t, "/newer", restored.Get(2, 1, now.Add(-time.Minute)).ExitPath)
+	_, err = restored.Restore(bytes.NewReader([]byte("invalid")), now)
+	assert.ErrorIs(t, err, ErrInvalidSnapshot)
+	_, err = restored.Restore(bytes.NewReader(buf.Bytes()[:buf.Len()-3]), now)
+	assert.ErrorIs(t, err, ErrInvalidSnapshot)
+}
+
+func TestMemCache_RestoreSkipInvalid(t *testing.T) {
+	now := time.Now().UTC()
+	data := append(append([]byte{}, snapshotMagic...), snapshotVersion)
+	data = binary.AppendUvarint(data, 3)
+
+	for _, field := range [][]byte{
+		[]byte("1_1"), encodeSession(&model.Session{Time: now, ExitPath: "/a"}),
+		[]byte("1_2"), []byte("invalid"),
+		[]byte("1_3"), encodeSession(&model.Session{Time: now, ExitPath: "/b"}),
+	} {
+		data = binary.AppendUvarint(data, uint64(len(field)))
+		data = append(data, field...)
+	}
+
+	cache := NewMemCache(db.NewClientMock(), 10)
+	n, err := cache.Restore(bytes.NewReader(data), now.Add(-time.Minute))
+	var skipped *SkippedSessionsError
+	assert.ErrorAs(t, err, &skipped)
+	assert.Equal(t, 1, skipped.Skipped)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, "/a", cache.Get(1, 1, now.Add(-time.Minute)).ExitPath)
+	assert.Equal(t, "/b", cache.Get(1, 3, now.Add(-time.Minute)).ExitPath)
+}
+
+func TestSaveSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.snapshot")
+	cache := NewMemCache(db.NewClientMock(), 10)
+	n, err := LoadSnapshot(cache, path, time.Time{})
+	assert.NoError(t, err)
+	assert.Zero(t, n)
+	cache.Put(1, 1, &model.Session{Time: time.Now(), ExitPath: "/"})
+	assert.NoError(t, SaveSnapshot(cache, path))
+	assert.NoError(t, SaveSnapshot(cache, path))
+	files, err := os.ReadDir(filepath.Dir(path))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	restored := NewMemCache(db.NewClientMock(), 10)
+	n, err = LoadSnapshot(restored, path, time.Now().Add(-time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, "/", restored.Get(1, 1, time.Now().Add(-time.Minute)).ExitPath)
+}
@@ -0,0 +1,375 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"github.com/go-redsync/redsync/v4"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/model"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/util"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultTieredTTL     = time.Second * 5
+	defaultTieredChannel = "pirsch_session_invalidate"
+	tieredClearAll       = "*"
+	tieredRelease        = "!"
+)
+
+// TieredCacheConfig is the configuration for the TieredCache.
+type TieredCacheConfig struct {
+	// RedisCacheConfig configures the underlying RedisCache (L2).
+	RedisCacheConfig
+
+	// TTL is the time a session is kept in memory and a node keeps the ownership of a fingerprint,
+	// unless another node requests it.
+	// Defaults to 5 seconds.
+	TTL time.Duration
+
+	// MaxSessions is the maximum number of sessions kept in memory.
+	// Defaults to 10_000.
+	MaxSessions int
+
+	// Channel is the Redis pub/sub channel used to invalidate sessions on other nodes.
+	// Defaults to "pirsch_session_invalidate".
+	Channel string
+}
+
+func (config *TieredCacheConfig) validate() {
+	if config.TTL <= 0 {
+		config.TTL = defaultTieredTTL
+	}
+
+	if config.MaxSessions <= 0 {
+		config.MaxSessions = defaultMaxSessions
+	}
+
+	if config.Channel == "" {
+		config.Channel = defaultTieredChannel
+	}
+
+	config.RedisCacheConfig.validate()
+}
+
+// TieredCache caches sessions in memory (L1) in front of Redis (L2).
+// Sessions are written to both levels and other nodes are notified through Redis pub/sub to drop their local copy.
+// A node acquiring the distributed lock for a fingerprint keeps it for the TTL,
+// so that subsequent requests for the same fingerprint on this node only need a local lock.
+// Other nodes request the release of the distributed lock before acquiring it,
+// so that it is handed over as soon as it is no longer used locally.
+type TieredCache struct {
+	config   TieredCacheConfig
+	node     string
+	l2       *RedisCache
+	sessions map[string]tieredEntry
+	owners   map[string]*tieredOwner
+	pubsub   *redis.PubSub
+	cancel   context.CancelFunc
+	m        sync.Mutex
+}
+
+type tieredEntry struct {
+	session model.Session
+	expires time.Time
+}
+
+// tieredOwner is the local mutex for a fingerprint and the distributed lock held by this node, if any.
+// It's kept as long as it's in use, so that there is only a single local mutex per fingerprint.
+type tieredOwner struct {
+	local sync.Mutex
+	lock  *redsync.Mutex
+	until time.Time
+	refs  int
+
+	// release is set when another node requested the distributed lock while it was in use
+	release bool
+}
+
+// TieredMutex locks a fingerprint locally and in Redis, unless the node already owns the fingerprint.
+type TieredMutex struct {
+	cache *TieredCache
+	key   string
+	owner *tieredOwner
+}
+
+// NewTieredCache creates a new two-level cache for given configuration.
+// Call Close to stop listening for invalidations and release all locks held by this node.
+func NewTieredCache(config TieredCacheConfig) *TieredCache {
+	config.validate()
+	ctx, cancel := context.WithCancel(context.Background())
+	cache := &TieredCache{
+		config:   config,
+		node:     util.RandString(16),
+		l2:       NewRedisCacheWithConfig(config.RedisCacheConfig),
+		sessions: make(map[string]tieredEntry),
+		owners:   make(map[string]*tieredOwner),
+		cancel:   cancel,
+	}
+	cache.pubsub = cache.l2.rds.Subscribe(ctx, config.Channel)
+
+	if _, err := cache.pubsub.Receive(ctx); err != nil {
+		cache.config.Logger.Error("error subscribing to session invalidation channel", "err", err)
+	}
+
+	go cache.listen(ctx)
+	return cache
+}
+
+// Get implements the Cache interface.
+func (cache *TieredCache) Get(clientID, fingerprint uint64, maxAge time.Time) *model.Session {
+	key := getSessionKey(clientID, fingerprint)
+	cache.m.Lock()
+	entry, found := cache.sessions[key]
+	cache.m.Unlock()
+
+	if found && time.Now().Before(entry.expires) && entry.session.Time.After(maxAge) {
+		return &entry.session
+	}
+
+	session := cache.l2.Get(clientID, fingerprint, maxAge)
+
+	if session == nil || !session.Time.After(maxAge) {
+		return nil
+	}
+
+	cache.putLocal(key, session)
+	return session
+}
+
+// Put implements the Cache interface.
+func (cache *TieredCache) Put(clientID, fingerprint uint64, session *model.Session) {
+	key := getSessionKey(clientID, fingerprint)
+	cache.putLocal(key, session)
+	cache.l2.Put(clientID, fingerprint, session)
+	cache.publish(key)
+}
+
+// Clear implements the Cache interface.
+func (cache *TieredCache) Clear() {
+	cache.clearLocal()
+	cache.l2.Clear()
+	cache.publish(tieredClearAll)
+}
+
+// NewMutex implements the Cache interface.
+func (cache *TieredCache) NewMutex(clientID, fingerprint uint64) sync.Locker {
+	return &TieredMutex{
+		cache: cache,
+		key:   getSessionKey(clientID, fingerprint),
+	}
+}
+
+// Close stops listening for invalidations and releases all distributed locks owned by this node.
+func (cache *TieredCache) Close() {
+	cache.cancel()
+
+	if err := cache.pubsub.Close(); err != nil {
+		cache.config.Logger.Error("error closing session invalidation channel", "err", err)
+	}
+
+	cache.m.Lock()
+	defer cache.m.Unlock()
+
+	for key, owner := range cache.owners {
+		if owner.refs == 0 {
+			cache.unlockOwner(key, owner)
+		}
+	}
+}
+
+// removeExpiredOwners must be called while holding the cache lock.
+func (cache *TieredCache) removeExpiredOwners() {
+	now := time.Now()
+
+	for key, owner := range cache.owners {
+		if owner.refs == 0 && !now.Before(owner.until) {
+			delete(cache.owners, key)
+		}
+	}
+}
+
+func (cache *TieredCache) putLocal(key string, session *model.Session) {
+	cache.m.Lock()
+	defer cache.m.Unlock()
+
+	if len(cache.sessions) >= cache.config.MaxSessions {
+		cache.sessions = make(map[string]tieredEntry)
+	}
+
+	existing, found := cache.sessions[key]
+
+	if !found || !existing.session.Time.After(session.Time) || time.Now().After(existing.expires) {
+		cache.sessions[key] = tieredEntry{
+			session: *session,
+			expires: time.Now().Add(cache.config.TTL),
+		}
+	}
+}
+
+func (cache *TieredCache) removeLocal(key string) {
+	cache.m.Lock()
+	defer cache.m.Unlock()
+	delete(cache.sessions, key)
+}
+
+func (cache *TieredCache) clearLocal() {
+	cache.m.Lock()
+	defer cache.m.Unlock()
+	cache.sessions = make(map[string]tieredEntry)
+}
+
+func (cache *TieredCache) publish(key string) {
+	msg := fmt.Sprintf("%s %s", cache.node, key)
+
+	if err := cache.l2.rds.Publish(context.Background(), cache.config.Channel, msg).Err(); err != nil {
+		cache.config.Logger.Error("error publishing session invalidation", "err", err)
+	}
+}
+
+func (cache *TieredCache) listen(ctx context.Context) {
+	messages := cache.pubsub.Channel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			node, key, found := strings.Cut(msg.Payload, " ")
+
+			if !found || node == cache.node {
+				continue
+			}
+
+			if key == tieredClearAll {
+				cache.clearLocal()
+			} else if lockKey, ok := strings.CutPrefix(key, tieredRelease); ok {
+				cache.releaseOwner(lockKey)
+			} else {
+				cache.removeLocal(key)
+			}
+		}
+	}
+}
+
+// releaseOwner releases the distributed lock for given key if it is held by this node and not in use.
+// Otherwise, it will be released as soon as the current local lock has been released.
+func (cache *TieredCache) releaseOwner(key string) {
+	cache.m.Lock()
+	defer cache.m.Unlock()
+	owner, found := cache.owners[key]
+
+	if !found {
+		return
+	}
+
+	if owner.refs == 0 {
+		cache.unlockOwner(key, owner)
+	} else {
+		owner.release = true
+	}
+}
+
+// unlockOwner must be called while holding the cache lock and the owner must not be in use.
+func (cache *TieredCache) unlockOwner(key string, owner *tieredOwner) {
+	owner.unlock(cache)
+	delete(cache.owners, key)
+}
+
+// unlock releases the distributed lock.
+// It must be called while holding the local lock, or while holding the cache lock if the owner is not in use.
+func (owner *tieredOwner) unlock(cache *TieredCache) {
+	if owner.lock != nil && time.Now().Before(owner.until) {
+		if _, err := owner.lock.Unlock(); err != nil {
+			cache.config.Logger.Error("error releasing session lock", "err", err)
+		}
+	}
+
+	owner.lock = nil
+}
+
+// Lock implements the sync.Locker interface.
+// It acquires the distributed lock only if this node does not own the fingerprint already.
+func (mutex *TieredMutex) Lock() {
+	cache := mutex.cache
+	cache.m.Lock()
+	owner, found := cache.owners[mutex.key]
+
+	if !found {
+		if len(cache.owners) >= cache.config.MaxSessions {
+			cache.removeExpiredOwners()
+		}
+
+		owner = new(tieredOwner)
+		cache.owners[mutex.key] = owner
+	}
+
+	owner.refs++
+	cache.m.Unlock()
+	owner.local.Lock()
+	mutex.owner = owner
+	mutex.handOver()
+	now := time.Now()
+
+	if owner.lock != nil && now.Add(cache.config.TTL/2).Before(owner.until) {
+		return
+	}
+
+	if owner.lock != nil {
+		if ok, err := owner.lock.Extend(); err == nil && ok {
+			owner.until = owner.lock.Until()
+			return
+		}
+	}
+
+	// the ownership has been lost, so other nodes might have modified the session in the meantime
+	owner.unlock(cache)
+	// the release is requested again on every attempt, in case the lock has been acquired by another node in the meantime
+	cache.removeLocal(mutex.key)
+	lock := cache.l2.newRedsyncMutex(cache.l2.config.Prefix+mutex.key+"_lock", cache.config.TTL)
+
+	if err := cache.l2.lockRedsyncMutex(lock, func() {
+		cache.publish(tieredRelease + mutex.key)
+	}); err != nil {
+		cache.config.Logger.Error("error acquiring session lock, falling back to local lock", "err", err, "key", mutex.key)
+		return
+	}
+
+	owner.lock = lock
+	owner.until = lock.Until()
+}
+
+// Unlock implements the sync.Locker interface.
+// The distributed lock is kept until it expires, so that the next request on this node does not need to acquire it again,
+// unless another node requested it.
+func (mutex *TieredMutex) Unlock() {
+	mutex.handOver()
+	mutex.owner.local.Unlock()
+	mutex.cache.m.Lock()
+	defer mutex.cache.m.Unlock()
+	mutex.owner.refs--
+
+	// the release might have been requested after handing over
+	if mutex.owner.refs == 0 && mutex.owner.release {
+		mutex.cache.unlockOwner(mutex.key, mutex.owner)
+	}
+}
+
+// handOver releases the distributed lock if another node requested it, so that the next local lock acquires it again.
+// It must be called while holding the local lock.
+func (mutex *TieredMutex) handOver() {
+	mutex.cache.m.Lock()
+	release := mutex.owner.release
+	mutex.owner.release = false
+	mutex.cache.m.Unlock()
+
+	if release {
+		mutex.owner.unlock(mutex.cache)
+	}
+}
@@ -0,0 +1,199 @@
+package session
+
+import (
+	"github.com/go-redis/redis/v8"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTieredCache(t *testing.T) {
+	server := newTestRedis(t)
+	cache := NewTieredCache(TieredCacheConfig{
+		RedisCacheConfig: RedisCacheConfig{
+			MaxAge:       time.Second,
+			RedisOptions: &redis.Options{Addr: server.Addr()},
+		},
+	})
+	defer cache.Close()
+	cache.Clear()
+	assert.Nil(t, cache.Get(1, 1, time.Time{}))
+	now := time.Now()
+	cache.Put(1, 1, &model.Session{Time: now, ExitPath: "/test"})
+	session := cache.Get(1, 1, time.Time{})
+	assert.NotNil(t, session)
+	assert.Equal(t, "/test", session.ExitPath)
+	assert.Nil(t, cache.Get(1, 1, now))
+	gets := server.Count("get")
+	cache.Get(1, 1, time.Time{})
+	assert.Equal(t, gets, server.Count("get"))
+	cache.Clear()
+	assert.Nil(t, cache.Get(1, 1, time.Time{}))
+}
+
+func TestTieredCache_Invalidation(t *testing.T) {
+	server := newTestRedis(t)
+	a := NewTieredCache(TieredCacheConfig{
+		RedisCacheConfig: RedisCacheConfig{
+			MaxAge:       time.Minute,
+			RedisOptions: &redis.Options{Addr: server.Addr()},
+		},
+		TTL: time.Minute,
+	})
+	defer a.Close()
+	b := NewTieredCache(TieredCacheConfig{
+		RedisCacheConfig: RedisCacheConfig{
+			MaxAge:       time.Minute,
+			RedisOptions: &redis.Options{Addr: server.Addr()},
+		},
+		TTL: time.Minute,
+	})
+	defer b.Close()
+	now := time.Now()
+	a.Put(1, 1, &model.Session{Time: now, ExitPath: "/a"})
+	session := b.Get(1, 1, time.Time{})
+	assert.NotNil(t, session)
+	assert.Equal(t, "/a", session.ExitPath)
+	a.Put(1, 1, &model.Session{Time: now.Add(time.Second), ExitPath: "/b"})
+	assert.Eventually(t, func() bool {
+		session := b.Get(1, 1, time.Time{})
+		return session != nil && session.ExitPath == "/b"
+	}, time.Second, time.Millisecond*10)
+	b.Clear()
+	assert.Eventually(t, func() bool {
+		return a.Get(1, 1, time.Time{}) == nil
+	}, time.Second, time.Millisecond*10)
+}
+
+func TestTieredCache_NewMutex(t *testing.T) {
+	server := newTestRedis(t)
+	cache := NewTieredCache(TieredCacheConfig{
+		TTL: time.Minute,
+		RedisCacheConfig: RedisCacheConfig{
+			RedisOptions: &redis.Options{Addr: server.Addr()},
+		},
+	})
+	m := cache.NewMutex(1, 1)
+	m.Lock()
+	m.Unlock()
+	assert.Equal(t, 1, server.Count("set"))
+	assert.Contains(t, server.Keys(), "pirsch_session_1_1_lock")
+
+	// the node owns the fingerprint, so no further distributed locks are required
+	var wg sync.WaitGroup
+	counter := 0
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			m := cache.NewMutex(1, 1)
+			m.Lock()
+			counter++
+			m.Unlock()
+			wg.Done()
+		}()
+	}
+
+	wg.Wait()
+	assert.Equal(t, 10, counter)
+	assert.Equal(t, 1, server.Count("set"))
+	cache.NewMutex(1, 2).Lock()
+	assert.Equal(t, 2, server.Count("set"))
+	cache.Close()
+	assert.NotContains(t, server.Keys(), "pirsch_session_1_1_lock")
+	assert.Contains(t, server.Keys(), "pirsch_session_1_2_lock")
+}
+
+func TestTieredCache_NewMutexOwnership(t *testing.T) {
+	server := newTestRedis(t)
+	config := TieredCacheConfig{
+		TTL: time.Minute,
+		RedisCacheConfig: RedisCacheConfig{
+			RedisOptions:   &redis.Options{Addr: server.Addr()},
+			LockTries:      100,
+			LockRetryDelay: time.Millisecond * 10,
+		},
+	}
+	a := NewTieredCache(config)
+	defer a.Close()
+	b := NewTieredCache(config)
+	defer b.Close()
+	m := a.NewMutex(1, 1)
+	m.Lock()
+	m.Unlock()
+	start := time.Now()
+	m = b.NewMutex(1, 1)
+	m.Lock()
+	m.Unlock()
+	assert.True(t, time.Since(start) < time.Second*5)
+	a.m.Lock()
+	assert.Empty(t, a.owners)
+	a.m.Unlock()
+
+	// the lock is handed over as soon as it is no longer used by the owning node
+	m = b.NewMutex(1, 1)
+	m.Lock()
+	locked := make(chan struct{})
+
+	go func() {
+		m := a.NewMutex(1, 1)
+		m.Lock()
+		close(locked)
+		m.Unlock()
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("lock acquired while being held by another node")
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	m.Unlock()
+
+	select {
+	case <-locked:
+	case <-time.After(time.Second * 5):
+		t.Fatal("lock not handed over")
+	}
+}
+
+func TestTieredCache_NewMutexContention(t *testing.T) {
+	server := newTestRedis(t)
+	config := TieredCacheConfig{
+		TTL: time.Minute,
+		RedisCacheConfig: RedisCacheConfig{
+			RedisOptions:   &redis.Options{Addr: server.Addr()},
+			LockTries:      5,
+			LockRetryDelay: time.Millisecond * 5,
+			LockTimeout:    time.Millisecond * 50,
+		},
+	}
+	a := NewTieredCache(config)
+	defer a.Close()
+	b := NewTieredCache(config)
+	defer b.Close()
+	var wg sync.WaitGroup
+	counter := 0
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(cache *TieredCache) {
+			for j := 0; j < 10; j++ {
+				m := cache.NewMutex(1, 1)
+				m.Lock()
+				counter++
+				m.Unlock()
+			}
+
+			wg.Done()
+		}([]*TieredCache{a, b}[i%2])
+	}
+
+	wg.Wait()
+	assert.Equal(t, 100, counter)
+	a.m.Lock()
+	assert.LessOrEqual(t, len(a.owners), 1)
+	a.m.Unlock()
+}
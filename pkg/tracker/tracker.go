@@ -2,12 +2,16 @@ package tracker
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/dchest/siphash"
 	"github.com/emvi/iso-639-1"
 	"github.com/pirsch-analytics/pirsch/v6/pkg"
 	"github.com/pirsch-analytics/pirsch/v6/pkg/model"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/tracker/geodb"
 	"github.com/pirsch-analytics/pirsch/v6/pkg/tracker/ip"
 	"github.com/pirsch-analytics/pirsch/v6/pkg/tracker/referrer"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/tracker/session"
 	"github.com/pirsch-analytics/pirsch/v6/pkg/tracker/ua"
 	util2 "github.com/pirsch-analytics/pirsch/v6/pkg/util"
 	"log"
@@ -16,6 +20,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 )
@@ -27,6 +32,10 @@ const (
 	minOperaVersion   = 65 // late 2019
 	minEdgeVersion    = 88 // late 2020
 	minIEVersion      = 11 // late 2013
+	minSamsungVersion = 10 // mid 2019
+	minVivaldiVersion = 2  // late 2018
+	minYandexVersion  = 19 // early 2019
+	minUCVersion      = 12 // early 2018
 
 	sessionMaxAge = time.Minute * 30
 
@@ -35,6 +44,30 @@ const (
 	sessionUpdate
 )
 
+var (
+	// ErrStopped is returned when data is tracked after the Tracker has been stopped.
+	ErrStopped = errors.New("tracker stopped")
+)
+
+// FlushError is returned when the Tracker could not save all data before the context was done.
+type FlushError struct {
+	// Unsaved is the number of page views, events, and session updates that have not been saved.
+	Unsaved int
+
+	// Err is the context error.
+	Err error
+}
+
+// Error implements the error interface.
+func (err *FlushError) Error() string {
+	return fmt.Sprintf("%d items left unsaved: %s", err.Unsaved, err.Err)
+}
+
+// Unwrap returns the context error.
+func (err *FlushError) Unwrap() error {
+	return err.Err
+}
+
 type eventType int
 
 type screenClass struct {
@@ -65,33 +98,44 @@ type data struct {
 
 // Tracker tracks page views, events, and updates sessions.
 type Tracker struct {
-	config  Config
-	data    chan data
-	cancel  context.CancelFunc
-	done    chan bool
-	stopped atomic.Bool
+	config         Config
+	data           chan data
+	cancel         context.CancelFunc
+	done           chan bool
+	flush          []chan chan struct{}
+	buffered       atomic.Int64
+	stopped        atomic.Bool
+	stop           chan struct{}
+	snapshotCancel context.CancelFunc
+	snapshotDone   chan struct{}
+	userAgentCache *ua.Cache
 }
 
 // NewTracker creates a new tracker for given client, salt and config.
 func NewTracker(config Config) *Tracker {
 	config.validate()
 	tracker := &Tracker{
-		config: config,
-		data:   make(chan data, config.WorkerBufferSize),
-		done:   make(chan bool),
+		config:         config,
+		data:           make(chan data, config.WorkerBufferSize),
+		done:           make(chan bool, config.Worker),
+		stop:           make(chan struct{}),
+		userAgentCache: ua.NewCache(config.UserAgentCacheSize),
 	}
+	tracker.restoreSessions()
 	tracker.startWorker()
+	tracker.startSnapshots()
 	return tracker
 }
 
 // PageView tracks a page view.
-func (tracker *Tracker) PageView(r *http.Request, clientID uint64, options Options) {
+// It returns ErrStopped if the Tracker has been stopped.
+func (tracker *Tracker) PageView(r *http.Request, clientID uint64, options Options) error {
 	if tracker.stopped.Load() {
-		return
+		return ErrStopped
 	}
 
 	now := time.Now().UTC()
-	userAgent, ipAddress, ignore := tracker.ignore(r)
+	userAgent, ipAddress, ignore := tracker.ignore(r, clientID)
 	options.validate(r)
 
 	if !options.Time.IsZero() {
@@ -120,7 +164,11 @@ func (tracker *Tracker) PageView(r *http.Request, clientID uint64, options Optio
 					Title:           session.ExitTitle,
 					Language:        session.Language,
 					CountryCode:     session.CountryCode,
+					Region:          session.Region,
 					City:            session.City,
+					TimeZone:        session.TimeZone,
+					ASN:             session.ASN,
+					ASOrganization:  session.ASOrganization,
 					Referrer:        session.Referrer,
 					ReferrerName:    session.ReferrerName,
 					ReferrerIcon:    session.ReferrerIcon,
@@ -130,6 +178,9 @@ func (tracker *Tracker) PageView(r *http.Request, clientID uint64, options Optio
 					BrowserVersion:  session.BrowserVersion,
 					Desktop:         session.Desktop,
 					Mobile:          session.Mobile,
+					DeviceType:      session.DeviceType,
+					DeviceVendor:    session.DeviceVendor,
+					DeviceModel:     session.DeviceModel,
 					ScreenClass:     session.ScreenClass,
 					UTMSource:       session.UTMSource,
 					UTMMedium:       session.UTMMedium,
@@ -157,19 +208,22 @@ func (tracker *Tracker) PageView(r *http.Request, clientID uint64, options Optio
 			},
 		}
 	}
+
+	return nil
 }
 
 // Event tracks an event.
-func (tracker *Tracker) Event(r *http.Request, clientID uint64, eventOptions EventOptions, options Options) {
+// It returns ErrStopped if the Tracker has been stopped.
+func (tracker *Tracker) Event(r *http.Request, clientID uint64, eventOptions EventOptions, options Options) error {
 	if tracker.stopped.Load() {
-		return
+		return ErrStopped
 	}
 
 	now := time.Now().UTC()
 	eventOptions.validate()
 
 	if eventOptions.Name != "" {
-		userAgent, ipAddress, ignore := tracker.ignore(r)
+		userAgent, ipAddress, ignore := tracker.ignore(r, clientID)
 		options.validate(r)
 
 		if !options.Time.IsZero() {
@@ -202,7 +256,11 @@ func (tracker *Tracker) Event(r *http.Request, clientID uint64, eventOptions Eve
 						Title:           session.ExitTitle,
 						Language:        session.Language,
 						CountryCode:     session.CountryCode,
+						Region:          session.Region,
 						City:            session.City,
+						TimeZone:        session.TimeZone,
+						ASN:             session.ASN,
+						ASOrganization:  session.ASOrganization,
 						Referrer:        session.Referrer,
 						ReferrerName:    session.ReferrerName,
 						ReferrerIcon:    session.ReferrerIcon,
@@ -212,6 +270,9 @@ func (tracker *Tracker) Event(r *http.Request, clientID uint64, eventOptions Eve
 						BrowserVersion:  session.BrowserVersion,
 						Desktop:         session.Desktop,
 						Mobile:          session.Mobile,
+						DeviceType:      session.DeviceType,
+						DeviceVendor:    session.DeviceVendor,
+						DeviceModel:     session.DeviceModel,
 						ScreenClass:     session.ScreenClass,
 						UTMSource:       session.UTMSource,
 						UTMMedium:       session.UTMMedium,
@@ -235,16 +296,19 @@ func (tracker *Tracker) Event(r *http.Request, clientID uint64, eventOptions Eve
 			}
 		}
 	}
+
+	return nil
 }
 
 // ExtendSession extends an existing session.
-func (tracker *Tracker) ExtendSession(r *http.Request, clientID uint64, options Options) {
+// It returns ErrStopped if the Tracker has been stopped.
+func (tracker *Tracker) ExtendSession(r *http.Request, clientID uint64, options Options) error {
 	if tracker.stopped.Load() {
-		return
+		return ErrStopped
 	}
 
 	now := time.Now().UTC()
-	userAgent, ipAddress, ignore := tracker.ignore(r)
+	userAgent, ipAddress, ignore := tracker.ignore(r, clientID)
 
 	if !ignore {
 		options.validate(r)
@@ -262,25 +326,130 @@ func (tracker *Tracker) ExtendSession(r *http.Request, clientID uint64, options
 			}
 		}
 	}
+
+	return nil
 }
 
 // Flush flushes all buffered data.
+// Flush blocks until all data has been saved. Use FlushContext to limit the time it takes.
 func (tracker *Tracker) Flush() {
-	tracker.stopWorker()
-	tracker.flushData()
-	tracker.startWorker()
+	if err := tracker.FlushContext(context.Background()); err != nil && !errors.Is(err, ErrStopped) {
+		tracker.config.Logger.Error("error flushing tracker", "err", err)
+	}
+}
+
+// FlushContext saves all buffered data without pausing the workers.
+// It returns a FlushError containing the number of unsaved items if the context is done before all data has been saved,
+// or ErrStopped if the Tracker has been stopped.
+func (tracker *Tracker) FlushContext(ctx context.Context) error {
+	if tracker.stopped.Load() {
+		return ErrStopped
+	}
+
+	done := make(chan struct{})
+	stopped := false
+
+	go func() {
+		defer close(done)
+		tracker.flushData()
+		acks := make([]chan struct{}, 0, len(tracker.flush))
+
+		// the workers might be stopped concurrently, in which case they won't acknowledge the flush anymore
+		for _, flush := range tracker.flush {
+			ack := make(chan struct{})
+
+			select {
+			case flush <- ack:
+				acks = append(acks, ack)
+			case <-tracker.stop:
+				stopped = true
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		for _, ack := range acks {
+			select {
+			case <-ack:
+			case <-tracker.stop:
+				stopped = true
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	if err := tracker.wait(ctx, done); err != nil {
+		return err
+	}
+
+	if stopped {
+		return ErrStopped
+	}
+
+	return nil
 }
 
 // Stop flushes and stops all workers.
+// Stop blocks until all data has been saved. Use StopContext to limit the time it takes.
 func (tracker *Tracker) Stop() {
-	if !tracker.stopped.Load() {
-		tracker.stopped.Store(true)
-		tracker.stopWorker()
+	if err := tracker.StopContext(context.Background()); err != nil {
+		tracker.config.Logger.Error("error stopping tracker", "err", err)
+	}
+}
+
+// StopContext stops all workers and saves the buffered data.
+// Data is drained concurrently while the workers shut down.
+// It returns a FlushError containing the number of unsaved items if the context is done before all data has been saved.
+// Calling it again after the Tracker has been stopped does nothing.
+func (tracker *Tracker) StopContext(ctx context.Context) error {
+	if !tracker.stopped.CompareAndSwap(false, true) {
+		return nil
+	}
+
+	close(tracker.stop)
+	tracker.stopSnapshots()
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		var wg sync.WaitGroup
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			tracker.stopWorker()
+		}()
+
+		go func() {
+			defer wg.Done()
+			tracker.flushData()
+		}()
+
+		wg.Wait()
 		tracker.flushData()
+	}()
+
+	err := tracker.wait(ctx, done)
+	tracker.snapshotSessions()
+	return err
+}
+
+func (tracker *Tracker) wait(ctx context.Context, done <-chan struct{}) error {
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return &FlushError{
+			Unsaved: len(tracker.data) + int(tracker.buffered.Load()),
+			Err:     ctx.Err(),
+		}
 	}
 }
 
-func (tracker *Tracker) ignore(r *http.Request) (model.UserAgent, string, bool) {
+func (tracker *Tracker) ignore(r *http.Request, clientID uint64) (model.UserAgent, string, bool) {
 	// respect do not track header
 	if r.Header.Get("DNT") == "1" {
 		return model.UserAgent{}, "", true
@@ -322,24 +491,28 @@ func (tracker *Tracker) ignore(r *http.Request) (model.UserAgent, string, bool)
 	}
 
 	// filter referrer spammers
-	if referrer.Ignore(r) {
+	if referrer.IgnoreForClient(r, clientID) {
+		return model.UserAgent{}, "", true
+	}
+
+	// filter for bot keywords
+	if ua.IsBlacklistedForClient(clientID, userAgent) {
 		return model.UserAgent{}, "", true
 	}
 
-	userAgentResult := ua.Parse(r)
+	userAgentResult := tracker.userAgentCache.Parse(r)
 
 	if tracker.ignoreBrowserVersion(userAgentResult.Browser, userAgentResult.BrowserVersion) {
 		return model.UserAgent{}, "", true
 	}
 
-	// filter for bot keywords
-	for _, botUserAgent := range ua.Blacklist {
-		if strings.Contains(userAgent, botUserAgent) {
-			return model.UserAgent{}, "", true
-		}
-	}
+	var ipAddress string
 
-	ipAddress := ip.Get(r, tracker.config.HeaderParser, tracker.config.AllowedProxySubnets)
+	if tracker.config.TrustedProxies != nil {
+		ipAddress = ip.GetUntrusted(r, tracker.config.HeaderParser, *tracker.config.TrustedProxies)
+	} else {
+		ipAddress = ip.Get(r, tracker.config.HeaderParser, tracker.config.AllowedProxySubnets)
+	}
 
 	if tracker.config.IPFilter != nil && tracker.config.IPFilter.Ignore(ipAddress) {
 		return model.UserAgent{}, "", true
@@ -355,7 +528,13 @@ func (tracker *Tracker) ignoreBrowserVersion(browser, version string) bool {
 		browser == pkg.BrowserSafari && tracker.browserVersionBefore(version, minSafariVersion) ||
 		browser == pkg.BrowserOpera && tracker.browserVersionBefore(version, minOperaVersion) ||
 		browser == pkg.BrowserEdge && tracker.browserVersionBefore(version, minEdgeVersion) ||
-		browser == pkg.BrowserIE && tracker.browserVersionBefore(version, minIEVersion)
+		browser == pkg.BrowserIE && tracker.browserVersionBefore(version, minIEVersion) ||
+		browser == pkg.BrowserSamsung && tracker.browserVersionBefore(version, minSamsungVersion) ||
+		browser == pkg.BrowserBrave && tracker.browserVersionBefore(version, minChromeVersion) ||
+		browser == pkg.BrowserVivaldi && tracker.browserVersionBefore(version, minVivaldiVersion) ||
+		browser == pkg.BrowserYandex && tracker.browserVersionBefore(version, minYandexVersion) ||
+		browser == pkg.BrowserUC && tracker.browserVersionBefore(version, minUCVersion) ||
+		browser == pkg.BrowserAndroidWebView && tracker.browserVersionBefore(version, minChromeVersion)
 }
 
 func (tracker *Tracker) browserVersionBefore(version string, min int) bool {
@@ -431,8 +610,10 @@ func (tracker *Tracker) newSession(clientID uint64, r *http.Request, fingerprint
 	ua.OSVersion = util2.ShortenString(ua.OSVersion, 20)
 	ua.Browser = util2.ShortenString(ua.Browser, 20)
 	ua.BrowserVersion = util2.ShortenString(ua.BrowserVersion, 20)
+	ua.DeviceVendor = util2.ShortenString(ua.DeviceVendor, 50)
+	ua.DeviceModel = util2.ShortenString(ua.DeviceModel, 100)
 	lang := util2.ShortenString(tracker.getLanguage(r), 10)
-	ref, referrerName, referrerIcon := referrer.Get(r, options.Referrer, options.Hostname)
+	ref, referrerName, referrerIcon := referrer.GetForClient(r, clientID, options.Referrer, options.Hostname)
 	ref = util2.ShortenString(ref, 200)
 	referrerName = util2.ShortenString(referrerName, 200)
 	referrerIcon = util2.ShortenString(referrerIcon, 2000)
@@ -443,10 +624,10 @@ func (tracker *Tracker) newSession(clientID uint64, r *http.Request, fingerprint
 	utmCampaign := strings.TrimSpace(query.Get("utm_campaign"))
 	utmContent := strings.TrimSpace(query.Get("utm_content"))
 	utmTerm := strings.TrimSpace(query.Get("utm_term"))
-	countryCode, city := "", ""
+	var location geodb.Location
 
-	if tracker.config.GeoDB != nil {
-		countryCode, city = tracker.config.GeoDB.GetLocation(ip)
+	if tracker.config.GeoProvider != nil {
+		location = tracker.config.GeoProvider.Locate(r, ip)
 	}
 
 	return &model.Session{
@@ -463,8 +644,12 @@ func (tracker *Tracker) newSession(clientID uint64, r *http.Request, fingerprint
 		EntryTitle:     options.Title,
 		ExitTitle:      options.Title,
 		Language:       lang,
-		CountryCode:    countryCode,
-		City:           city,
+		CountryCode:    location.CountryCode,
+		Region:         location.Region,
+		City:           location.City,
+		TimeZone:       location.TimeZone,
+		ASN:            location.ASN,
+		ASOrganization: util2.ShortenString(location.ASOrganization, 200),
 		Referrer:       ref,
 		ReferrerName:   referrerName,
 		ReferrerIcon:   referrerIcon,
@@ -474,6 +659,9 @@ func (tracker *Tracker) newSession(clientID uint64, r *http.Request, fingerprint
 		BrowserVersion: ua.BrowserVersion,
 		Desktop:        ua.IsDesktop(),
 		Mobile:         ua.IsMobile(),
+		DeviceType:     ua.DeviceType,
+		DeviceVendor:   ua.DeviceVendor,
+		DeviceModel:    ua.DeviceModel,
 		ScreenClass:    screenClass,
 		UTMSource:      utmSource,
 		UTMMedium:      utmMedium,
@@ -570,7 +758,7 @@ func (tracker *Tracker) getScreenWidthFromHeader(r *http.Request, header string)
 }
 
 func (tracker *Tracker) referrerOrCampaignChanged(r *http.Request, session *model.Session, ref, hostname string) bool {
-	ref, refName, _ := referrer.Get(r, ref, hostname)
+	ref, refName, _ := referrer.GetForClient(r, session.ClientID, ref, hostname)
 
 	if ref != "" && ref != session.Referrer || refName != "" && refName != session.ReferrerName {
 		return true
@@ -601,9 +789,11 @@ func (tracker *Tracker) fingerprint(salt, ua, ip string, now time.Time) uint64 {
 func (tracker *Tracker) startWorker() {
 	ctx, cancelFunc := context.WithCancel(context.Background())
 	tracker.cancel = cancelFunc
+	tracker.flush = make([]chan chan struct{}, tracker.config.Worker)
 
 	for i := 0; i < tracker.config.Worker; i++ {
-		go tracker.aggregateData(ctx)
+		tracker.flush[i] = make(chan chan struct{})
+		go tracker.aggregateData(ctx, tracker.flush[i])
 	}
 }
 
@@ -616,81 +806,25 @@ func (tracker *Tracker) stopWorker() {
 }
 
 func (tracker *Tracker) flushData() {
-	bufferSize := tracker.config.WorkerBufferSize
-	sessions := make([]model.Session, 0, bufferSize*2)
-	pageViews := make([]model.PageView, 0, bufferSize)
-	events := make([]model.Event, 0, bufferSize)
-	userAgents := make([]model.UserAgent, 0, bufferSize)
-	bots := make([]model.Bot, 0, bufferSize)
+	batch := tracker.newBatch()
 
 	for {
-		stop := false
-
 		select {
 		case data := <-tracker.data:
-			if data.cancelSession != nil {
-				sessions = append(sessions, *data.cancelSession)
-			}
-
-			if data.session != nil {
-				sessions = append(sessions, *data.session)
-			}
-
-			if data.pageView != nil {
-				pageViews = append(pageViews, *data.pageView)
-			}
-
-			if data.event != nil {
-				events = append(events, *data.event)
-			}
-
-			if data.ua != nil {
-				userAgents = append(userAgents, *data.ua)
-			}
+			batch.add(data)
 
-			if data.bot != nil {
-				bots = append(bots, *data.bot)
-			}
-
-			if len(sessions)+2 >= bufferSize*2 ||
-				len(pageViews)+1 >= bufferSize ||
-				len(events)+1 >= bufferSize ||
-				len(userAgents)+1 >= bufferSize ||
-				len(bots)+1 >= bufferSize {
-				tracker.saveSessions(sessions)
-				tracker.savePageViews(pageViews)
-				tracker.saveEvents(events)
-				tracker.saveUserAgents(userAgents)
-				tracker.saveBots(bots)
-				sessions = sessions[:0]
-				pageViews = pageViews[:0]
-				events = events[:0]
-				userAgents = userAgents[:0]
-				bots = bots[:0]
+			if batch.full() {
+				batch.save()
 			}
 		default:
-			stop = true
-		}
-
-		if stop {
-			break
+			batch.save()
+			return
 		}
 	}
-
-	tracker.saveSessions(sessions)
-	tracker.savePageViews(pageViews)
-	tracker.saveEvents(events)
-	tracker.saveUserAgents(userAgents)
-	tracker.saveBots(bots)
 }
 
-func (tracker *Tracker) aggregateData(ctx context.Context) {
-	bufferSize := tracker.config.WorkerBufferSize
-	sessions := make([]model.Session, 0, bufferSize*2)
-	pageViews := make([]model.PageView, 0, bufferSize)
-	events := make([]model.Event, 0, bufferSize)
-	userAgents := make([]model.UserAgent, 0, bufferSize)
-	bots := make([]model.Bot, 0, bufferSize)
+func (tracker *Tracker) aggregateData(ctx context.Context, flush <-chan chan struct{}) {
+	batch := tracker.newBatch()
 	timer := time.NewTimer(tracker.config.WorkerTimeout)
 	defer timer.Stop()
 
@@ -699,67 +833,87 @@ func (tracker *Tracker) aggregateData(ctx context.Context) {
 
 		select {
 		case data := <-tracker.data:
-			if data.cancelSession != nil {
-				sessions = append(sessions, *data.cancelSession)
-			}
+			batch.add(data)
 
-			if data.session != nil {
-				sessions = append(sessions, *data.session)
+			if batch.full() {
+				batch.save()
 			}
+		case ack := <-flush:
+			batch.save()
+			close(ack)
+		case <-timer.C:
+			batch.save()
+		case <-ctx.Done():
+			batch.save()
+			tracker.done <- true
+			return
+		}
+	}
+}
 
-			if data.pageView != nil {
-				pageViews = append(pageViews, *data.pageView)
-			}
+func (tracker *Tracker) restoreSessions() {
+	cache, ok := tracker.config.SessionCache.(session.Snapshotter)
 
-			if data.event != nil {
-				events = append(events, *data.event)
-			}
+	if !ok || tracker.config.SessionSnapshotPath == "" {
+		return
+	}
 
-			if data.ua != nil {
-				userAgents = append(userAgents, *data.ua)
-			}
+	n, err := session.LoadSnapshot(cache, tracker.config.SessionSnapshotPath, time.Now().UTC().Add(-sessionMaxAge))
 
-			if data.bot != nil {
-				bots = append(bots, *data.bot)
-			}
+	if err != nil {
+		tracker.config.Logger.Error("error restoring session snapshot", "err", err)
+		var skipped *session.SkippedSessionsError
 
-			if len(sessions)+2 >= bufferSize*2 ||
-				len(pageViews)+1 >= bufferSize ||
-				len(events)+1 >= bufferSize ||
-				len(userAgents)+1 >= bufferSize ||
-				len(bots)+1 >= bufferSize {
-				tracker.saveSessions(sessions)
-				tracker.savePageViews(pageViews)
-				tracker.saveEvents(events)
-				tracker.saveUserAgents(userAgents)
-				tracker.saveBots(bots)
-				sessions = sessions[:0]
-				pageViews = pageViews[:0]
-				events = events[:0]
-				userAgents = userAgents[:0]
-				bots = bots[:0]
-			}
-		case <-timer.C:
-			tracker.saveSessions(sessions)
-			tracker.savePageViews(pageViews)
-			tracker.saveEvents(events)
-			tracker.saveUserAgents(userAgents)
-			tracker.saveBots(bots)
-			sessions = sessions[:0]
-			pageViews = pageViews[:0]
-			events = events[:0]
-			userAgents = userAgents[:0]
-			bots = bots[:0]
-		case <-ctx.Done():
-			tracker.saveSessions(sessions)
-			tracker.savePageViews(pageViews)
-			tracker.saveEvents(events)
-			tracker.saveUserAgents(userAgents)
-			tracker.saveBots(bots)
-			tracker.done <- true
+		if !errors.As(err, &skipped) {
 			return
 		}
 	}
+
+	tracker.config.Logger.Debug("restored sessions from snapshot", "sessions", n)
+}
+
+func (tracker *Tracker) snapshotSessions() {
+	cache, ok := tracker.config.SessionCache.(session.Snapshotter)
+
+	if !ok || tracker.config.SessionSnapshotPath == "" {
+		return
+	}
+
+	if err := session.SaveSnapshot(cache, tracker.config.SessionSnapshotPath); err != nil {
+		tracker.config.Logger.Error("error writing session snapshot", "err", err)
+	}
+}
+
+func (tracker *Tracker) startSnapshots() {
+	if tracker.config.SessionSnapshotInterval <= 0 || tracker.config.SessionSnapshotPath == "" {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	tracker.snapshotCancel = cancel
+	tracker.snapshotDone = make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(tracker.config.SessionSnapshotInterval)
+		defer ticker.Stop()
+		defer close(tracker.snapshotDone)
+
+		for {
+			select {
+			case <-ticker.C:
+				tracker.snapshotSessions()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (tracker *Tracker) stopSnapshots() {
+	if tracker.snapshotCancel != nil {
+		tracker.snapshotCancel()
+		<-tracker.snapshotDone
+	}
 }
 
 func (tracker *Tracker) savePageViews(pageViews []model.PageView) {
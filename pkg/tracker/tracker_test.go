@@ -1,6 +1,7 @@
 package tracker
 
 import (
+	"context"
 	"fmt"
 	"github.com/go-redis/redis/v8"
 	"github.com/pirsch-analytics/pirsch/v6/pkg"
@@ -11,8 +12,11 @@ import (
 	"github.com/pirsch-analytics/pirsch/v6/pkg/tracker/session"
 	"github.com/pirsch-analytics/pirsch/v6/pkg/tracker/ua"
 	"github.com/stretchr/testify/assert"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 )
@@ -72,6 +76,7 @@ func TestTracker_PageView(t *testing.T) {
 	req.RemoteAddr = "81.2.69.142"
 	geoDB, _ := geodb.NewGeoDB("", "")
 	assert.NoError(t, geoDB.UpdateFromFile("../../test/GeoIP2-City-Test.mmdb"))
+	assert.NoError(t, geoDB.UpdateASNFromFile("../../test/GeoLite2-ASN-Test.mmdb"))
 	client := db.NewClientMock()
 	tracker := NewTracker(Config{
 		Store: client,
@@ -103,7 +108,11 @@ func TestTracker_PageView(t *testing.T) {
 	assert.True(t, sessions[0].IsBounce)
 	assert.Equal(t, "fr", sessions[0].Language)
 	assert.Equal(t, "gb", sessions[0].CountryCode)
+	assert.Equal(t, "gb-eng", sessions[0].Region)
 	assert.Equal(t, "London", sessions[0].City)
+	assert.Equal(t, "Europe/London", sessions[0].TimeZone)
+	assert.Equal(t, uint32(20712), sessions[0].ASN)
+	assert.Equal(t, "Andrews & Arnold Ltd", sessions[0].ASOrganization)
 	assert.Equal(t, "https://google.com", sessions[0].Referrer)
 	assert.Equal(t, "Google", sessions[0].ReferrerName)
 	assert.Equal(t, pkg.OSLinux, sessions[0].OS)
@@ -126,7 +135,11 @@ func TestTracker_PageView(t *testing.T) {
 	assert.Equal(t, "Foo", pageViews[0].Title)
 	assert.Equal(t, "fr", pageViews[0].Language)
 	assert.Equal(t, "gb", pageViews[0].CountryCode)
+	assert.Equal(t, "gb-eng", pageViews[0].Region)
 	assert.Equal(t, "London", pageViews[0].City)
+	assert.Equal(t, "Europe/London", pageViews[0].TimeZone)
+	assert.Equal(t, uint32(20712), pageViews[0].ASN)
+	assert.Equal(t, "Andrews & Arnold Ltd", pageViews[0].ASOrganization)
 	assert.Equal(t, "https://google.com", pageViews[0].Referrer)
 	assert.Equal(t, "Google", pageViews[0].ReferrerName)
 	assert.Equal(t, pkg.OSLinux, pageViews[0].OS)
@@ -211,6 +224,90 @@ func TestTracker_PageView(t *testing.T) {
 	assert.Equal(t, userAgent, userAgents[0].UserAgent)
 }
 
+func TestTracker_PageViewGeoProvider(t *testing.T) {
+	geoDB, _ := geodb.NewGeoDB("", "")
+	assert.NoError(t, geoDB.UpdateFromFile("../../test/GeoIP2-City-Test.mmdb"))
+	assert.NoError(t, geoDB.UpdateASNFromFile("../../test/GeoLite2-ASN-Test.mmdb"))
+	_, subnet, _ := net.ParseCIDR("10.0.0.0/8")
+	client := db.NewClientMock()
+	tracker := NewTracker(Config{
+		Store:               client,
+		HeaderParser:        ip.DefaultHeaderParser,
+		AllowedProxySubnets: []net.IPNet{*subnet},
+		GeoProvider: geodb.GeoProviders{
+			geodb.NewHeaderProvider(geodb.CloudflareHeaders, []net.IPNet{*subnet}),
+			geoDB,
+		},
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Add("User-Agent", userAgent)
+	req.Header.Set("X-Forwarded-For", "81.2.69.142")
+	req.Header.Set("CF-IPCountry", "DE")
+	req.Header.Set("CF-Region-Code", "BE")
+	req.Header.Set("CF-IPCity", "Berlin")
+	req.RemoteAddr = "10.0.0.1"
+	tracker.PageView(req, 123, Options{})
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Add("User-Agent", userAgent)
+	req.RemoteAddr = "216.160.83.56"
+	req.Header.Set("CF-IPCountry", "DE")
+	tracker.PageView(req, 123, Options{})
+	tracker.Flush()
+	sessions := client.GetSessions()
+	assert.Len(t, sessions, 2)
+	assert.Equal(t, "de", sessions[0].CountryCode)
+	assert.Equal(t, "de-be", sessions[0].Region)
+	assert.Equal(t, "Berlin", sessions[0].City)
+	assert.Empty(t, sessions[0].TimeZone)
+	assert.Equal(t, uint32(20712), sessions[0].ASN)
+	assert.Equal(t, "Andrews & Arnold Ltd", sessions[0].ASOrganization)
+	assert.Equal(t, "us", sessions[1].CountryCode)
+	assert.Equal(t, "us-wa", sessions[1].Region)
+	assert.Equal(t, "Milton (WA)", sessions[1].City)
+	assert.Equal(t, "America/Los_Angeles", sessions[1].TimeZone)
+	assert.Equal(t, uint32(209), sessions[1].ASN)
+}
+
+func TestTracker_PageViewBrowsers(t *testing.T) {
+	input := []struct {
+		userAgent string
+		browser   string
+	}{
+		{"Mozilla/5.0 (Linux; Android 13; SAMSUNG SM-S918B) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/23.0 Chrome/115.0.0.0 Mobile Safari/537.36", pkg.BrowserSamsung},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/118.0.0.0 Safari/537.36 Brave/118.1.59.124", pkg.BrowserBrave},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/118.0.0.0 Safari/537.36 Vivaldi/6.4.3160.34", pkg.BrowserVivaldi},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/116.0.0.0 YaBrowser/23.9.0.0 Safari/537.36", pkg.BrowserYandex},
+		{"Mozilla/5.0 (Linux; U; Android 10; en-US; RMX1851 Build/QKQ1.190918.001) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/78.0.3904.108 UCBrowser/13.4.0.1306 Mobile Safari/537.36", pkg.BrowserUC},
+		{"Mozilla/5.0 (Linux; Android 13; Pixel 7) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/118.0.0.0 Mobile DuckDuckGo/5 Safari/537.36", pkg.BrowserDuckDuckGo},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 17_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Mobile/15E148 DuckDuckGo/7 Safari/605.1.15", pkg.BrowserDuckDuckGo},
+		{"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Safari/605.1.15 Ddg/17.1", pkg.BrowserDuckDuckGo},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148 Instagram 305.0.0.34.110 (iPhone14,5; iOS 17_0; en_US; en; scale=3.00; 1170x2532; 526431520)", pkg.BrowserInstagram},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 17_0_3 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148 [FBAN/FBIOS;FBDV/iPhone14,2;FBMD/iPhone;FBSN/iOS;FBSV/17.0.3;FBSS/3;FBID/phone;FBLC/en_US;FBOP/5;FBAV/437.0.0.35.117]", pkg.BrowserFacebook},
+		{"Mozilla/5.0 (Linux; Android 13; Pixel 6 Build/TQ3A.230901.001; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/117.0.5938.153 Mobile Safari/537.36 [FB_IAB/FB4A;FBAV/436.0.0.35.101;]", pkg.BrowserFacebook},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 16_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148 musical_ly_31.5.0 JsSdk/2.0 NetType/WIFI Channel/App Store ByteLocale/en Region/US", pkg.BrowserTikTok},
+		{"Mozilla/5.0 (Linux; Android 13; SM-S908B Build/TP1A.220624.014; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/118.0.5993.111 Mobile Safari/537.36", pkg.BrowserAndroidWebView},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 17_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148", pkg.BrowserIOSWebView},
+	}
+
+	for _, in := range input {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("User-Agent", in.userAgent)
+		client := db.NewClientMock()
+		tracker := NewTracker(Config{
+			Store: client,
+		})
+		tracker.PageView(req, 0, Options{})
+		tracker.Flush()
+		sessions := client.GetSessions()
+
+		if assert.Len(t, sessions, 1, in.userAgent) {
+			assert.Equal(t, in.browser, sessions[0].Browser, in.userAgent)
+		}
+
+		tracker.Stop()
+	}
+}
+
 func TestTracker_PageViewRebounce(t *testing.T) {
 	client := db.NewClientMock()
 	tracker := NewTracker(Config{
@@ -391,8 +488,9 @@ func TestTracker_PageViewFindSessionRedis(t *testing.T) {
 	req.Header.Set("Referer", "https://google.com")
 	req.RemoteAddr = "81.2.69.142"
 	client := db.NewClientMock()
-	cache := session.NewRedisCache(time.Minute*30, nil, &redis.Options{
-		Addr: "localhost:6379",
+	cache := session.NewRedisCacheWithConfig(session.RedisCacheConfig{
+		MaxAge:       time.Minute * 30,
+		RedisOptions: &redis.Options{Addr: "localhost:6379"},
 	})
 	tracker := NewTracker(Config{
 		Store:        client,
@@ -424,6 +522,7 @@ func TestTracker_Event(t *testing.T) {
 	req.RemoteAddr = "81.2.69.142"
 	geoDB, _ := geodb.NewGeoDB("", "")
 	assert.NoError(t, geoDB.UpdateFromFile("../../test/GeoIP2-City-Test.mmdb"))
+	assert.NoError(t, geoDB.UpdateASNFromFile("../../test/GeoLite2-ASN-Test.mmdb"))
 	client := db.NewClientMock()
 	tracker := NewTracker(Config{
 		Store: client,
@@ -458,7 +557,11 @@ func TestTracker_Event(t *testing.T) {
 	assert.Equal(t, "Foo", events[0].Title)
 	assert.Equal(t, "fr", events[0].Language)
 	assert.Equal(t, "gb", events[0].CountryCode)
+	assert.Equal(t, "gb-eng", events[0].Region)
 	assert.Equal(t, "London", events[0].City)
+	assert.Equal(t, "Europe/London", events[0].TimeZone)
+	assert.Equal(t, uint32(20712), events[0].ASN)
+	assert.Equal(t, "Andrews & Arnold Ltd", events[0].ASOrganization)
 	assert.Equal(t, "https://google.com", events[0].Referrer)
 	assert.Equal(t, "Google", events[0].ReferrerName)
 	assert.Equal(t, pkg.OSLinux, events[0].OS)
@@ -664,6 +767,131 @@ func TestTracker_Flush(t *testing.T) {
 	assert.Equal(t, 10, count)
 }
 
+func TestTracker_SessionSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions")
+	client := db.NewClientMock()
+	config := Config{
+		Store:                   client,
+		Salt:                    "salt",
+		FingerprintKey0:         1,
+		FingerprintKey1:         2,
+		SessionCache:            session.NewMemCache(client, 100),
+		SessionSnapshotPath:     path,
+		SessionSnapshotInterval: time.Millisecond * 50,
+	}
+	tracker := NewTracker(config)
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("User-Agent", userAgent)
+	tracker.PageView(req, 0, Options{})
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(path)
+		return err == nil
+	}, time.Second, time.Millisecond*10)
+	tracker.Stop()
+	cache := session.NewMemCache(client, 100)
+	config.SessionCache = cache
+	config.SessionSnapshotInterval = 0
+	tracker = NewTracker(config)
+	assert.Len(t, cache.Sessions(), 1)
+	tracker.PageView(req, 0, Options{Path: "/foo"})
+	tracker.Stop()
+	sessions := client.GetSessions()
+	assert.Len(t, sessions, 3)
+	assert.Equal(t, int8(-1), sessions[1].Sign)
+	assert.Equal(t, uint16(2), sessions[2].PageViews)
+}
+
+func TestTracker_FlushContext(t *testing.T) {
+	client := db.NewClientMock()
+	tracker := NewTracker(Config{
+		Store:         client,
+		Worker:        4,
+		WorkerTimeout: time.Minute,
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("User-Agent", userAgent)
+
+	for i := 0; i < 20; i++ {
+		req.RemoteAddr = fmt.Sprintf("81.2.69.%d", i)
+		assert.NoError(t, tracker.PageView(req, 0, Options{}))
+	}
+
+	assert.NoError(t, tracker.FlushContext(context.Background()))
+	assert.Len(t, client.GetPageViews(), 20)
+
+	// workers keep running after flushing
+	assert.NoError(t, tracker.PageView(req, 0, Options{Path: "/foo"}))
+	assert.NoError(t, tracker.FlushContext(context.Background()))
+	assert.Len(t, client.GetPageViews(), 21)
+	assert.NoError(t, tracker.StopContext(context.Background()))
+	assert.ErrorIs(t, tracker.FlushContext(context.Background()), ErrStopped)
+}
+
+func TestTracker_FlushContextStopped(t *testing.T) {
+	tracker := NewTracker(Config{
+		Store:  db.NewClientMock(),
+		Worker: 4,
+	})
+	assert.NoError(t, tracker.StopContext(context.Background()))
+
+	// simulate a flush that started before the Tracker was stopped
+	tracker.stopped.Store(false)
+	done := make(chan error)
+
+	go func() {
+		done <- tracker.FlushContext(context.Background())
+	}()
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, ErrStopped)
+	case <-time.After(time.Second * 5):
+		t.Fatal("flush blocked after the tracker has been stopped")
+	}
+}
+
+func TestTracker_StopContext(t *testing.T) {
+	client := &slowStore{ClientMock: db.NewClientMock(), delay: time.Millisecond * 200}
+	tracker := NewTracker(Config{
+		Store:            client,
+		Worker:           1,
+		WorkerBufferSize: 2,
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("User-Agent", userAgent)
+
+	for i := 0; i < 10; i++ {
+		req.RemoteAddr = fmt.Sprintf("81.2.69.%d", i)
+		go tracker.PageView(req.Clone(context.Background()), 0, Options{})
+	}
+
+	time.Sleep(time.Millisecond * 50)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+	err := tracker.StopContext(ctx)
+	var flushErr *FlushError
+	assert.ErrorAs(t, err, &flushErr)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Greater(t, flushErr.Unsaved, 0)
+	assert.ErrorIs(t, tracker.PageView(req, 0, Options{}), ErrStopped)
+	assert.ErrorIs(t, tracker.Event(req, 0, EventOptions{Name: "event"}, Options{}), ErrStopped)
+	assert.ErrorIs(t, tracker.ExtendSession(req, 0, Options{}), ErrStopped)
+	assert.NoError(t, tracker.StopContext(context.Background()))
+	assert.Eventually(t, func() bool {
+		return len(client.GetPageViews()) == 10
+	}, time.Second*5, time.Millisecond*50)
+}
+
+type slowStore struct {
+	*db.ClientMock
+	delay time.Duration
+}
+
+func (store *slowStore) SavePageViews(pageViews []model.PageView) error {
+	time.Sleep(store.delay)
+	return store.ClientMock.SavePageViews(pageViews)
+}
+
 func TestTrackerBots(t *testing.T) {
 	store := db.NewClientMock()
 	tracker := NewTracker(Config{
@@ -720,39 +948,39 @@ func TestTracker_ignorePrefetch(t *testing.T) {
 	req.Header.Add("User-Agent", userAgent)
 	req.Header.Set("X-Moz", "prefetch")
 
-	if _, _, ignore := tracker.ignore(req); !ignore {
+	if _, _, ignore := tracker.ignore(req, 0); !ignore {
 		t.Fatal("Session with X-Moz header must be ignored")
 	}
 
 	req.Header.Del("X-Moz")
 	req.Header.Set("X-Purpose", "prefetch")
 
-	if _, _, ignore := tracker.ignore(req); !ignore {
+	if _, _, ignore := tracker.ignore(req, 0); !ignore {
 		t.Fatal("Session with X-Purpose header must be ignored")
 	}
 
 	req.Header.Set("X-Purpose", "preview")
 
-	if _, _, ignore := tracker.ignore(req); !ignore {
+	if _, _, ignore := tracker.ignore(req, 0); !ignore {
 		t.Fatal("Session with X-Purpose header must be ignored")
 	}
 
 	req.Header.Del("X-Purpose")
 	req.Header.Set("Purpose", "prefetch")
 
-	if _, _, ignore := tracker.ignore(req); !ignore {
+	if _, _, ignore := tracker.ignore(req, 0); !ignore {
 		t.Fatal("Session with Purpose header must be ignored")
 	}
 
 	req.Header.Set("Purpose", "preview")
 
-	if _, _, ignore := tracker.ignore(req); !ignore {
+	if _, _, ignore := tracker.ignore(req, 0); !ignore {
 		t.Fatal("Session with Purpose header must be ignored")
 	}
 
 	req.Header.Del("Purpose")
 
-	if _, _, ignore := tracker.ignore(req); ignore {
+	if _, _, ignore := tracker.ignore(req, 0); ignore {
 		t.Fatal("Session must not be ignored")
 	}
 }
@@ -782,7 +1010,7 @@ func TestTracker_ignoreUserAgent(t *testing.T) {
 	for _, userAgent := range userAgents {
 		req.Header.Set("User-Agent", userAgent.userAgent)
 
-		if _, _, ignore := tracker.ignore(req); ignore != userAgent.ignore {
+		if _, _, ignore := tracker.ignore(req, 0); ignore != userAgent.ignore {
 			if userAgent.ignore {
 				t.Fatalf("Request with User-Agent '%s' must be ignored", userAgent.userAgent)
 			} else {
@@ -799,7 +1027,7 @@ func TestTracker_ignoreBotUserAgent(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/", nil)
 		req.Header.Set("User-Agent", botUserAgent)
 
-		if _, _, ignore := tracker.ignore(req); !ignore {
+		if _, _, ignore := tracker.ignore(req, 0); !ignore {
 			t.Fatalf("Request with user agent '%v' must have been ignored", botUserAgent)
 		}
 	}
@@ -815,7 +1043,7 @@ func TestTracker_ignoreBotUserAgent(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/", nil)
 		req.Header.Set("User-Agent", userAgent)
 
-		if _, _, ignore := tracker.ignore(req); !ignore {
+		if _, _, ignore := tracker.ignore(req, 0); !ignore {
 			t.Fatalf("Request with user agent '%v' must have been ignored", botUserAgent)
 		}
 	}
@@ -827,19 +1055,19 @@ func TestTracker_ignoreReferrer(t *testing.T) {
 	req.Header.Set("User-Agent", "ua")
 	req.Header.Set("Referer", "2your.site")
 
-	if _, _, ignore := tracker.ignore(req); !ignore {
+	if _, _, ignore := tracker.ignore(req, 0); !ignore {
 		t.Fatal("Request must have been ignored")
 	}
 
 	req.Header.Set("Referer", "subdomain.2your.site")
 
-	if _, _, ignore := tracker.ignore(req); !ignore {
+	if _, _, ignore := tracker.ignore(req, 0); !ignore {
 		t.Fatal("Request for subdomain must have been ignored")
 	}
 
 	req = httptest.NewRequest(http.MethodGet, "/?ref=2your.site", nil)
 
-	if _, _, ignore := tracker.ignore(req); !ignore {
+	if _, _, ignore := tracker.ignore(req, 0); !ignore {
 		t.Fatal("Request must have been ignored")
 	}
 }
@@ -849,14 +1077,28 @@ func TestTracker_ignoreBrowserVersion(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/", nil)
 	req.Header.Set("User-Agent", "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/61.0.4147.135 Safari/537.36")
 
-	if _, _, ignore := tracker.ignore(req); !ignore {
+	if _, _, ignore := tracker.ignore(req, 0); !ignore {
+		t.Fatal("Request must have been ignored")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Linux; Android 7.0; SAMSUNG SM-G930F) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/7.2 Chrome/59.0.3071.125 Mobile Safari/537.36")
+
+	if _, _, ignore := tracker.ignore(req, 0); !ignore {
 		t.Fatal("Request must have been ignored")
 	}
 
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Linux; Android 13; SAMSUNG SM-S918B) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/23.0 Chrome/115.0.0.0 Mobile Safari/537.36")
+
+	if _, _, ignore := tracker.ignore(req, 0); ignore {
+		t.Fatal("Request must not have been ignored")
+	}
+
 	req = httptest.NewRequest(http.MethodGet, "/", nil)
 	req.Header.Set("User-Agent", userAgent)
 
-	if _, _, ignore := tracker.ignore(req); ignore {
+	if _, _, ignore := tracker.ignore(req, 0); ignore {
 		t.Fatal("Request must not have been ignored")
 	}
 }
@@ -866,13 +1108,13 @@ func TestTracker_ignoreDoNotTrack(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/", nil)
 	req.Header.Set("User-Agent", userAgent)
 
-	if _, _, ignore := tracker.ignore(req); ignore {
+	if _, _, ignore := tracker.ignore(req, 0); ignore {
 		t.Fatal("Request must not have been ignored")
 	}
 
 	req.Header.Set("DNT", "1")
 
-	if _, _, ignore := tracker.ignore(req); !ignore {
+	if _, _, ignore := tracker.ignore(req, 0); !ignore {
 		t.Fatal("Request must have been ignored")
 	}
 }
@@ -886,15 +1128,40 @@ func TestTracker_ignoreIP(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/", nil)
 	req.Header.Set("User-Agent", userAgent)
 
-	if _, _, ignore := tracker.ignore(req); ignore {
+	if _, _, ignore := tracker.ignore(req, 0); ignore {
 		t.Fatal("Request must not have been ignored")
 	}
 
 	req.RemoteAddr = "90.154.29.38"
 
-	if _, _, ignore := tracker.ignore(req); !ignore {
+	if _, _, ignore := tracker.ignore(req, 0); !ignore {
+		t.Fatal("Request must have been ignored")
+	}
+}
+
+func TestTracker_ignoreIPTrustedProxies(t *testing.T) {
+	filter := ip.NewCIDRFilter()
+	filter.Update([]string{"90.154.29.38"}, nil, nil, nil)
+	_, subnet, _ := net.ParseCIDR("10.0.0.0/8")
+	tracker := NewTracker(Config{
+		IPFilter:       filter,
+		HeaderParser:   ip.DefaultHeaderParser,
+		TrustedProxies: &ip.TrustedProxies{Subnets: []net.IPNet{*subnet}},
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("X-Forwarded-For", "1.2.3.4, 90.154.29.38, 10.0.0.2")
+	req.RemoteAddr = "10.0.0.1:12345"
+
+	if _, _, ignore := tracker.ignore(req, 0); !ignore {
 		t.Fatal("Request must have been ignored")
 	}
+
+	// the spoofed address is ignored
+	req.Header.Set("X-Forwarded-For", "90.154.29.38, 1.2.3.4, 10.0.0.2")
+	_, ipAddress, ignore := tracker.ignore(req, 0)
+	assert.False(t, ignore)
+	assert.Equal(t, "1.2.3.4", ipAddress)
 }
 
 func TestTracker_ignorePageViews(t *testing.T) {
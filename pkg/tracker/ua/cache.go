@@ -0,0 +1,101 @@
+package ua
+
+import (
+	"container/list"
+	"github.com/pirsch-analytics/pirsch/v6/pkg/model"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultCacheSize = 10_000
+
+// clientHintHeaders are all headers read by Parse besides the User-Agent.
+var clientHintHeaders = []string{
+	"Sec-CH-UA",
+	"Sec-CH-UA-Full-Version-List",
+	"Sec-CH-UA-Mobile",
+	"Sec-CH-UA-Model",
+	"Sec-CH-UA-Platform",
+	"Sec-CH-UA-Platform-Version",
+	"Sec-CH-UA-Form-Factors",
+}
+
+// Cache is a least recently used cache for Parse results.
+// Entries are keyed by the User-Agent and client hint headers.
+type Cache struct {
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+	m       sync.Mutex
+}
+
+type cacheEntry struct {
+	key       string
+	userAgent model.UserAgent
+}
+
+// NewCache creates a new Cache for up to size results.
+// The size defaults to 10,000 if it is less than 1.
+func NewCache(size int) *Cache {
+	if size < 1 {
+		size = defaultCacheSize
+	}
+
+	return &Cache{
+		size:    size,
+		entries: make(map[string]*list.Element, size),
+		order:   list.New(),
+	}
+}
+
+// Parse returns the cached result for given request or calls Parse and caches the result.
+func (cache *Cache) Parse(r *http.Request) model.UserAgent {
+	key := cacheKey(r)
+	cache.m.Lock()
+
+	if element, found := cache.entries[key]; found {
+		cache.order.MoveToFront(element)
+		userAgent := element.Value.(*cacheEntry).userAgent
+		cache.m.Unlock()
+		userAgent.Time = time.Now().UTC()
+		return userAgent
+	}
+
+	cache.m.Unlock()
+	userAgent := Parse(r)
+	cache.m.Lock()
+	defer cache.m.Unlock()
+
+	if _, found := cache.entries[key]; !found {
+		cache.entries[key] = cache.order.PushFront(&cacheEntry{key: key, userAgent: userAgent})
+
+		if cache.order.Len() > cache.size {
+			oldest := cache.order.Back()
+			cache.order.Remove(oldest)
+			delete(cache.entries, oldest.Value.(*cacheEntry).key)
+		}
+	}
+
+	return userAgent
+}
+
+// Len returns the number of cached results.
+func (cache *Cache) Len() int {
+	cache.m.Lock()
+	defer cache.m.Unlock()
+	return cache.order.Len()
+}
+
+func cacheKey(r *http.Request) string {
+	var sb strings.Builder
+	sb.WriteString(r.UserAgent())
+
+	for _, header := range clientHintHeaders {
+		sb.WriteByte(0)
+		sb.WriteString(r.Header.Get(header))
+	}
+
+	return sb.String()
+}
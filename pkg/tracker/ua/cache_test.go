@@ -0,0 +1,65 @@
+package ua
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	cache := NewCache(2)
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("User-Agent", userAgentsChrome[0].ua)
+	userAgent := cache.Parse(req)
+	assert.Equal(t, Parse(req).Browser, userAgent.Browser)
+	assert.Equal(t, 1, cache.Len())
+	time.Sleep(time.Millisecond * 2)
+	cached := cache.Parse(req)
+	assert.Equal(t, userAgent.Browser, cached.Browser)
+	assert.True(t, cached.Time.After(userAgent.Time))
+	assert.Equal(t, 1, cache.Len())
+
+	// client hints must be part of the key
+	req.Header.Set("Sec-CH-UA", `"Brave";v="119", "Chromium";v="119", "Not?A_Brand";v="24"`)
+	assert.Equal(t, "Brave", cache.Parse(req).Browser)
+	assert.Equal(t, 2, cache.Len())
+
+	// the least recently used entry is evicted
+	req.Header.Del("Sec-CH-UA")
+	cache.Parse(req)
+	req.Header.Set("User-Agent", userAgentsFirefox[0].ua)
+	cache.Parse(req)
+	assert.Equal(t, 2, cache.Len())
+	req.Header.Set("User-Agent", userAgentsChrome[0].ua)
+	_, found := cache.entries[cacheKey(req)]
+	assert.True(t, found)
+	req.Header.Set("Sec-CH-UA", `"Brave";v="119", "Chromium";v="119", "Not?A_Brand";v="24"`)
+	_, found = cache.entries[cacheKey(req)]
+	assert.False(t, found)
+}
+
+func BenchmarkParse(b *testing.B) {
+	requests := make([]*http.Request, 0, len(userAgentsAll))
+
+	for _, userAgent := range userAgentsAll {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("User-Agent", userAgent.ua)
+		requests = append(requests, req)
+	}
+
+	b.Run("Parse", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			Parse(requests[n%len(requests)])
+		}
+	})
+
+	b.Run("Cache", func(b *testing.B) {
+		cache := NewCache(0)
+
+		for n := 0; n < b.N; n++ {
+			cache.Parse(requests[n%len(requests)])
+		}
+	})
+}
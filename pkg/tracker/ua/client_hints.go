@@ -0,0 +1,33 @@
+package ua
+
+import (
+	"net/http"
+	"strings"
+)
+
+var (
+	// acceptClientHints are the high entropy client hints requested from the browser.
+	// Low entropy hints (Sec-CH-UA, Sec-CH-UA-Mobile, and Sec-CH-UA-Platform) are always sent.
+	acceptClientHints = []string{
+		"Sec-CH-UA-Platform-Version",
+		"Sec-CH-UA-Full-Version-List",
+		"Sec-CH-UA-Model",
+		"Sec-CH-UA-Form-Factors",
+	}
+
+	// criticalClientHints are the hints the browser should retry a navigation request for if they were missing.
+	criticalClientHints = []string{
+		"Sec-CH-UA-Platform-Version",
+		"Sec-CH-UA-Full-Version-List",
+	}
+)
+
+// SetClientHintHeaders sets the Accept-CH and Critical-CH headers on given response,
+// asking the browser to send the client hints used by Parse for subsequent requests.
+// This should be called for the tracking endpoint, as client hints are only sent to the origin that requested them.
+func SetClientHintHeaders(w http.ResponseWriter) {
+	header := w.Header()
+	header.Set("Accept-CH", strings.Join(acceptClientHints, ", "))
+	header.Set("Critical-CH", strings.Join(criticalClientHints, ", "))
+	header.Add("Vary", strings.Join(criticalClientHints, ", "))
+}
@@ -0,0 +1,25 @@
+package ua
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSetClientHintHeaders(t *testing.T) {
+	w := httptest.NewRecorder()
+	SetClientHintHeaders(w)
+	acceptCH := w.Header().Get("Accept-CH")
+	criticalCH := w.Header().Get("Critical-CH")
+	assert.Contains(t, acceptCH, "Sec-CH-UA-Platform-Version")
+	assert.Contains(t, acceptCH, "Sec-CH-UA-Full-Version-List")
+	assert.Contains(t, acceptCH, "Sec-CH-UA-Model")
+
+	// critical hints must be requested as well
+	for _, header := range strings.Split(criticalCH, ", ") {
+		assert.Contains(t, acceptCH, header)
+	}
+
+	assert.Equal(t, criticalCH, w.Header().Get("Vary"))
+}
@@ -0,0 +1,170 @@
+package ua
+
+import (
+	"github.com/emvi/null"
+	"github.com/pirsch-analytics/pirsch/v6/pkg"
+	"net/http"
+	"strings"
+)
+
+type deviceTypeKeyword struct {
+	keyword    string
+	deviceType string
+}
+
+type knownDevice struct {
+	keyword string
+	vendor  string
+	model   string
+}
+
+type deviceVendorPrefix struct {
+	prefix string
+	vendor string
+}
+
+// getDevice returns the device type, vendor, and model for given request.
+// The model is read from the Sec-CH-UA-Model client hint if present, or else extracted from the User-Agent.
+func getDevice(r *http.Request, os string, mobile null.Bool) (string, string, string) {
+	ua := r.UserAgent()
+	lower := strings.ToLower(ua)
+	system := parseSystem(ua, strings.IndexRune(ua, uaSystemLeftDelimiter), strings.IndexRune(ua, uaSystemRightDelimiter))
+	deviceType := getDeviceType(r, lower, os, mobile)
+	vendor, model := getDeviceVendorModel(lower, system, os)
+
+	if chModel := strings.Trim(r.Header.Get("Sec-CH-UA-Model"), `"' `); chModel != "" {
+		model = chModel
+		vendor = ""
+	}
+
+	if vendor == "" {
+		vendor = getDeviceVendor(model)
+	}
+
+	return deviceType, vendor, model
+}
+
+func getDeviceType(r *http.Request, ua, os string, mobile null.Bool) string {
+	for _, keyword := range deviceTypeKeywords {
+		if strings.Contains(ua, keyword.keyword) {
+			return keyword.deviceType
+		}
+	}
+
+	for _, formFactor := range strings.Split(r.Header.Get("Sec-CH-UA-Form-Factors"), ",") {
+		if deviceType, found := formFactorMapping[strings.ToLower(strings.Trim(formFactor, `"' `))]; found {
+			return deviceType
+		}
+	}
+
+	switch os {
+	case pkg.OSAndroid:
+		// Android tablets do not send the "Mobile" token or client hint
+		if mobile.Valid {
+			if mobile.Bool {
+				return pkg.DeviceTypeMobile
+			}
+
+			return pkg.DeviceTypeTablet
+		}
+
+		if strings.Contains(ua, "mobile") {
+			return pkg.DeviceTypeMobile
+		}
+
+		return pkg.DeviceTypeTablet
+	case pkg.OSiOS, pkg.OSWindowsMobile:
+		return pkg.DeviceTypeMobile
+	case pkg.OSWindows, pkg.OSMac, pkg.OSLinux, pkg.OSChrome:
+		if mobile.Valid && mobile.Bool {
+			return pkg.DeviceTypeMobile
+		}
+
+		return pkg.DeviceTypeDesktop
+	}
+
+	if mobile.Valid {
+		if mobile.Bool {
+			return pkg.DeviceTypeMobile
+		}
+
+		return pkg.DeviceTypeDesktop
+	}
+
+	return ""
+}
+
+func getDeviceVendorModel(ua string, system []string, os string) (string, string) {
+	for _, device := range knownDevices {
+		if strings.Contains(ua, device.keyword) {
+			return device.vendor, device.model
+		}
+	}
+
+	if os == pkg.OSMac {
+		return "Apple", "Mac"
+	}
+
+	if os == pkg.OSAndroid {
+		return "", getAndroidModel(system)
+	}
+
+	return "", ""
+}
+
+// getAndroidModel returns the model from the system part of an Android User-Agent.
+// Examples: "Linux; Android 13; SM-S901B Build/TP1A.220624.014", "Linux; U; Android 4.0.3; ko-kr; LG-L160L Build/IML74K".
+func getAndroidModel(system []string) string {
+	android := false
+
+	for _, sys := range system {
+		if strings.HasPrefix(sys, "Android") {
+			android = true
+			continue
+		}
+
+		if !android {
+			continue
+		}
+
+		model, _, _ := strings.Cut(sys, " Build/")
+		model = strings.TrimSpace(model)
+
+		if model != "" && !ignoreAndroidModel(model) {
+			return model
+		}
+	}
+
+	return ""
+}
+
+// ignoreAndroidModel returns true for system parts that are not a model, like the locale, "wv" for WebViews,
+// or the placeholder "K" sent by browsers that reduce the User-Agent.
+func ignoreAndroidModel(model string) bool {
+	if model == "K" || model == "U" || model == "wv" || strings.HasPrefix(model, "rv:") || strings.Contains(model, "Mobile") {
+		return true
+	}
+
+	// locales like "en", "en-us", or "en_US"
+	if len(model) == 2 || len(model) == 5 && (model[2] == '-' || model[2] == '_') {
+		return strings.ToLower(model[:2]) == model[:2]
+	}
+
+	return false
+}
+
+func getDeviceVendor(model string) string {
+	if model == "" {
+		return ""
+	}
+
+	lower := strings.ToLower(model)
+
+	for _, vendor := range deviceVendorPrefixes {
+		if strings.HasPrefix(lower, vendor.prefix) {
+			return vendor.vendor
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,92 @@
+package ua
+
+import (
+	"github.com/pirsch-analytics/pirsch/v6/pkg"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetDevice(t *testing.T) {
+	input := []string{
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/119.0.0.0 Safari/537.36",
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Safari/605.1.15",
+		"Mozilla/5.0 (X11; Linux x86_64; rv:109.0) Gecko/20100101 Firefox/119.0",
+		"Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1",
+		"Mozilla/5.0 (iPad; CPU OS 16_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.6 Mobile/15E148 Safari/604.1",
+		"Mozilla/5.0 (Linux; Android 13; SM-S901B Build/TP1A.220624.014) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/112.0.0.0 Mobile Safari/537.36",
+		"Mozilla/5.0 (Linux; Android 13; Pixel 7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/116.0.0.0 Mobile Safari/537.36",
+		"Mozilla/5.0 (Linux; Android 12; SM-X906C Build/QP1A.190711.020; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/80.0.3987.119 Safari/537.36",
+		"Mozilla/5.0 (Linux; U; Android 4.0.3; ko-kr; LG-L160L Build/IML74K) AppleWebkit/534.30 (KHTML, like Gecko) Version/4.0 Mobile Safari/534.30",
+		"Mozilla/5.0 (Linux; Android 10; K) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/116.0.0.0 Mobile Safari/537.36",
+		"Mozilla/5.0 (Linux; Android 9; AFTKA Build/PS7285.3204N) AppleWebKit/537.36 (KHTML, like Gecko) Silk/114.3.1 like Chrome/114.0.5735.196 Safari/537.36",
+		"Mozilla/5.0 (Linux; Android 11; KFTRWI) AppleWebKit/537.36 (KHTML, like Gecko) Silk/118.4.3 like Chrome/118.0.5993.144 Safari/537.36",
+		"Mozilla/5.0 (SMART-TV; LINUX; Tizen 6.0) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/4.0 Chrome/76.0.3809.146 TV Safari/537.36",
+		"Mozilla/5.0 (Web0S; Linux/SmartTV) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/79.0.3945.79 Safari/537.36 WebAppManager",
+		"Mozilla/5.0 (X11; Linux armv7l) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/90.0.4430.225 Safari/537.36 CrKey/1.56.500000",
+		"Mozilla/5.0 (PlayStation 5 3.21) AppleWebKit/605.1.15 (KHTML, like Gecko)",
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64; Xbox; Xbox One) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/70.0.3538.102 Safari/537.36 Edge/18.19041",
+		"Mozilla/5.0 (Nintendo Switch; WifiWebAuthApplet) AppleWebKit/606.4 (KHTML, like Gecko) NF/6.0.1.15.4 NintendoBrowser/5.1.0.20393",
+		"Mozilla/5.0 (Linux; Android 11; Pixel Watch) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/108.0.0.0 Mobile Safari/537.36",
+		"Mozilla/5.0 (compatible; Unknown/1.0)",
+	}
+	expected := [][]string{
+		{pkg.DeviceTypeDesktop, "", ""},
+		{pkg.DeviceTypeDesktop, "Apple", "Mac"},
+		{pkg.DeviceTypeDesktop, "", ""},
+		{pkg.DeviceTypeMobile, "Apple", "iPhone"},
+		{pkg.DeviceTypeTablet, "Apple", "iPad"},
+		{pkg.DeviceTypeMobile, "Samsung", "SM-S901B"},
+		{pkg.DeviceTypeMobile, "Google", "Pixel 7"},
+		{pkg.DeviceTypeTablet, "Samsung", "SM-X906C"},
+		{pkg.DeviceTypeMobile, "LG", "LG-L160L"},
+		{pkg.DeviceTypeMobile, "", ""},
+		{pkg.DeviceTypeTV, "Amazon", "AFTKA"},
+		{pkg.DeviceTypeTablet, "Amazon", "KFTRWI"},
+		{pkg.DeviceTypeTV, "Samsung", "Smart TV"},
+		{pkg.DeviceTypeTV, "LG", ""},
+		{pkg.DeviceTypeTV, "Google", "Chromecast"},
+		{pkg.DeviceTypeConsole, "Sony", "PlayStation 5"},
+		{pkg.DeviceTypeConsole, "Microsoft", "Xbox One"},
+		{pkg.DeviceTypeConsole, "Nintendo", "Switch"},
+		{pkg.DeviceTypeWearable, "Google", "Pixel Watch"},
+		{"", "", ""},
+	}
+
+	for i, ua := range input {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("User-Agent", ua)
+		userAgent := Parse(req)
+		assert.Equal(t, expected[i][0], userAgent.DeviceType, ua)
+		assert.Equal(t, expected[i][1], userAgent.DeviceVendor, ua)
+		assert.Equal(t, expected[i][2], userAgent.DeviceModel, ua)
+	}
+}
+
+func TestGetDeviceClientHints(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Linux; Android 10; K) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/116.0.0.0 Safari/537.36")
+	req.Header.Set("Sec-CH-UA-Platform", `"Android"`)
+	req.Header.Set("Sec-CH-UA-Mobile", "?0")
+	req.Header.Set("Sec-CH-UA-Model", `"SM-X906C"`)
+	userAgent := Parse(req)
+	assert.Equal(t, pkg.DeviceTypeTablet, userAgent.DeviceType)
+	assert.Equal(t, "Samsung", userAgent.DeviceVendor)
+	assert.Equal(t, "SM-X906C", userAgent.DeviceModel)
+	assert.False(t, userAgent.IsDesktop())
+	assert.True(t, userAgent.IsMobile())
+	req.Header.Set("Sec-CH-UA-Mobile", "?1")
+	req.Header.Set("Sec-CH-UA-Model", `"Pixel 8"`)
+	userAgent = Parse(req)
+	assert.Equal(t, pkg.DeviceTypeMobile, userAgent.DeviceType)
+	assert.Equal(t, "Google", userAgent.DeviceVendor)
+	assert.Equal(t, "Pixel 8", userAgent.DeviceModel)
+	req.Header.Set("Sec-CH-UA-Platform", `"Windows"`)
+	req.Header.Set("Sec-CH-UA-Mobile", "?0")
+	req.Header.Set("Sec-CH-UA-Model", `""`)
+	req.Header.Set("Sec-CH-UA-Form-Factors", `"Tablet"`)
+	userAgent = Parse(req)
+	assert.Equal(t, pkg.DeviceTypeTablet, userAgent.DeviceType)
+	assert.Empty(t, userAgent.DeviceModel)
+}
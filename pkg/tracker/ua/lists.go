@@ -113,4 +113,115 @@ var (
 		"macOS":       pkg.OSMac,
 		"Windows":     pkg.OSWindows,
 	}
+
+	// deviceTypeKeywords maps lowercase User-Agent keywords to a device type.
+	// The list is ordered, so that more specific devices are matched first (a TV running Android is not a tablet).
+	deviceTypeKeywords = []deviceTypeKeyword{
+		{"smart-tv", pkg.DeviceTypeTV},
+		{"smarttv", pkg.DeviceTypeTV},
+		{"googletv", pkg.DeviceTypeTV},
+		{"google tv", pkg.DeviceTypeTV},
+		{"android tv", pkg.DeviceTypeTV},
+		{"appletv", pkg.DeviceTypeTV},
+		{"apple tv", pkg.DeviceTypeTV},
+		{"hbbtv", pkg.DeviceTypeTV},
+		{"crkey", pkg.DeviceTypeTV},
+		{"web0s", pkg.DeviceTypeTV},
+		{"netcast", pkg.DeviceTypeTV},
+		{"bravia", pkg.DeviceTypeTV},
+		{"roku", pkg.DeviceTypeTV},
+		{"; aft", pkg.DeviceTypeTV}, // Amazon Fire TV
+		{"playstation", pkg.DeviceTypeConsole},
+		{"xbox", pkg.DeviceTypeConsole},
+		{"nintendo", pkg.DeviceTypeConsole},
+		{" watch", pkg.DeviceTypeWearable},
+		{"wearos", pkg.DeviceTypeWearable},
+		{"ipad", pkg.DeviceTypeTablet},
+		{"tablet", pkg.DeviceTypeTablet},
+		{"kindle", pkg.DeviceTypeTablet},
+		{"silk/", pkg.DeviceTypeTablet},
+		{"playbook", pkg.DeviceTypeTablet},
+		{"; kf", pkg.DeviceTypeTablet}, // Amazon Kindle Fire
+		{"iphone", pkg.DeviceTypeMobile},
+		{"ipod", pkg.DeviceTypeMobile},
+		{"windows phone", pkg.DeviceTypeMobile},
+	}
+
+	// formFactorMapping maps the Sec-CH-UA-Form-Factors client hint to a device type.
+	formFactorMapping = map[string]string{
+		"desktop": pkg.DeviceTypeDesktop,
+		"mobile":  pkg.DeviceTypeMobile,
+		"tablet":  pkg.DeviceTypeTablet,
+		"watch":   pkg.DeviceTypeWearable,
+	}
+
+	// knownDevices maps lowercase User-Agent keywords to the vendor and model of devices that can be identified by name.
+	knownDevices = []knownDevice{
+		{"iphone", "Apple", "iPhone"},
+		{"ipad", "Apple", "iPad"},
+		{"ipod", "Apple", "iPod"},
+		{"appletv", "Apple", "Apple TV"},
+		{"apple tv", "Apple", "Apple TV"},
+		{"playstation 5", "Sony", "PlayStation 5"},
+		{"playstation 4", "Sony", "PlayStation 4"},
+		{"playstation vita", "Sony", "PlayStation Vita"},
+		{"playstation", "Sony", "PlayStation"},
+		{"xbox series x", "Microsoft", "Xbox Series X"},
+		{"xbox series s", "Microsoft", "Xbox Series S"},
+		{"xbox one", "Microsoft", "Xbox One"},
+		{"xbox", "Microsoft", "Xbox"},
+		{"nintendo switch", "Nintendo", "Switch"},
+		{"nintendo wiiu", "Nintendo", "Wii U"},
+		{"nintendo 3ds", "Nintendo", "3DS"},
+		{"nintendo", "Nintendo", ""},
+		{"crkey", "Google", "Chromecast"},
+		{"roku", "Roku", ""},
+		{"bravia", "Sony", "Bravia"},
+		{"web0s", "LG", ""},
+		{"netcast", "LG", ""},
+		{"smart-tv; linux; tizen", "Samsung", "Smart TV"},
+	}
+
+	// deviceVendorPrefixes maps lowercase model prefixes to the vendor.
+	deviceVendorPrefixes = []deviceVendorPrefix{
+		{"sm-", "Samsung"},
+		{"gt-", "Samsung"},
+		{"samsung", "Samsung"},
+		{"galaxy", "Samsung"},
+		{"pixel", "Google"},
+		{"nexus", "Google"},
+		{"redmi", "Xiaomi"},
+		{"poco", "Xiaomi"},
+		{"xiaomi", "Xiaomi"},
+		{"mi ", "Xiaomi"},
+		{"m2", "Xiaomi"},
+		{"oneplus", "OnePlus"},
+		{"cph", "OPPO"},
+		{"oppo", "OPPO"},
+		{"rmx", "realme"},
+		{"moto", "Motorola"},
+		{"xt1", "Motorola"},
+		{"xt2", "Motorola"},
+		{"lm-", "LG"},
+		{"lg-", "LG"},
+		{"huawei", "Huawei"},
+		{"ane-", "Huawei"},
+		{"ele-", "Huawei"},
+		{"vog-", "Huawei"},
+		{"mar-", "Huawei"},
+		{"honor", "Honor"},
+		{"xperia", "Sony"},
+		{"so-", "Sony"},
+		{"nokia", "Nokia"},
+		{"ta-", "Nokia"},
+		{"vivo", "vivo"},
+		{"aft", "Amazon"},
+		{"kf", "Amazon"},
+		{"lenovo", "Lenovo"},
+		{"asus", "ASUS"},
+		{"infinix", "Infinix"},
+		{"tecno", "TECNO"},
+		{"iphone", "Apple"},
+		{"ipad", "Apple"},
+	}
 )
@@ -0,0 +1,153 @@
+package ua
+
+// Matcher checks a string for multiple substrings at once.
+// It's an Aho-Corasick automaton compiled into a deterministic state machine,
+// so that a lookup takes a single pass over the input, regardless of the number of patterns.
+type Matcher struct {
+	patterns []string
+
+	// classes maps each byte to its column in the transition table.
+	// All bytes not used in any pattern share class 0.
+	classes [256]uint16
+	width   int
+
+	// transitions is the state transition table with one row of width entries per state.
+	transitions []int32
+
+	// match is true for all states that end a pattern, either directly or through a suffix.
+	match []bool
+
+	// output is the index of the pattern ending in a state or -1.
+	output []int32
+
+	// suffix links a state to the next state ending a pattern by following the failure links, or -1.
+	suffix []int32
+}
+
+// NewMatcher compiles a Matcher for given patterns.
+// Empty patterns are ignored.
+func NewMatcher(patterns []string) *Matcher {
+	m := &Matcher{
+		patterns: patterns,
+		width:    1,
+	}
+
+	for _, pattern := range patterns {
+		for i := 0; i < len(pattern); i++ {
+			if m.classes[pattern[i]] == 0 {
+				m.classes[pattern[i]] = uint16(m.width)
+				m.width++
+			}
+		}
+	}
+
+	// build the trie, -1 marks a missing edge
+	m.addState()
+
+	for p, pattern := range patterns {
+		if pattern == "" {
+			continue
+		}
+
+		state := int32(0)
+
+		for i := 0; i < len(pattern); i++ {
+			edge := int(state)*m.width + int(m.classes[pattern[i]])
+
+			if m.transitions[edge] < 0 {
+				m.transitions[edge] = m.addState()
+			}
+
+			state = m.transitions[edge]
+		}
+
+		m.match[state] = true
+		m.output[state] = int32(p)
+	}
+
+	// turn the trie into a state machine by following the failure links breadth-first
+	fail := make([]int32, len(m.match))
+	queue := make([]int32, 0, len(m.match))
+
+	for c := 0; c < m.width; c++ {
+		if next := m.transitions[c]; next < 0 {
+			m.transitions[c] = 0
+		} else {
+			queue = append(queue, next)
+		}
+	}
+
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		f := fail[state]
+		m.match[state] = m.match[state] || m.match[f]
+
+		if m.output[f] >= 0 {
+			m.suffix[state] = f
+		} else {
+			m.suffix[state] = m.suffix[f]
+		}
+
+		row := int(state) * m.width
+		failRow := int(f) * m.width
+
+		for c := 0; c < m.width; c++ {
+			if next := m.transitions[row+c]; next < 0 {
+				m.transitions[row+c] = m.transitions[failRow+c]
+			} else {
+				fail[next] = m.transitions[failRow+c]
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return m
+}
+
+// Contains returns true if given string contains any of the patterns.
+func (m *Matcher) Contains(s string) bool {
+	state := int32(0)
+
+	for i := 0; i < len(s); i++ {
+		state = m.transitions[int(state)*m.width+int(m.classes[s[i]])]
+
+		if m.match[state] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ContainsFunc returns true if given string contains any of the patterns for which accept returns true.
+func (m *Matcher) ContainsFunc(s string, accept func(pattern string) bool) bool {
+	state := int32(0)
+
+	for i := 0; i < len(s); i++ {
+		state = m.transitions[int(state)*m.width+int(m.classes[s[i]])]
+
+		if m.match[state] {
+			for match := state; match >= 0; match = m.suffix[match] {
+				if p := m.output[match]; p >= 0 && accept(m.patterns[p]) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func (m *Matcher) addState() int32 {
+	state := int32(len(m.match))
+	m.match = append(m.match, false)
+	m.output = append(m.output, -1)
+	m.suffix = append(m.suffix, -1)
+
+	for i := 0; i < m.width; i++ {
+		m.transitions = append(m.transitions, -1)
+	}
+
+	return state
+}
@@ -0,0 +1,100 @@
+package ua
+
+import (
+	"github.com/pirsch-analytics/pirsch/v6/pkg/tracker/lists"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestMatcher(t *testing.T) {
+	m := NewMatcher([]string{"he", "she", "his", "hers", ""})
+	assert.True(t, m.Contains("ushers"))
+	assert.True(t, m.Contains("she"))
+	assert.True(t, m.Contains("ahisb"))
+	assert.True(t, m.Contains("xhe"))
+	assert.False(t, m.Contains("hi"))
+	assert.False(t, m.Contains("ers"))
+	assert.False(t, m.Contains(""))
+	assert.False(t, NewMatcher(nil).Contains("anything"))
+	m = NewMatcher([]string{"abcd", "bc"})
+	assert.True(t, m.Contains("xabcx"))
+	assert.False(t, m.Contains("abx"))
+}
+
+func TestMatcherContainsFunc(t *testing.T) {
+	m := NewMatcher([]string{"he", "she", "hers"})
+	assert.True(t, m.ContainsFunc("ushers", func(string) bool { return true }))
+	assert.True(t, m.ContainsFunc("ushers", func(pattern string) bool { return pattern == "hers" }))
+	assert.True(t, m.ContainsFunc("ushers", func(pattern string) bool { return pattern == "he" }))
+	assert.False(t, m.ContainsFunc("ushe", func(pattern string) bool { return pattern == "hers" }))
+	assert.False(t, m.ContainsFunc("ushers", func(string) bool { return false }))
+}
+
+func TestIsBlacklisted(t *testing.T) {
+	for _, botUserAgent := range Blacklist {
+		assert.True(t, IsBlacklisted(botUserAgent), botUserAgent)
+		assert.True(t, IsBlacklisted("prefix "+botUserAgent+" suffix"), botUserAgent)
+	}
+
+	for _, userAgent := range userAgentsAll {
+		lower := strings.ToLower(userAgent.ua)
+		assert.Equal(t, blacklistContains(lower), IsBlacklisted(lower), userAgent.ua)
+	}
+}
+
+func TestIsBlacklistedForClient(t *testing.T) {
+	Bots.SetClient(42, lists.Entries{"examplewatcher": ""}, []string{"bot"})
+	defer Bots.RemoveClient(42)
+	assert.True(t, IsBlacklisted("some bot"))
+	assert.False(t, IsBlacklistedForClient(42, "some bot"))
+	assert.True(t, IsBlacklistedForClient(42, "some bot with googlebot"))
+	assert.False(t, IsBlacklisted("examplewatcher/1.0"))
+	assert.True(t, IsBlacklistedForClient(42, "examplewatcher/1.0"))
+	assert.False(t, IsBlacklistedForClient(42, "mozilla/5.0"))
+	Bots.SetClient(42, nil, nil)
+	assert.False(t, IsBlacklistedForClient(42, "examplewatcher/1.0"))
+	assert.True(t, IsBlacklistedForClient(42, "some bot"))
+}
+
+func TestBotsLoad(t *testing.T) {
+	defer Bots.Reset()
+	assert.True(t, IsBlacklisted("some bot"))
+	assert.NoError(t, Bots.Load(strings.NewReader("# version: test\nexamplewatcher")))
+	assert.Equal(t, "test", Bots.Version())
+	assert.False(t, IsBlacklisted("some bot"))
+	assert.True(t, IsBlacklisted("examplewatcher/1.0"))
+	Bots.Reset()
+	assert.True(t, IsBlacklisted("some bot"))
+	assert.False(t, IsBlacklisted("examplewatcher/1.0"))
+}
+
+func BenchmarkBlacklist(b *testing.B) {
+	userAgents := make([]string, 0, len(userAgentsAll))
+
+	for _, userAgent := range userAgentsAll {
+		userAgents = append(userAgents, strings.ToLower(userAgent.ua))
+	}
+
+	b.Run("Contains", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			blacklistContains(userAgents[n%len(userAgents)])
+		}
+	})
+
+	b.Run("Matcher", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			IsBlacklisted(userAgents[n%len(userAgents)])
+		}
+	})
+}
+
+func blacklistContains(userAgent string) bool {
+	for _, botUserAgent := range Blacklist {
+		if strings.Contains(userAgent, botUserAgent) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -366,7 +366,7 @@ var userAgentsChrome = []testUserAgent{
 	},
 	{
 		ua:             "Mozilla/5.0 (Linux; Android 5.1.1; Nexus 5 Build/LMY48B; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/43.0.2357.65 Mobile Safari/537.36",
-		browser:        pkg.BrowserChrome,
+		browser:        pkg.BrowserAndroidWebView,
 		browserVersion: "43.0",
 		os:             pkg.OSAndroid,
 		osVersion:      "5.1",
@@ -564,12 +564,208 @@ var userAgentsIE = []testUserAgent{
 	},
 }
 
+var userAgentsSamsung = []testUserAgent{
+	{
+		ua:             "Mozilla/5.0 (Linux; Android 13; SAMSUNG SM-S918B) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/23.0 Chrome/115.0.0.0 Mobile Safari/537.36",
+		browser:        pkg.BrowserSamsung,
+		browserVersion: "23.0",
+		os:             pkg.OSAndroid,
+		osVersion:      "13",
+	},
+	{
+		ua:             "Mozilla/5.0 (Linux; Android 12; SAMSUNG SM-X700) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/21.0 Chrome/110.0.5481.154 Safari/537.36",
+		browser:        pkg.BrowserSamsung,
+		browserVersion: "21.0",
+		os:             pkg.OSAndroid,
+		osVersion:      "12",
+	},
+	{
+		ua:             "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/22.0 Chrome/111.0.5563.116 Safari/537.36",
+		browser:        pkg.BrowserSamsung,
+		browserVersion: "22.0",
+		os:             pkg.OSLinux,
+		osVersion:      "",
+	},
+}
+
+var userAgentsBrave = []testUserAgent{
+	{
+		ua:             "Mozilla/5.0 (Linux; Android 10; K) AppleWebKit/537.36 (KHTML, like Gecko) Brave Chrome/89.0.4389.105 Mobile Safari/537.36",
+		browser:        pkg.BrowserBrave,
+		browserVersion: "",
+		os:             pkg.OSAndroid,
+		osVersion:      "10",
+	},
+	{
+		ua:             "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/89.0.4389.114 Safari/537.36 Brave/89.1.22.71",
+		browser:        pkg.BrowserBrave,
+		browserVersion: "89.1",
+		os:             pkg.OSWindows,
+		osVersion:      "10",
+	},
+}
+
+var userAgentsVivaldi = []testUserAgent{
+	{
+		ua:             "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/118.0.0.0 Safari/537.36 Vivaldi/6.4.3160.34",
+		browser:        pkg.BrowserVivaldi,
+		browserVersion: "6.4",
+		os:             pkg.OSWindows,
+		osVersion:      "10",
+	},
+	{
+		ua:             "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/116.0.0.0 Safari/537.36 Vivaldi/6.2.3105.58",
+		browser:        pkg.BrowserVivaldi,
+		browserVersion: "6.2",
+		os:             pkg.OSLinux,
+		osVersion:      "",
+	},
+}
+
+var userAgentsYandex = []testUserAgent{
+	{
+		ua:             "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/116.0.0.0 YaBrowser/23.9.0.0 Safari/537.36",
+		browser:        pkg.BrowserYandex,
+		browserVersion: "23.9",
+		os:             pkg.OSWindows,
+		osVersion:      "10",
+	},
+	{
+		ua:             "Mozilla/5.0 (Linux; arm_64; Android 13; SM-A536B) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/116.0.5845.114 YaBrowser/23.9.1.93.00 SA/3 Mobile Safari/537.36",
+		browser:        pkg.BrowserYandex,
+		browserVersion: "23.9",
+		os:             pkg.OSAndroid,
+		osVersion:      "13",
+	},
+	{
+		ua:             "Mozilla/5.0 (iPhone; CPU iPhone OS 16_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.0 YaBrowser/23.7.6.385.10 SA/3 Mobile/15E148 Safari/604.1",
+		browser:        pkg.BrowserYandex,
+		browserVersion: "23.7",
+		os:             pkg.OSiOS,
+		osVersion:      "16.6",
+	},
+}
+
+var userAgentsUC = []testUserAgent{
+	{
+		ua:             "Mozilla/5.0 (Linux; U; Android 10; en-US; RMX1851 Build/QKQ1.190918.001) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/78.0.3904.108 UCBrowser/13.4.0.1306 Mobile Safari/537.36",
+		browser:        pkg.BrowserUC,
+		browserVersion: "13.4",
+		os:             pkg.OSAndroid,
+		osVersion:      "10",
+	},
+	{
+		ua:             "Mozilla/5.0 (iPhone; CPU iPhone OS 15_4 like Mac OS X; zh-CN) AppleWebKit/537.51.1 (KHTML, like Gecko) Mobile/19E241 UCBrowser/13.9.0.1865 Mobile AliApp(TUnionSDK/0.1.20.4)",
+		browser:        pkg.BrowserUC,
+		browserVersion: "13.9",
+		os:             pkg.OSiOS,
+		osVersion:      "15.4",
+	},
+}
+
+var userAgentsDuckDuckGo = []testUserAgent{
+	{
+		ua:             "Mozilla/5.0 (Linux; Android 13; Pixel 7) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/118.0.0.0 Mobile DuckDuckGo/5 Safari/537.36",
+		browser:        pkg.BrowserDuckDuckGo,
+		browserVersion: "5",
+		os:             pkg.OSAndroid,
+		osVersion:      "13",
+	},
+	{
+		ua:             "Mozilla/5.0 (iPhone; CPU iPhone OS 17_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Mobile/15E148 DuckDuckGo/7 Safari/605.1.15",
+		browser:        pkg.BrowserDuckDuckGo,
+		browserVersion: "7",
+		os:             pkg.OSiOS,
+		osVersion:      "17.1",
+	},
+	{
+		ua:             "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Safari/605.1.15 Ddg/17.1",
+		browser:        pkg.BrowserDuckDuckGo,
+		browserVersion: "17.1",
+		os:             pkg.OSMac,
+		osVersion:      "10.15",
+	},
+}
+
+var userAgentsInApp = []testUserAgent{
+	{
+		ua:             "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148 Instagram 305.0.0.34.110 (iPhone14,5; iOS 17_0; en_US; en; scale=3.00; 1170x2532; 526431520)",
+		browser:        pkg.BrowserInstagram,
+		browserVersion: "305.0",
+		os:             pkg.OSiOS,
+		osVersion:      "17.0",
+	},
+	{
+		ua:             "Mozilla/5.0 (Linux; Android 13; SM-G991B Build/TP1A.220624.014; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/118.0.5993.80 Mobile Safari/537.36 Instagram 307.0.0.34.111 Android (33/13; 480dpi; 1080x2176; samsung; SM-G991B; o1s; exynos2100; en_US; 532277146)",
+		browser:        pkg.BrowserInstagram,
+		browserVersion: "307.0",
+		os:             pkg.OSAndroid,
+		osVersion:      "13",
+	},
+	{
+		ua:             "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0_3 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148 [FBAN/FBIOS;FBDV/iPhone14,2;FBMD/iPhone;FBSN/iOS;FBSV/17.0.3;FBSS/3;FBID/phone;FBLC/en_US;FBOP/5;FBAV/437.0.0.35.117]",
+		browser:        pkg.BrowserFacebook,
+		browserVersion: "437.0",
+		os:             pkg.OSiOS,
+		osVersion:      "17.0",
+	},
+	{
+		ua:             "Mozilla/5.0 (Linux; Android 13; Pixel 6 Build/TQ3A.230901.001; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/117.0.5938.153 Mobile Safari/537.36 [FB_IAB/FB4A;FBAV/436.0.0.35.101;]",
+		browser:        pkg.BrowserFacebook,
+		browserVersion: "436.0",
+		os:             pkg.OSAndroid,
+		osVersion:      "13",
+	},
+	{
+		ua:             "Mozilla/5.0 (iPhone; CPU iPhone OS 16_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148 musical_ly_31.5.0 JsSdk/2.0 NetType/WIFI Channel/App Store ByteLocale/en Region/US",
+		browser:        pkg.BrowserTikTok,
+		browserVersion: "31.5",
+		os:             pkg.OSiOS,
+		osVersion:      "16.6",
+	},
+	{
+		ua:             "Mozilla/5.0 (Linux; Android 12; SM-A525F Build/SP1A.210812.016; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/117.0.5938.140 Mobile Safari/537.36 trill_2023105030 JsSdk/1.0 NetType/WIFI Channel/googleplay AppName/trill app_version/31.5.3 ByteLocale/en ByteFullLocale/en Region/DE BytedanceWebview/d8a21c6",
+		browser:        pkg.BrowserTikTok,
+		browserVersion: "",
+		os:             pkg.OSAndroid,
+		osVersion:      "12",
+	},
+	{
+		ua:             "Mozilla/5.0 (Linux; Android 13; SM-S908B Build/TP1A.220624.014; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/118.0.5993.111 Mobile Safari/537.36",
+		browser:        pkg.BrowserAndroidWebView,
+		browserVersion: "118.0",
+		os:             pkg.OSAndroid,
+		osVersion:      "13",
+	},
+	{
+		ua:             "Mozilla/5.0 (iPhone; CPU iPhone OS 17_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148",
+		browser:        pkg.BrowserIOSWebView,
+		browserVersion: "",
+		os:             pkg.OSiOS,
+		osVersion:      "17.1",
+	},
+	{
+		ua:             "Mozilla/5.0 (iPad; CPU OS 16_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148 ExampleApp/2.4",
+		browser:        pkg.BrowserIOSWebView,
+		browserVersion: "",
+		os:             pkg.OSiOS,
+		osVersion:      "16.6",
+	},
+}
+
 var userAgentsAll = mergeUserAgentLists(userAgentsEdge,
 	userAgentsOpera,
 	userAgentsFirefox,
 	userAgentsChrome,
 	userAgentsSafari,
-	userAgentsIE)
+	userAgentsIE,
+	userAgentsSamsung,
+	userAgentsBrave,
+	userAgentsVivaldi,
+	userAgentsYandex,
+	userAgentsUC,
+	userAgentsDuckDuckGo,
+	userAgentsInApp)
 
 func mergeUserAgentLists(ua ...[]testUserAgent) []testUserAgent {
 	list := make([]testUserAgent, 0)
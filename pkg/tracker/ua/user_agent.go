@@ -5,6 +5,7 @@ import (
 	"github.com/pirsch-analytics/pirsch/v6/pkg"
 	"github.com/pirsch-analytics/pirsch/v6/pkg/model"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 	"unicode"
@@ -41,6 +42,7 @@ func Parse(r *http.Request) model.UserAgent {
 	}
 
 	userAgent.Mobile = getMobile(r)
+	userAgent.DeviceType, userAgent.DeviceVendor, userAgent.DeviceModel = getDevice(r, userAgent.OS, userAgent.Mobile)
 	return userAgent
 }
 
@@ -100,7 +102,7 @@ func mapOS(system []string) (string, string) {
 	}
 
 	if os == pkg.OSWindows {
-		return os, windowsVersions[getOSVersion(system[1], 1)]
+		return os, getWindowsVersionFromCH(system[1])
 	}
 
 	return os, getOSVersion(system[1], 1)
@@ -118,8 +120,34 @@ func getBrowser(products []string, system []string, os string) (string, string)
 	productChrome := ""
 	productSafari := ""
 
-	for _, product := range products {
-		if strings.HasPrefix(product, "Chrome/") {
+	for i, product := range products {
+		// in-app browsers and browsers based on Chromium or WebKit must be checked before looking at Chrome and Safari
+		if strings.HasPrefix(product, "SamsungBrowser/") {
+			return pkg.BrowserSamsung, getProductVersion(product, 1)
+		} else if strings.HasPrefix(product, "Brave/") || product == "Brave" {
+			return pkg.BrowserBrave, getProductVersion(product, 1)
+		} else if strings.HasPrefix(product, "Vivaldi/") {
+			return pkg.BrowserVivaldi, getProductVersion(product, 1)
+		} else if strings.HasPrefix(product, "YaBrowser/") {
+			return pkg.BrowserYandex, getProductVersion(product, 1)
+		} else if strings.HasPrefix(product, "UCBrowser/") {
+			return pkg.BrowserUC, getProductVersion(product, 1)
+		} else if strings.HasPrefix(product, "DuckDuckGo/") || strings.HasPrefix(product, "Ddg/") {
+			return pkg.BrowserDuckDuckGo, getProductVersion(product, 1)
+		} else if product == "Instagram" {
+			// Instagram separates the version by a space: "Instagram 305.0.0.34.110"
+			if i+1 < len(products) {
+				return pkg.BrowserInstagram, getOSVersion(products[i+1], 1)
+			}
+
+			return pkg.BrowserInstagram, ""
+		} else if strings.HasPrefix(product, "[FB") || strings.HasPrefix(product, "FBAN/") || strings.HasPrefix(product, "FBAV/") {
+			return pkg.BrowserFacebook, getFacebookVersion(products[i:])
+		} else if strings.HasPrefix(product, "musical_ly_") {
+			return pkg.BrowserTikTok, getOSVersion(product[len("musical_ly_"):], 1)
+		} else if strings.HasPrefix(product, "trill_") || strings.HasPrefix(product, "BytedanceWebview/") {
+			return pkg.BrowserTikTok, ""
+		} else if strings.HasPrefix(product, "Chrome/") {
 			productChrome = product
 		} else if strings.HasPrefix(product, "Safari/") {
 			productSafari = product
@@ -136,9 +164,17 @@ func getBrowser(products []string, system []string, os string) (string, string)
 		}
 	}
 
+	// Android apps add "wv" to the system information when showing a website inside a WebView
+	if os == pkg.OSAndroid && productChrome != "" && findPrefix(system, "wv") != "" {
+		return pkg.BrowserAndroidWebView, getProductVersion(productChrome, 1)
+	}
+
 	// When we made it to this point, it's gone get ugly and inaccurate, as Safari and Chrome send almost identical
 	// user agents most of the time. But anything coming from Mac or iOS is most likely Safari, I guess...
-	if (os == pkg.OSMac || os == pkg.OSiOS) && productSafari != "" && productChrome == "" {
+	if os == pkg.OSiOS && productSafari == "" && productChrome == "" {
+		// WKWebView drops the Safari/ and Version/ product strings, which are added by Safari itself
+		browser = pkg.BrowserIOSWebView
+	} else if (os == pkg.OSMac || os == pkg.OSiOS) && productSafari != "" && productChrome == "" {
 		browser = pkg.BrowserSafari
 		version = getSafariVersion(products, productSafari)
 	} else if productChrome != "" {
@@ -159,6 +195,19 @@ func getMobile(r *http.Request) null.Bool {
 	return null.NewBool(false, false)
 }
 
+// getFacebookVersion returns the app version from the Facebook in-app browser product list.
+// Example: "[FBAN/FBIOS;FBDV/iPhone14,2;FBMD/iPhone;FBSN/iOS;FBSV/17.0.3;FBSS/3;FBID/phone;FBLC/en_US;FBOP/5]".
+func getFacebookVersion(products []string) string {
+	for _, product := range products {
+		if i := strings.Index(product, "FBAV/"); i > -1 {
+			version, _, _ := strings.Cut(product[i:], ";")
+			return getProductVersion(version, 1)
+		}
+	}
+
+	return ""
+}
+
 // older Safari versions send their version number inside the Version/ product string instead of the Safari/ part
 func getSafariVersion(products []string, productSafari string) string {
 	productVersion := findPrefix(products, "Version/")
@@ -193,6 +242,26 @@ func isIE(system []string) string {
 	return ""
 }
 
+// getWindowsVersionFromCH maps the Sec-CH-UA-Platform-Version to the Windows release.
+// Windows 11 reports 13 and above, Windows 10 1 to 10, and earlier versions 0.x.
+// https://learn.microsoft.com/en-us/microsoft-edge/web-platform/how-to-detect-win11
+func getWindowsVersionFromCH(version string) string {
+	major, minor, _ := strings.Cut(getOSVersion(version, 1), ".")
+	v, err := strconv.Atoi(major)
+
+	if err != nil {
+		return ""
+	}
+
+	if v >= 13 {
+		return "11"
+	} else if v > 0 {
+		return "10"
+	}
+
+	return windowsVersions["6."+minor]
+}
+
 func getWindowsMobileVersion(system string) string {
 	parts := strings.Split(system, " ")
 
@@ -333,7 +402,13 @@ func parse(r *http.Request) ([]string, []string, bool, bool) {
 		system = parseSystem(ua, systemStart, systemEnd)
 	}
 
-	chProduct := r.Header.Get("Sec-CH-UA")
+	// the full version list is only sent if requested using the Accept-CH header, see SetClientHintHeaders
+	chProduct := r.Header.Get("Sec-CH-UA-Full-Version-List")
+
+	if chProduct == "" {
+		chProduct = r.Header.Get("Sec-CH-UA")
+	}
+
 	productFromCH := false
 	var products []string
 
@@ -418,6 +493,16 @@ func parseProductsFromCH(header string) []string {
 				return []string{pkg.BrowserEdge, parseProductVersion(version)}
 			} else if strings.Contains(product, "Opera") {
 				return []string{pkg.BrowserOpera, parseProductVersion(version)}
+			} else if strings.Contains(product, "Samsung Internet") {
+				return []string{pkg.BrowserSamsung, parseProductVersion(version)}
+			} else if strings.Contains(product, "Brave") {
+				return []string{pkg.BrowserBrave, parseProductVersion(version)}
+			} else if strings.Contains(product, "Vivaldi") {
+				return []string{pkg.BrowserVivaldi, parseProductVersion(version)}
+			} else if strings.Contains(product, "YaBrowser") || strings.Contains(product, "Yandex") {
+				return []string{pkg.BrowserYandex, parseProductVersion(version)}
+			} else if strings.Contains(product, "DuckDuckGo") {
+				return []string{pkg.BrowserDuckDuckGo, parseProductVersion(version)}
 			} else if !strings.Contains(product, "Brand") && !strings.Contains(product, "Chromium") {
 				genericProduct = strings.Trim(product, `"' `)
 				genericVersion = parseProductVersion(version)
@@ -436,7 +521,7 @@ func parseProductVersion(version string) string {
 	version = strings.ToLower(version)
 
 	if strings.HasPrefix(version, `v="`) {
-		return strings.Trim(version[3:], `"`)
+		return getOSVersion(strings.Trim(version[3:], `"`), 1)
 	}
 
 	return ""
@@ -166,6 +166,18 @@ func TestParseClientHints(t *testing.T) {
 	assert.Equal(t, "87", ua.BrowserVersion)
 	assert.Equal(t, pkg.OSWindows, ua.OS)
 	assert.Equal(t, "11", ua.OSVersion)
+	req.Header.Set("Sec-CH-UA", `"Brave";v="119", "Chromium";v="119", "Not?A_Brand";v="24"`)
+	ua = Parse(req)
+	assert.Equal(t, pkg.BrowserBrave, ua.Browser)
+	assert.Equal(t, "119", ua.BrowserVersion)
+	req.Header.Set("Sec-CH-UA", `"Chromium";v="116", "Not)A;Brand";v="24", "YaBrowser";v="23"`)
+	ua = Parse(req)
+	assert.Equal(t, pkg.BrowserYandex, ua.Browser)
+	assert.Equal(t, "23", ua.BrowserVersion)
+	req.Header.Set("Sec-CH-UA", `"Not_A Brand";v="8", "Chromium";v="120", "Samsung Internet";v="23"`)
+	ua = Parse(req)
+	assert.Equal(t, pkg.BrowserSamsung, ua.Browser)
+	assert.Equal(t, "23", ua.BrowserVersion)
 }
 
 func TestParse(t *testing.T) {
@@ -215,3 +227,57 @@ func TestParse(t *testing.T) {
 		assert.False(t, productFromCH)
 	}
 }
+
+func TestParseClientHintsPlatformVersion(t *testing.T) {
+	input := []struct {
+		platform string
+		version  string
+	}{
+		{"Windows", "0.1.0"},
+		{"Windows", "0.3.0"},
+		{"Windows", "10.0.0"},
+		{"Windows", "13.0.0"},
+		{"Windows", "15.0.0"},
+		{"Windows", ""},
+		{"macOS", "10.15.7"},
+		{"macOS", "14.1.0"},
+		{"macOS", "15.0"},
+	}
+	expected := []struct {
+		os      string
+		version string
+	}{
+		{pkg.OSWindows, "7"},
+		{pkg.OSWindows, "8"},
+		{pkg.OSWindows, "10"},
+		{pkg.OSWindows, "11"},
+		{pkg.OSWindows, "11"},
+		{pkg.OSWindows, ""},
+		{pkg.OSMac, "10.15"},
+		{pkg.OSMac, "14.1"},
+		{pkg.OSMac, "15.0"},
+	}
+
+	for i, in := range input {
+		req, _ := http.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/119.0.0.0 Safari/537.36")
+		req.Header.Set("Sec-CH-UA-Platform", fmt.Sprintf(`"%s"`, in.platform))
+		req.Header.Set("Sec-CH-UA-Platform-Version", fmt.Sprintf(`"%s"`, in.version))
+		ua := Parse(req)
+		assert.Equal(t, expected[i].os, ua.OS)
+		assert.Equal(t, expected[i].version, ua.OSVersion)
+	}
+}
+
+func TestParseClientHintsFullVersionList(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/119.0.0.0 Safari/537.36")
+	req.Header.Set("Sec-CH-UA", `"Google Chrome";v="119", "Chromium";v="119", "Not?A_Brand";v="24"`)
+	ua := Parse(req)
+	assert.Equal(t, pkg.BrowserChrome, ua.Browser)
+	assert.Equal(t, "119", ua.BrowserVersion)
+	req.Header.Set("Sec-CH-UA-Full-Version-List", `"Google Chrome";v="119.0.6045.160", "Chromium";v="119.0.6045.160", "Not?A_Brand";v="24.0.0.0"`)
+	ua = Parse(req)
+	assert.Equal(t, pkg.BrowserChrome, ua.Browser)
+	assert.Equal(t, "119.0", ua.BrowserVersion)
+}
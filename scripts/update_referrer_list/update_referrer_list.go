@@ -7,9 +7,9 @@ import (
 	"log"
 	"net/http"
 	"os"
-	"os/exec"
 	"sort"
 	"strings"
+	"time"
 )
 
 const (
@@ -23,7 +23,7 @@ type domain struct {
 
 type list map[string]map[string]domain
 
-// run this script from the root directory to generate the list.go
+// run this script from the root directory to generate the groups.txt
 func main() {
 	downloadSnowplowList()
 	fromSnowplow := loadList(snowplowList)
@@ -33,7 +33,6 @@ func main() {
 	addGroups(groups, mapping)
 	keys := getKeys(groups)
 	writeList(groups, keys)
-	formatCode()
 	log.Println("Done!")
 }
 
@@ -96,30 +95,17 @@ func getKeys(groups map[string]string) []string {
 func writeList(groups map[string]string, keys []string) {
 	log.Println("Writing list")
 	var out strings.Builder
-	out.WriteString(`package referrer
+	out.WriteString(fmt.Sprintf("# version: %s\n", time.Now().UTC().Format(time.DateOnly)))
+	out.WriteString(`# Maps referrer hosts (and paths) to a group name.
+# This file is generated by scripts/update_referrer_list/update_referrer_list.go from mapping-snowplow.json and mapping.json.
 
-var (
-	groups = map[string]string{
 `)
 
 	for _, key := range keys {
-		out.WriteString(fmt.Sprintf(`"%s": "%s",`, key, groups[key]))
-		out.WriteRune('\n')
+		out.WriteString(fmt.Sprintf("%s\t%s\n", key, groups[key]))
 	}
 
-	out.WriteString(`}
-)`)
-
-	if err := os.WriteFile("pkg/tracker/referrer/list.go", []byte(out.String()), 0644); err != nil {
-		log.Fatal(err)
-	}
-}
-
-func formatCode() {
-	log.Println("Formatting code")
-	cmd := exec.Command("go", "fmt", "./...")
-
-	if err := cmd.Run(); err != nil {
+	if err := os.WriteFile("pkg/tracker/referrer/groups.txt", []byte(out.String()), 0644); err != nil {
 		log.Fatal(err)
 	}
 }